@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid"
+	"github.com/wearevest/plaidgo/plaid/core"
+	"github.com/wearevest/plaidgo/plaid/webhooks"
+)
+
+// app wires the subsystems a Plaid integration is built from: link tokens are handed out
+// by a LinkTokenCache, public tokens are exchanged for access tokens, TRANSACTIONS webhooks
+// received by a webhooks.Router schedule syncs in an outbox, an OutboxDispatcher syncs
+// them into a TransactionSyncStore, and a Canary watches the health of the linked items.
+//
+// Everything is kept in memory; a real service swaps the memory stores for its database.
+type app struct {
+	client     *plaid.Client
+	linkTokens *plaid.LinkTokenCache
+	syncStore  *plaid.MemoryTransactionSyncStore
+	outbox     *plaid.MemoryOutboxStore
+	dispatcher *plaid.OutboxDispatcher
+	router     *webhooks.Router
+	canary     *plaid.Canary
+
+	mu     sync.Mutex
+	tokens map[plaid.ItemID]string // item id -> access token
+}
+
+func newApp(client *plaid.Client, verifyWebhooks bool) *app {
+	a := &app{
+		client:     client,
+		linkTokens: plaid.NewLinkTokenCache(client, 0),
+		syncStore:  plaid.NewMemoryTransactionSyncStore(),
+		outbox:     plaid.NewMemoryOutboxStore(),
+		router:     webhooks.NewRouter(),
+		tokens:     map[plaid.ItemID]string{},
+	}
+	a.dispatcher = plaid.NewOutboxDispatcher(client, plaid.OutboxDispatcherConfig{
+		Store:       a.outbox,
+		AccessToken: a.accessToken,
+		SyncStore:   a.syncStore,
+		Backoff:     2 * time.Second,
+		OnDeadLetter: func(entry plaid.OutboxEntry) {
+			log.Printf("sync of item %s given up after %d attempts: %s", entry.ItemID, entry.Attempts,
+				entry.LastError)
+		},
+		OnError: func(entry plaid.OutboxEntry, err error) {
+			log.Printf("sync of item %s failed: %v", entry.ItemID, err)
+		},
+	})
+	a.canary = plaid.NewCanary(client, plaid.CanaryConfig{Tokens: a})
+
+	if verifyWebhooks {
+		a.router.Verifier = webhooks.NewVerifier(client.WebhookKeySource())
+	}
+	a.router.Retry = webhooks.RetryPolicy{Attempts: 3, Backoff: time.Second}
+	a.router.OnDeadLetter = func(ctx context.Context, letter webhooks.DeadLetter) error {
+		log.Printf("webhook dropped after %d attempts: %v", letter.Attempts, letter.Err)
+		return nil
+	}
+	a.dispatcher.HandleWebhooks(a.router)
+	a.router.OnItem(a.handleItemWebhook)
+	return a
+}
+
+// linkToken returns a link token for a user of the app.
+func (a *app) linkToken(ctx context.Context, clientUserID, webhookURL string) (*plaid.LinkTokenCreateResponse, error) {
+	return a.linkTokens.LinkToken(ctx, plaid.LinkUser{ClientUserID: clientUserID}, plaid.LinkTokenConfig{
+		ClientName:   "plaid-go sandbox app",
+		Language:     "en",
+		CountryCodes: []string{"US"},
+		Products:     []string{"transactions"},
+		Options:      &plaid.LinkTokenCreateOptions{Webhook: webhookURL},
+	})
+}
+
+// connect exchanges the public token Link returned to the user and stores the item's
+// access token. It returns the item's id.
+func (a *app) connect(ctx context.Context, clientUserID, publicToken string) (plaid.ItemID, error) {
+	res, err := a.client.ItemPublicTokenExchangeContext(ctx, publicToken)
+	if err != nil {
+		return "", err
+	}
+	a.linkTokens.Forget(clientUserID)
+	a.mu.Lock()
+	a.tokens[res.ItemID] = res.AccessToken
+	a.mu.Unlock()
+	return res.ItemID, nil
+}
+
+// disconnect removes an item at Plaid and forgets its access token.
+func (a *app) disconnect(ctx context.Context, itemID plaid.ItemID) error {
+	accessToken, err := a.accessToken(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if _, err = a.client.ItemRemoveContext(ctx, accessToken); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	delete(a.tokens, itemID)
+	a.mu.Unlock()
+	return nil
+}
+
+// scheduleSync records a sync of an item in the outbox, the way the router does for a
+// SYNC_UPDATES_AVAILABLE webhook.
+func (a *app) scheduleSync(ctx context.Context, itemID plaid.ItemID, reason string) error {
+	return a.outbox.Enqueue(ctx, plaid.OutboxEntry{ItemID: itemID, Reason: reason, ScheduledAt: time.Now()})
+}
+
+// transactions returns the synced transactions of an item.
+func (a *app) transactions(ctx context.Context, itemID plaid.ItemID) ([]plaid.Transaction, error) {
+	accessToken, err := a.accessToken(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	return a.syncStore.Transactions(accessToken), nil
+}
+
+// health checks every linked item and explains how to fix the ones in an error state.
+func (a *app) health(ctx context.Context) (*plaid.CanaryReport, error) {
+	report, err := a.canary.Check(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range report.TopErrors() {
+		action := plaid.ActionRetry
+		if code != plaid.CanaryTransportError {
+			action = plaid.Remediation(core.Error{ErrorCode: code}).Action
+		}
+		log.Printf("%d items with %s: %s", report.Outcomes[code], code, action)
+	}
+	return report, nil
+}
+
+func (a *app) handleItemWebhook(ctx context.Context, webhook *webhooks.ItemWebhook) error {
+	if webhook.Error == nil {
+		log.Printf("item %s: %s", webhook.ItemID, webhook.WebhookCode)
+		return nil
+	}
+	steps := plaid.Remediation(*webhook.Error)
+	log.Printf("item %s: %s, %s", webhook.ItemID, webhook.Error.ErrorCode, steps.Action)
+	return nil
+}
+
+// AccessTokens implements plaid.TokenStore for the Canary.
+func (a *app) AccessTokens(ctx context.Context) ([]string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tokens := make([]string, 0, len(a.tokens))
+	for _, token := range a.tokens {
+		tokens = append(tokens, token)
+	}
+	sort.Strings(tokens)
+	return tokens, nil
+}
+
+func (a *app) accessToken(ctx context.Context, itemID plaid.ItemID) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	token, ok := a.tokens[itemID]
+	if !ok {
+		return "", errors.New("unknown item " + string(itemID))
+	}
+	return token, nil
+}
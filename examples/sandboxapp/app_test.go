@@ -0,0 +1,19 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRunFake runs the example end to end against the fake server of package plaidtest.
+func TestRunFake(t *testing.T) {
+	defer func(f bool, d time.Duration) { *fake, *timeout = f, d }(*fake, *timeout)
+	*fake, *timeout = true, 30*time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := run(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
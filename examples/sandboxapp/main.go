@@ -0,0 +1,222 @@
+// Command sandboxapp is an end-to-end example of a Plaid integration built from the
+// subsystems of package plaid. It links an item, receives its TRANSACTIONS webhooks,
+// syncs its transactions through an outbox into a cursor store and checks the item's
+// health, and exits with a non-zero status if any step fails, so running it verifies that
+// the subsystems compose.
+//
+// Against Plaid's Sandbox, with the credentials in PLAID_CLIENT_ID and PLAID_SECRET:
+//
+//	PLAID_ENV=sandbox go run ./examples/sandboxapp
+//
+// Sandbox can't post webhooks to a local receiver, so the app schedules the syncs itself
+// unless -webhook-url is the public URL of the receiver, e.g. of a tunnel to -addr.
+//
+// Against the fake server of package plaidtest, without credentials or network access:
+//
+//	go run ./examples/sandboxapp -fake
+//
+// The repository has no go.mod; see the README for running the example in GOPATH mode.
+//
+// With -serve the app keeps receiving webhooks and syncing after the checks until it is
+// interrupted.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid"
+	"github.com/wearevest/plaidgo/plaid/plaidtest"
+	"github.com/wearevest/plaidgo/plaid/webhooks"
+)
+
+// institutionID is First Platypus Bank, Sandbox's institution without MFA.
+const institutionID = "ins_109508"
+
+var (
+	fake       = flag.Bool("fake", false, "run against the fake server of package plaidtest instead of Sandbox")
+	addr       = flag.String("addr", "127.0.0.1:0", "address of the webhook receiver")
+	webhookURL = flag.String("webhook-url", "", "public URL Plaid posts the item's webhooks to")
+	serve      = flag.Bool("serve", false, "keep serving webhooks and syncing after the checks")
+	timeout    = flag.Duration("timeout", 2*time.Minute, "how long to wait for the first transactions")
+)
+
+func main() {
+	flag.Parse()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := run(ctx); err != nil {
+		log.Fatal(err)
+	}
+	log.Print("all checks passed")
+}
+
+func run(ctx context.Context) error {
+	var client *plaid.Client
+	if *fake {
+		server := startFakeServer()
+		defer server.Close()
+		client = server.Client()
+	} else {
+		var err error
+		if client, err = plaid.NewClientFromEnv(); err != nil {
+			return err
+		}
+	}
+	a := newApp(client, !*fake)
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	receiver := &http.Server{Handler: (&webhooks.Server{Router: a.router}).Handler()}
+	go receiver.Serve(listener)
+	defer receiver.Close()
+	receiverURL := "http://" + listener.Addr().String() + "/plaid/webhook"
+	log.Printf("receiving webhooks at %s", receiverURL)
+
+	// 1. The user opens Link with a link token.
+	const userID = "sandboxapp-user"
+	linkToken, err := a.linkToken(ctx, userID, *webhookURL)
+	if err != nil {
+		return check("create link token", err)
+	}
+	log.Printf("link token %s expires at %s", linkToken.LinkToken, linkToken.Expiration)
+
+	// 2. Link returns a public token, which Sandbox creates without the user.
+	publicToken, err := client.SandboxPublicTokenCreateContext(ctx, institutionID, []string{"transactions"})
+	if err != nil {
+		return check("create public token", err)
+	}
+	itemID, err := a.connect(ctx, userID, publicToken.PublicToken)
+	if err != nil {
+		return check("exchange public token", err)
+	}
+	log.Printf("linked item %s", itemID)
+
+	// 3. Plaid announces transactions with a webhook, which schedules a sync.
+	if *fake {
+		if err = postWebhook(ctx, receiverURL, itemID); err != nil {
+			return check("receive webhook", err)
+		}
+	} else if *webhookURL == "" {
+		if err = a.scheduleSync(ctx, itemID, "TRANSACTIONS/SYNC_UPDATES_AVAILABLE"); err != nil {
+			return check("schedule sync", err)
+		}
+	}
+
+	// 4. The dispatcher runs the sync into the cursor store.
+	transactions, err := awaitTransactions(ctx, a, itemID)
+	if err != nil {
+		return check("sync transactions", err)
+	}
+	log.Printf("synced %d transactions", len(transactions))
+
+	// 5. The canary checks the health of the linked items.
+	report, err := a.health(ctx)
+	if err != nil {
+		return check("check item health", err)
+	}
+	if report.Healthy() != report.Sampled {
+		return check("check item health", fmt.Errorf("%d of %d items unhealthy", report.Sampled-report.Healthy(),
+			report.Sampled))
+	}
+	log.Printf("%d of %d items healthy", report.Healthy(), report.Sampled)
+
+	if *serve {
+		if err = a.dispatcher.Start(ctx); err != nil {
+			return err
+		}
+		defer a.dispatcher.Close()
+		log.Print("serving until interrupted")
+		<-ctx.Done()
+		ctx = context.WithoutCancel(ctx)
+	}
+
+	// 6. The user disconnects the item.
+	if err = a.disconnect(ctx, itemID); err != nil {
+		return check("remove item", err)
+	}
+	return nil
+}
+
+// awaitTransactions dispatches the scheduled syncs until the item has transactions.
+// Sandbox takes a few seconds to fetch the transactions of a new item; without webhooks
+// the sync is scheduled again until they arrive.
+func awaitTransactions(ctx context.Context, a *app, itemID plaid.ItemID) ([]plaid.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	for {
+		if _, err := a.dispatcher.DispatchDue(ctx); err != nil {
+			return nil, err
+		}
+		transactions, err := a.transactions(ctx, itemID)
+		if err != nil {
+			return nil, err
+		}
+		if len(transactions) > 0 {
+			return transactions, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.New("no transactions before the timeout")
+		case <-time.After(3 * time.Second):
+		}
+		if !*fake && *webhookURL == "" {
+			if err = a.scheduleSync(ctx, itemID, "TRANSACTIONS/SYNC_UPDATES_AVAILABLE"); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// postWebhook posts the webhook the fake server would have sent to the receiver.
+func postWebhook(ctx context.Context, receiverURL string, itemID plaid.ItemID) error {
+	body := `{"webhook_type": "TRANSACTIONS", "webhook_code": "SYNC_UPDATES_AVAILABLE", "item_id": "` +
+		string(itemID) + `", "initial_update_complete": true, "historical_update_complete": true}`
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, receiverURL, bytes.NewReader([]byte(body)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("receiver responded %s", res.Status)
+	}
+	return nil
+}
+
+// startFakeServer starts a fake server with one item at institutionID.
+func startFakeServer() *plaidtest.Server {
+	server := plaidtest.NewServer()
+	account := plaid.Account{AccountID: "fake-checking", Name: "Plaid Checking", Type: "depository", Mask: "0000"}
+	server.AddItem(plaidtest.Item{
+		AccessToken: "access-sandbox-fake",
+		PublicToken: "public-sandbox-fake",
+		Item:        plaid.Item{ItemId: "fake-item", InstitutionId: institutionID},
+		Accounts:    []plaid.Account{account},
+		Transactions: []plaid.Transaction{
+			{TransactionID: "fake-1", AccountID: account.AccountID, Name: "Uber", Amount: 6.33, Date: "2024-03-01"},
+			{TransactionID: "fake-2", AccountID: account.AccountID, Name: "Starbucks", Amount: 4.33, Date: "2024-03-02"},
+			{TransactionID: "fake-3", AccountID: account.AccountID, Name: "United Airlines", Amount: 500, Date: "2024-03-04"},
+		},
+	})
+	return server
+}
+
+func check(step string, err error) error {
+	return fmt.Errorf("%s: %w", step, err)
+}
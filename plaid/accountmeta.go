@@ -0,0 +1,125 @@
+package plaid
+
+import (
+	"context"
+	"sync"
+)
+
+// AccountMetadata is application data attached to a Plaid account, such as the nickname a
+// user gave it.
+type AccountMetadata struct {
+	Nickname    string            `json:"nickname,omitempty"`
+	Hidden      bool              `json:"hidden,omitempty"`
+	BudgetGroup string            `json:"budget_group,omitempty"`
+	Extra       map[string]string `json:"extra,omitempty"`
+}
+
+// AccountMetadataStore persists AccountMetadata by account id.
+type AccountMetadataStore interface {
+	// AccountMetadata returns the metadata of the given accounts. Accounts without metadata
+	// are left out of the result.
+	AccountMetadata(ctx context.Context, accountIDs []AccountID) (map[AccountID]AccountMetadata, error)
+	SetAccountMetadata(ctx context.Context, accountID AccountID, metadata AccountMetadata) error
+}
+
+// MemoryAccountMetadataStore is an AccountMetadataStore that keeps metadata in memory. It
+// doesn't survive restarts and is meant for tests.
+type MemoryAccountMetadataStore struct {
+	mu       sync.Mutex
+	metadata map[AccountID]AccountMetadata
+}
+
+// NewMemoryAccountMetadataStore instantiates an empty MemoryAccountMetadataStore.
+func NewMemoryAccountMetadataStore() *MemoryAccountMetadataStore {
+	return &MemoryAccountMetadataStore{metadata: map[AccountID]AccountMetadata{}}
+}
+
+// AccountMetadata implements AccountMetadataStore.
+func (s *MemoryAccountMetadataStore) AccountMetadata(ctx context.Context,
+	accountIDs []AccountID) (map[AccountID]AccountMetadata, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := map[AccountID]AccountMetadata{}
+	for _, id := range accountIDs {
+		if metadata, ok := s.metadata[id]; ok {
+			result[id] = metadata
+		}
+	}
+	return result, nil
+}
+
+// SetAccountMetadata implements AccountMetadataStore.
+func (s *MemoryAccountMetadataStore) SetAccountMetadata(ctx context.Context, accountID AccountID,
+	metadata AccountMetadata) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metadata[accountID] = metadata
+	return nil
+}
+
+// DecoratedAccount is an account joined with its metadata.
+type DecoratedAccount struct {
+	Account
+	Metadata AccountMetadata
+}
+
+// DisplayName returns the account's nickname, falling back to the name the institution
+// gave it.
+func (a DecoratedAccount) DisplayName() string {
+	if a.Metadata.Nickname != "" {
+		return a.Metadata.Nickname
+	}
+	return a.Name
+}
+
+// DecorateAccounts joins accounts with their metadata from store in a single lookup.
+// Accounts without metadata get the zero AccountMetadata.
+func DecorateAccounts(ctx context.Context, store AccountMetadataStore,
+	accounts []Account) ([]DecoratedAccount, error) {
+
+	ids := make([]AccountID, len(accounts))
+	for i, account := range accounts {
+		ids[i] = account.AccountID
+	}
+	metadata, err := store.AccountMetadata(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	decorated := make([]DecoratedAccount, len(accounts))
+	for i, account := range accounts {
+		decorated[i] = DecoratedAccount{Account: account, Metadata: metadata[account.AccountID]}
+	}
+	return decorated, nil
+}
+
+// VisibleAccounts returns the accounts that are not hidden.
+func VisibleAccounts(accounts []DecoratedAccount) []DecoratedAccount {
+	var visible []DecoratedAccount
+	for _, account := range accounts {
+		if !account.Metadata.Hidden {
+			visible = append(visible, account)
+		}
+	}
+	return visible
+}
+
+// AccountsWithMetadata fetches the accounts of an item from /accounts/get and joins them
+// with their metadata from store.
+func (c *Client) AccountsWithMetadata(accessToken string,
+	store AccountMetadataStore) ([]DecoratedAccount, error) {
+
+	return c.AccountsWithMetadataContext(context.Background(), accessToken, store)
+}
+
+// AccountsWithMetadataContext is like AccountsWithMetadata but carries a context.
+func (c *Client) AccountsWithMetadataContext(ctx context.Context, accessToken string,
+	store AccountMetadataStore) ([]DecoratedAccount, error) {
+
+	res, err := c.AccountsContext(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return DecorateAccounts(ctx, store, res.Accounts)
+}
@@ -0,0 +1,144 @@
+package plaid
+
+import (
+	"strings"
+)
+
+// The account types Plaid reports in Account.Type.
+//
+// See https://plaid.com/docs/api/accounts/#account-type-schema.
+const (
+	AccountTypeDepository = "depository"
+	AccountTypeCredit     = "credit"
+	AccountTypeLoan       = "loan"
+	AccountTypeInvestment = "investment"
+	AccountTypeOther      = "other"
+)
+
+// Common account subtypes Plaid reports in Account.Subtype. Note the space in "credit card"
+// and "money market".
+const (
+	AccountSubtypeChecking    = "checking"
+	AccountSubtypeSavings     = "savings"
+	AccountSubtypeMoneyMarket = "money market"
+	AccountSubtypeCD          = "cd"
+	AccountSubtypeCreditCard  = "credit card"
+	AccountSubtypePaypal      = "paypal"
+	AccountSubtypeMortgage    = "mortgage"
+	AccountSubtypeStudent     = "student"
+	AccountSubtype401k        = "401k"
+	AccountSubtypeIRA         = "ira"
+	AccountSubtypeBrokerage   = "brokerage"
+)
+
+// normalizeAccountType lowercases a type or subtype and spells "credit_card" the way Plaid
+// does, as "credit card".
+func normalizeAccountType(s string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "_", " ")
+}
+
+func (a *Account) isType(accountType string) bool {
+	return normalizeAccountType(a.Type) == accountType
+}
+
+func (a *Account) isSubtype(subtype string) bool {
+	return normalizeAccountType(a.Subtype) == subtype
+}
+
+// IsDepository reports whether the account is a depository account, e.g. a checking or
+// savings account.
+func (a *Account) IsDepository() bool {
+	return a.isType(AccountTypeDepository)
+}
+
+// IsCredit reports whether the account is a credit account, e.g. a credit card.
+func (a *Account) IsCredit() bool {
+	return a.isType(AccountTypeCredit)
+}
+
+// IsLoan reports whether the account is a loan, e.g. a mortgage or student loan.
+func (a *Account) IsLoan() bool {
+	return a.isType(AccountTypeLoan)
+}
+
+// IsInvestment reports whether the account is an investment account. Older API versions
+// report investment accounts with the type "brokerage".
+func (a *Account) IsInvestment() bool {
+	return a.isType(AccountTypeInvestment) || a.isType("brokerage")
+}
+
+// IsChecking reports whether the account is a checking account.
+func (a *Account) IsChecking() bool {
+	return a.IsDepository() && a.isSubtype(AccountSubtypeChecking)
+}
+
+// IsSavings reports whether the account is a savings account. Money market accounts and
+// CDs are not included.
+func (a *Account) IsSavings() bool {
+	return a.IsDepository() && a.isSubtype(AccountSubtypeSavings)
+}
+
+// IsCreditCard reports whether the account is a credit card.
+func (a *Account) IsCreditCard() bool {
+	return a.IsCredit() && a.isSubtype(AccountSubtypeCreditCard)
+}
+
+// Accounts is a list of accounts, as returned by the accounts, balance and identity
+// endpoints, with filters for the common account types.
+type Accounts []Account
+
+// Filter returns the accounts keep returns true for, in their original order.
+func (accounts Accounts) Filter(keep func(account *Account) bool) Accounts {
+	var filtered Accounts
+	for i := range accounts {
+		if keep(&accounts[i]) {
+			filtered = append(filtered, accounts[i])
+		}
+	}
+	return filtered
+}
+
+// ByID returns the account with the given id.
+func (accounts Accounts) ByID(accountID AccountID) (*Account, bool) {
+	for i := range accounts {
+		if accounts[i].AccountID == accountID {
+			return &accounts[i], true
+		}
+	}
+	return nil, false
+}
+
+// Depository returns the depository accounts.
+func (accounts Accounts) Depository() Accounts {
+	return accounts.Filter((*Account).IsDepository)
+}
+
+// Credit returns the credit accounts.
+func (accounts Accounts) Credit() Accounts {
+	return accounts.Filter((*Account).IsCredit)
+}
+
+// Loans returns the loan accounts.
+func (accounts Accounts) Loans() Accounts {
+	return accounts.Filter((*Account).IsLoan)
+}
+
+// Investments returns the investment accounts.
+func (accounts Accounts) Investments() Accounts {
+	return accounts.Filter((*Account).IsInvestment)
+}
+
+// Checking returns the checking accounts.
+func (accounts Accounts) Checking() Accounts {
+	return accounts.Filter((*Account).IsChecking)
+}
+
+// Savings returns the savings accounts.
+func (accounts Accounts) Savings() Accounts {
+	return accounts.Filter((*Account).IsSavings)
+}
+
+// CreditCards returns the credit cards.
+func (accounts Accounts) CreditCards() Accounts {
+	return accounts.Filter((*Account).IsCreditCard)
+}
@@ -0,0 +1,131 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+)
+
+// ItemApplicationList (POST /item/application/list) lists the applications that have
+// access to an item's data through Data Transparency Messaging.
+//
+// See https://plaid.com/docs/api/items/#itemapplicationlist.
+func (c *Client) ItemApplicationList(accessToken string) ([]ConnectedApplication, error) {
+	return c.ItemApplicationListContext(context.Background(), accessToken)
+}
+
+// ItemApplicationListContext is like ItemApplicationList but carries a context.
+func (c *Client) ItemApplicationListContext(ctx context.Context, accessToken string) ([]ConnectedApplication, error) {
+	var res itemApplicationListResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/item/application/list", itemJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Applications, nil
+}
+
+// ItemApplicationScopesUpdate (POST /item/application/scopes/update) updates the data an
+// application is allowed to access for an item. scopesContext is ScopesContextEnrollment
+// when the scopes are collected while connecting the item and ScopesContextPortal when the
+// user changes them later.
+//
+// See https://plaid.com/docs/api/items/#itemapplicationscopesupdate.
+func (c *Client) ItemApplicationScopesUpdate(accessToken, applicationID string, scopes ApplicationScopes,
+	scopesContext ScopesContext, state string) error {
+	return c.ItemApplicationScopesUpdateContext(context.Background(), accessToken, applicationID,
+		scopes, scopesContext, state)
+}
+
+// ItemApplicationScopesUpdateContext is like ItemApplicationScopesUpdate but carries a context.
+func (c *Client) ItemApplicationScopesUpdateContext(ctx context.Context, accessToken, applicationID string,
+	scopes ApplicationScopes, scopesContext ScopesContext, state string) error {
+
+	if applicationID == "" {
+		return errors.New("/item/application/scopes/update - application id must be specified")
+	}
+	if scopesContext != ScopesContextEnrollment && scopesContext != ScopesContextPortal {
+		return errors.New("/item/application/scopes/update - context must be ENROLLMENT or PORTAL")
+	}
+	var res struct {
+		RequestID string `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	return c.postAndDecode(ctx, "/item/application/scopes/update", itemApplicationScopesUpdateJson{
+		ClientID:      creds.clientID,
+		Secret:        creds.secret,
+		AccessToken:   accessToken,
+		ApplicationID: applicationID,
+		Scopes:        scopes,
+		State:         state,
+		Context:       scopesContext,
+	}, &res)
+}
+
+// ConnectedApplication is an application with access to an item's data.
+//
+// See https://plaid.com/docs/api/items/#item-application-list-response-applications.
+type ConnectedApplication struct {
+	ApplicationID   string             `json:"application_id"`
+	Name            string             `json:"name"`
+	DisplayName     string             `json:"display_name"`
+	LogoURL         string             `json:"logo_url"`
+	ApplicationURL  string             `json:"application_url"`
+	ReasonForAccess string             `json:"reason_for_access"`
+	CreatedAt       string             `json:"created_at"` // e.g. "2021-03-22"
+	Scopes          *ApplicationScopes `json:"scopes"`
+}
+
+// ApplicationScopes describes the data an application may access.
+type ApplicationScopes struct {
+	ProductAccess *ProductAccess  `json:"product_access,omitempty"`
+	Accounts      []AccountAccess `json:"accounts,omitempty"`
+	// NewAccounts allows access to accounts added to the item later.
+	NewAccounts *bool `json:"new_accounts,omitempty"`
+}
+
+// ProductAccess lists the products an application may use. A nil field means the product
+// is allowed.
+type ProductAccess struct {
+	Statements                  *bool `json:"statements,omitempty"`
+	Identity                    *bool `json:"identity,omitempty"`
+	Auth                        *bool `json:"auth,omitempty"`
+	Transactions                *bool `json:"transactions,omitempty"`
+	AccountsDetailsTransactions *bool `json:"accounts_details_transactions,omitempty"`
+	AccountsRoutingNumber       *bool `json:"accounts_routing_number,omitempty"`
+	AccountsStatements          *bool `json:"accounts_statements,omitempty"`
+	AccountsTaxStatements       *bool `json:"accounts_tax_statements,omitempty"`
+	CustomersProfiles           *bool `json:"customers_profiles,omitempty"`
+}
+
+// AccountAccess allows or denies an application access to one account.
+type AccountAccess struct {
+	UniqueID   string `json:"unique_id"` // the account id
+	Authorized bool   `json:"authorized"`
+}
+
+// ScopesContext is where a scopes update was collected from.
+type ScopesContext string
+
+const (
+	ScopesContextEnrollment ScopesContext = "ENROLLMENT"
+	ScopesContextPortal     ScopesContext = "PORTAL"
+)
+
+type itemApplicationListResponse struct {
+	Applications []ConnectedApplication `json:"applications"`
+	RequestID    string                 `json:"request_id"`
+}
+
+type itemApplicationScopesUpdateJson struct {
+	ClientID      string            `json:"client_id"`
+	Secret        string            `json:"secret"`
+	AccessToken   string            `json:"access_token"`
+	ApplicationID string            `json:"application_id"`
+	Scopes        ApplicationScopes `json:"scopes"`
+	State         string            `json:"state,omitempty"`
+	Context       ScopesContext     `json:"context"`
+}
@@ -0,0 +1,88 @@
+package plaid
+
+// AssetReportChangeset describes what changed between two asset reports for the same user.
+type AssetReportChangeset struct {
+	NewAccounts     []AssetReportAccount
+	RemovedAccounts []AssetReportAccount
+	BalanceChanges  []BalanceChange
+	NewTransactions []Transaction
+}
+
+// BalanceChange is a change of the current or available balance of an account.
+type BalanceChange struct {
+	AccountID         AccountID
+	PreviousCurrent   float64
+	Current           float64
+	PreviousAvailable float64
+	Available         float64
+}
+
+// Empty reports whether the changeset contains no changes.
+func (c AssetReportChangeset) Empty() bool {
+	return len(c.NewAccounts) == 0 && len(c.RemovedAccounts) == 0 &&
+		len(c.BalanceChanges) == 0 && len(c.NewTransactions) == 0
+}
+
+// DiffAssetReports compares two retrievals of asset reports for the same user, e.g. the
+// original report and a refresh of it, and returns the accounts that were added or removed,
+// the balances that changed and the transactions that are new in current.
+//
+// Accounts are matched by account id and transactions by transaction id, so pending
+// transactions that posted in the meantime are reported as new.
+func DiffAssetReports(previous, current AssetReport) AssetReportChangeset {
+	var changes AssetReportChangeset
+
+	previousAccounts := assetReportAccounts(previous)
+	currentAccounts := assetReportAccounts(current)
+
+	for _, item := range current.Items {
+		for _, account := range item.Accounts {
+			before, ok := previousAccounts[account.AccountID]
+			if !ok {
+				changes.NewAccounts = append(changes.NewAccounts, account)
+				changes.NewTransactions = append(changes.NewTransactions, account.Transactions...)
+				continue
+			}
+			if before.Balances.Current != account.Balances.Current ||
+				before.Balances.Available != account.Balances.Available {
+				changes.BalanceChanges = append(changes.BalanceChanges, BalanceChange{
+					AccountID:         account.AccountID,
+					PreviousCurrent:   before.Balances.Current,
+					Current:           account.Balances.Current,
+					PreviousAvailable: before.Balances.Available,
+					Available:         account.Balances.Available,
+				})
+			}
+
+			seen := map[TransactionID]bool{}
+			for _, t := range before.Transactions {
+				seen[t.TransactionID] = true
+			}
+			for _, t := range account.Transactions {
+				if !seen[t.TransactionID] {
+					changes.NewTransactions = append(changes.NewTransactions, t)
+				}
+			}
+		}
+	}
+
+	for _, item := range previous.Items {
+		for _, account := range item.Accounts {
+			if _, ok := currentAccounts[account.AccountID]; !ok {
+				changes.RemovedAccounts = append(changes.RemovedAccounts, account)
+			}
+		}
+	}
+	return changes
+}
+
+// assetReportAccounts indexes the accounts of a report by account id.
+func assetReportAccounts(report AssetReport) map[AccountID]AssetReportAccount {
+	accounts := map[AccountID]AssetReportAccount{}
+	for _, item := range report.Items {
+		for _, account := range item.Accounts {
+			accounts[account.AccountID] = account
+		}
+	}
+	return accounts
+}
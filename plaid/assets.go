@@ -0,0 +1,184 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// MaxAssetReportDays is the largest number of days of history an asset report can cover.
+const MaxAssetReportDays = 731
+
+// AssetReportCreate (POST /asset_report/create) starts generating an asset report for one
+// or more items. The report is ready once the PRODUCT_READY webhook arrives and can then
+// be retrieved with AssetReportGet.
+//
+// See https://plaid.com/docs/api/products/assets/#asset_reportcreate.
+func (c *Client) AssetReportCreate(accessTokens []string, daysRequested int,
+	options *AssetReportCreateOptions) (*assetReportCreateResponse, error) {
+	return c.AssetReportCreateContext(context.Background(), accessTokens, daysRequested, options)
+}
+
+// AssetReportCreateContext is like AssetReportCreate but carries a context.
+func (c *Client) AssetReportCreateContext(ctx context.Context, accessTokens []string, daysRequested int,
+	options *AssetReportCreateOptions) (*assetReportCreateResponse, error) {
+
+	if len(accessTokens) == 0 {
+		return nil, errors.New("/asset_report/create - at least one access token must be specified")
+	}
+	if daysRequested < 0 || daysRequested > MaxAssetReportDays {
+		return nil, errors.New("/asset_report/create - days requested must be between 0 and " +
+			strconv.Itoa(MaxAssetReportDays))
+	}
+
+	var res assetReportCreateResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/asset_report/create", assetReportCreateJson{
+		ClientID:      creds.clientID,
+		Secret:        creds.secret,
+		AccessTokens:  accessTokens,
+		DaysRequested: daysRequested,
+		Options:       options,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AssetReportGet (POST /asset_report/get) retrieves a generated asset report.
+//
+// See https://plaid.com/docs/api/products/assets/#asset_reportget.
+func (c *Client) AssetReportGet(assetReportToken string,
+	options *AssetReportGetOptions) (*assetReportGetResponse, error) {
+	return c.AssetReportGetContext(context.Background(), assetReportToken, options)
+}
+
+// AssetReportGetContext is like AssetReportGet but carries a context.
+func (c *Client) AssetReportGetContext(ctx context.Context, assetReportToken string,
+	options *AssetReportGetOptions) (*assetReportGetResponse, error) {
+
+	creds := c.creds.Load()
+	request := assetReportGetJson{
+		ClientID:         creds.clientID,
+		Secret:           creds.secret,
+		AssetReportToken: assetReportToken,
+	}
+	if options != nil {
+		request.IncludeInsights = options.IncludeInsights
+		request.FastReport = options.FastReport
+	}
+	var res assetReportGetResponse
+	if err := c.postAndDecode(ctx, "/asset_report/get", request, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AssetReportCreateOptions represents options associated with creating an asset report.
+//
+// See https://plaid.com/docs/api/products/assets/#asset_reportcreate.
+type AssetReportCreateOptions struct {
+	ClientReportID string `json:"client_report_id,omitempty"`
+	Webhook        string `json:"webhook,omitempty"`
+	// IncludeFastReport additionally generates a report without transactions that is
+	// ready sooner, see AssetReportGetOptions.FastReport.
+	IncludeFastReport bool             `json:"include_fast_report,omitempty"`
+	User              *AssetReportUser `json:"user,omitempty"`
+}
+
+// AssetReportGetOptions represents options associated with retrieving an asset report.
+type AssetReportGetOptions struct {
+	// IncludeInsights adds merchant names and categories to the report's transactions.
+	IncludeInsights bool
+	// FastReport retrieves the report without transactions, which requires the report to
+	// be created with IncludeFastReport.
+	FastReport bool
+}
+
+// AssetReportUser identifies the user an asset report is about.
+type AssetReportUser struct {
+	ClientUserID string `json:"client_user_id,omitempty"`
+	FirstName    string `json:"first_name,omitempty"`
+	MiddleName   string `json:"middle_name,omitempty"`
+	LastName     string `json:"last_name,omitempty"`
+	SSN          string `json:"ssn,omitempty"`
+	PhoneNumber  string `json:"phone_number,omitempty"`
+	Email        string `json:"email,omitempty"`
+}
+
+// AssetReport is a point in time snapshot of a user's items, accounts, balances and
+// transactions.
+//
+// See https://plaid.com/docs/api/products/assets/#asset_reportget.
+type AssetReport struct {
+	AssetReportID  string            `json:"asset_report_id"`
+	ClientReportID string            `json:"client_report_id"`
+	DateGenerated  string            `json:"date_generated"`
+	DaysRequested  int               `json:"days_requested"`
+	User           AssetReportUser   `json:"user"`
+	Items          []AssetReportItem `json:"items"`
+}
+
+// AssetReportItem is an item as captured by an asset report.
+type AssetReportItem struct {
+	ItemID          ItemID               `json:"item_id"`
+	InstitutionID   string               `json:"institution_id"`
+	InstitutionName string               `json:"institution_name"`
+	DateLastUpdated string               `json:"date_last_updated"`
+	Accounts        []AssetReportAccount `json:"accounts"`
+}
+
+// AssetReportAccount is an account as captured by an asset report, including its
+// transactions and the history of its balance.
+type AssetReportAccount struct {
+	Account
+	DaysAvailable      float64             `json:"days_available"`
+	HistoricalBalances []HistoricalBalance `json:"historical_balances"`
+}
+
+// HistoricalBalance is the balance of an account at the end of a day.
+type HistoricalBalance struct {
+	Date                   string  `json:"date"`
+	Current                float64 `json:"current"`
+	IsoCurrencyCode        string  `json:"iso_currency_code"`
+	UnofficialCurrencyCode string  `json:"unofficial_currency_code"`
+}
+
+// AssetReportWarning describes data that couldn't be included in an asset report.
+type AssetReportWarning struct {
+	WarningType string `json:"warning_type"`
+	WarningCode string `json:"warning_code"`
+	Cause       struct {
+		ItemID ItemID     `json:"item_id"`
+		Error  plaidError `json:"error"`
+	} `json:"cause"`
+}
+
+type assetReportCreateResponse struct {
+	AssetReportToken string `json:"asset_report_token"`
+	AssetReportID    string `json:"asset_report_id"`
+	RequestID        string `json:"request_id"`
+}
+
+type assetReportGetResponse struct {
+	Report    AssetReport          `json:"report"`
+	Warnings  []AssetReportWarning `json:"warnings"`
+	RequestID string               `json:"request_id"`
+}
+
+type assetReportCreateJson struct {
+	ClientID      string                    `json:"client_id"`
+	Secret        string                    `json:"secret"`
+	AccessTokens  []string                  `json:"access_tokens"`
+	DaysRequested int                       `json:"days_requested"`
+	Options       *AssetReportCreateOptions `json:"options,omitempty"`
+}
+
+type assetReportGetJson struct {
+	ClientID         string `json:"client_id"`
+	Secret           string `json:"secret"`
+	AssetReportToken string `json:"asset_report_token"`
+	IncludeInsights  bool   `json:"include_insights,omitempty"`
+	FastReport       bool   `json:"fast_report,omitempty"`
+}
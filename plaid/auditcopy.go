@@ -0,0 +1,151 @@
+package plaid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/wearevest/plaidgo/plaid/transport"
+)
+
+// Auditor ids accepted by AssetReportAuditCopyCreate for the government sponsored
+// enterprises' automated underwriting systems.
+const (
+	AuditorFannieMae  = "fannie_mae"  // Desktop Underwriter (DU)
+	AuditorFreddieMac = "freddie_mac" // Loan Product Advisor (LPA)
+)
+
+// AssetReportAuditCopyCreate (POST /asset_report/audit_copy/create) creates an audit copy of
+// an asset report that the given auditor can retrieve. For Fannie Mae and Freddie Mac the
+// returned audit copy token is what gets submitted to DU or LPA, which retrieve the report
+// in the format they require themselves.
+//
+// See https://plaid.com/docs/api/products/assets/#asset_reportaudit_copycreate.
+func (c *Client) AssetReportAuditCopyCreate(assetReportToken,
+	auditorID string) (*auditCopyCreateResponse, error) {
+	return c.AssetReportAuditCopyCreateContext(context.Background(), assetReportToken, auditorID)
+}
+
+// AssetReportAuditCopyCreateContext is like AssetReportAuditCopyCreate but carries a context.
+func (c *Client) AssetReportAuditCopyCreateContext(ctx context.Context, assetReportToken,
+	auditorID string) (*auditCopyCreateResponse, error) {
+
+	if auditorID == "" {
+		return nil, errors.New("/asset_report/audit_copy/create - auditor id must be specified")
+	}
+	var res auditCopyCreateResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/asset_report/audit_copy/create", auditCopyCreateJson{
+		ClientID:         creds.clientID,
+		Secret:           creds.secret,
+		AssetReportToken: assetReportToken,
+		AuditorID:        auditorID,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AssetReportAuditCopyGet (POST /asset_report/audit_copy/get) retrieves the asset report
+// behind an audit copy token. It is used by auditors.
+//
+// See https://plaid.com/docs/api/products/assets/#asset_reportaudit_copyget.
+func (c *Client) AssetReportAuditCopyGet(auditCopyToken string) (*assetReportGetResponse, error) {
+	return c.AssetReportAuditCopyGetContext(context.Background(), auditCopyToken)
+}
+
+// AssetReportAuditCopyGetContext is like AssetReportAuditCopyGet but carries a context.
+func (c *Client) AssetReportAuditCopyGetContext(ctx context.Context,
+	auditCopyToken string) (*assetReportGetResponse, error) {
+
+	var res assetReportGetResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/asset_report/audit_copy/get", auditCopyJson{
+		ClientID:       creds.clientID,
+		Secret:         creds.secret,
+		AuditCopyToken: auditCopyToken,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AssetReportAuditCopyRemove (POST /asset_report/audit_copy/remove) revokes an audit copy,
+// after which the auditor can no longer retrieve the report.
+//
+// See https://plaid.com/docs/api/products/assets/#asset_reportaudit_copyremove.
+func (c *Client) AssetReportAuditCopyRemove(auditCopyToken string) (*auditCopyRemoveResponse, error) {
+	return c.AssetReportAuditCopyRemoveContext(context.Background(), auditCopyToken)
+}
+
+// AssetReportAuditCopyRemoveContext is like AssetReportAuditCopyRemove but carries a context.
+func (c *Client) AssetReportAuditCopyRemoveContext(ctx context.Context,
+	auditCopyToken string) (*auditCopyRemoveResponse, error) {
+
+	var res auditCopyRemoveResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/asset_report/audit_copy/remove", auditCopyJson{
+		ClientID:       creds.clientID,
+		Secret:         creds.secret,
+		AuditCopyToken: auditCopyToken,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// AssetReportPDFGet (POST /asset_report/pdf/get) retrieves an asset report as a PDF, the
+// format lenders keep on file alongside the audit copy.
+//
+// See https://plaid.com/docs/api/products/assets/#asset_reportpdfget.
+func (c *Client) AssetReportPDFGet(assetReportToken string) ([]byte, error) {
+	return c.AssetReportPDFGetContext(context.Background(), assetReportToken)
+}
+
+// AssetReportPDFGetContext is like AssetReportPDFGet but carries a context.
+func (c *Client) AssetReportPDFGetContext(ctx context.Context, assetReportToken string) ([]byte, error) {
+	creds := c.creds.Load()
+	jsonText, err := json.Marshal(assetReportGetJson{
+		ClientID:         creds.clientID,
+		Secret:           creds.secret,
+		AssetReportToken: assetReportToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	res, raw, err := c.do(ctx, "POST", "/asset_report/pdf/get", bytes.NewReader(jsonText))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == 200 {
+		return raw, nil
+	}
+	return nil, transport.DecodeError(res.StatusCode, raw)
+}
+
+type auditCopyCreateResponse struct {
+	AuditCopyToken string `json:"audit_copy_token"`
+	RequestID      string `json:"request_id"`
+}
+
+type auditCopyRemoveResponse struct {
+	Removed   bool   `json:"removed"`
+	RequestID string `json:"request_id"`
+}
+
+type auditCopyCreateJson struct {
+	ClientID         string `json:"client_id"`
+	Secret           string `json:"secret"`
+	AssetReportToken string `json:"asset_report_token"`
+	AuditorID        string `json:"auditor_id"`
+}
+
+type auditCopyJson struct {
+	ClientID       string `json:"client_id"`
+	Secret         string `json:"secret"`
+	AuditCopyToken string `json:"audit_copy_token"`
+}
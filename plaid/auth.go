@@ -2,18 +2,32 @@ package plaid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 )
 
 // AuthAddUser (POST /auth) submits a set of user credentials to add an Auth user.
 //
 // See https://plaid.com/docs/api/#add-auth-user.
+//
+// Deprecated: use Link with LinkTokenCreate and AuthGet instead.
 func (c *Client) AuthAddUser(username, password, pin, institutionType string,
 	options *AuthOptions) (postRes *postResponse, mfaRes *mfaResponse, err error) {
 
+	return c.AuthAddUserContext(context.Background(), username, password, pin, institutionType, options)
+}
+
+// AuthAddUserContext is like AuthAddUser but carries a context.
+//
+// Deprecated: use Link with LinkTokenCreate and AuthGet instead.
+func (c *Client) AuthAddUserContext(ctx context.Context, username, password, pin, institutionType string,
+	options *AuthOptions) (postRes *postResponse, mfaRes *mfaResponse, err error) {
+
+	c.deprecated("AuthAddUser", "Link with LinkTokenCreate and AuthGet")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(authJson{
-		ClientID: c.clientID,
-		Secret:   c.secret,
+		ClientID: creds.clientID,
+		Secret:   creds.secret,
 		Type:     institutionType,
 		Username: username,
 		Password: password,
@@ -23,73 +37,118 @@ func (c *Client) AuthAddUser(username, password, pin, institutionType string,
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/auth", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/auth", bytes.NewReader(jsonText))
 }
 
 // AuthStepSendMethod (POST /auth/step) specifies a particular send method for MFA,
 // e.g. `{"mask":"xxx-xxx-5309"}`.
 //
 // See https://plaid.com/docs/api/#auth-mfa.
+//
+// Deprecated: use Link with LinkTokenCreate instead.
 func (c *Client) AuthStepSendMethod(accessToken, key, value string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.AuthStepSendMethodContext(context.Background(), accessToken, key, value)
+}
+
+// AuthStepSendMethodContext is like AuthStepSendMethod but carries a context.
+//
+// Deprecated: use Link with LinkTokenCreate instead.
+func (c *Client) AuthStepSendMethodContext(ctx context.Context, accessToken, key, value string) (postRes *postResponse,
+	mfaRes *mfaResponse, err error) {
+
+	c.deprecated("AuthStepSendMethod", "Link with LinkTokenCreate")
 	sendMethod := map[string]string{key: value}
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(authStepSendMethodJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		Options:     authStepOptions{sendMethod},
 	})
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/auth/step", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/auth/step", bytes.NewReader(jsonText))
 }
 
 // AuthStep (POST /auth/step) submits an MFA answer for a given access token.
 //
 // See https://plaid.com/docs/api/#auth-mfa.
+//
+// Deprecated: use Link with LinkTokenCreate instead.
 func (c *Client) AuthStep(accessToken, answer string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.AuthStepContext(context.Background(), accessToken, answer)
+}
+
+// AuthStepContext is like AuthStep but carries a context.
+//
+// Deprecated: use Link with LinkTokenCreate instead.
+func (c *Client) AuthStepContext(ctx context.Context, accessToken, answer string) (postRes *postResponse,
+	mfaRes *mfaResponse, err error) {
+
+	c.deprecated("AuthStep", "Link with LinkTokenCreate")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(authStepJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		MFA:         answer,
 	})
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/auth/step", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/auth/step", bytes.NewReader(jsonText))
 }
 
 // AuthGet (POST /auth/get) retrieves account data for a given access token.
 //
 // See https://plaid.com/docs/api/#get-auth-data.
 func (c *Client) AuthGet(accessToken string) (postRes *postResponse, err error) {
+	return c.AuthGetContext(context.Background(), accessToken)
+}
+
+// AuthGetContext is like AuthGet but carries a context.
+func (c *Client) AuthGetContext(ctx context.Context, accessToken string) (postRes *postResponse, err error) {
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(authGetJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 	})
 	if err != nil {
 		return nil, err
 	}
 	// /auth/get will never return an MFA response
-	postRes, _, err = c.postAndUnmarshal("/auth/get", bytes.NewReader(jsonText))
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/auth/get", bytes.NewReader(jsonText))
 	return postRes, err
 }
 
 // AuthUpdate (PATCH /auth) updates user credentials for a given access token.
 //
 // See https://plaid.com/docs/api/#update-auth-user.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
 func (c *Client) AuthUpdate(username, password, pin, accessToken string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.AuthUpdateContext(context.Background(), username, password, pin, accessToken)
+}
+
+// AuthUpdateContext is like AuthUpdate but carries a context.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
+func (c *Client) AuthUpdateContext(ctx context.Context, username, password, pin, accessToken string) (postRes *postResponse,
+	mfaRes *mfaResponse, err error) {
+
+	c.deprecated("AuthUpdate", "Link in update mode, see LinkTokenCreateOptions.AccessToken")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(authUpdateJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		Username:    username,
 		Password:    password,
 		PIN:         pin,
@@ -98,18 +157,31 @@ func (c *Client) AuthUpdate(username, password, pin, accessToken string) (postRe
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.patchAndUnmarshal("/auth", bytes.NewReader(jsonText))
+	return c.patchAndUnmarshal(ctx, "/auth", bytes.NewReader(jsonText))
 }
 
 // AuthUpdateStep (PATCH /auth/step) updates user credentials and MFA for a given access token.
 //
 // See https://plaid.com/docs/api/#update-auth-user.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
 func (c *Client) AuthUpdateStep(username, password, pin, mfa, accessToken string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.AuthUpdateStepContext(context.Background(), username, password, pin, mfa, accessToken)
+}
+
+// AuthUpdateStepContext is like AuthUpdateStep but carries a context.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
+func (c *Client) AuthUpdateStepContext(ctx context.Context, username, password, pin, mfa, accessToken string) (
+	postRes *postResponse, mfaRes *mfaResponse, err error) {
+
+	c.deprecated("AuthUpdateStep", "Link in update mode, see LinkTokenCreateOptions.AccessToken")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(authUpdateStepJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		Username:    username,
 		Password:    password,
 		PIN:         pin,
@@ -119,22 +191,33 @@ func (c *Client) AuthUpdateStep(username, password, pin, mfa, accessToken string
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.patchAndUnmarshal("/auth/step", bytes.NewReader(jsonText))
+	return c.patchAndUnmarshal(ctx, "/auth/step", bytes.NewReader(jsonText))
 }
 
 // AuthDelete (DELETE /auth) deletes data for a given access token.
 //
 // See https://plaid.com/docs/api/#delete-auth-user.
+//
+// Deprecated: use ItemRemove instead.
 func (c *Client) AuthDelete(accessToken string) (deleteRes *deleteResponse, err error) {
+	return c.AuthDeleteContext(context.Background(), accessToken)
+}
+
+// AuthDeleteContext is like AuthDelete but carries a context.
+//
+// Deprecated: use ItemRemove instead.
+func (c *Client) AuthDeleteContext(ctx context.Context, accessToken string) (deleteRes *deleteResponse, err error) {
+	c.deprecated("AuthDelete", "ItemRemove")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(authDeleteJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return c.deleteAndUnmarshal("/auth", bytes.NewReader(jsonText))
+	return c.deleteAndUnmarshal(ctx, "/auth", bytes.NewReader(jsonText))
 }
 
 // AuthOptions represents options associated with adding an Auth user.
@@ -0,0 +1,40 @@
+// Package auth holds the types of Plaid's Auth product, which retrieves account and routing
+// numbers and verifies accounts for money movement. The plaid package's Client makes the
+// requests; see package core for how the products are split out of plaid.
+//
+// See https://plaid.com/docs/auth/.
+package auth
+
+// VerificationStatus is the state of an account added through micro-deposit based Auth flows.
+//
+// See https://plaid.com/docs/auth/coverage/.
+type VerificationStatus string
+
+const (
+	PendingAutomaticVerification VerificationStatus = "pending_automatic_verification"
+	PendingManualVerification    VerificationStatus = "pending_manual_verification"
+	AutomaticallyVerified        VerificationStatus = "automatically_verified"
+	ManuallyVerified             VerificationStatus = "manually_verified"
+	VerificationFailed           VerificationStatus = "verification_failed"
+	VerificationExpired          VerificationStatus = "verification_expired"
+)
+
+// Terminal reports whether s is a final status that will not change anymore.
+func (s VerificationStatus) Terminal() bool {
+	switch s {
+	case AutomaticallyVerified, ManuallyVerified, VerificationFailed, VerificationExpired:
+		return true
+	}
+	return false
+}
+
+// Pending reports whether s is a status that will change once the account is verified or
+// verification fails.
+func (s VerificationStatus) Pending() bool {
+	return s == PendingAutomaticVerification || s == PendingManualVerification
+}
+
+// Verified reports whether the account can be used for money movement.
+func (s VerificationStatus) Verified() bool {
+	return s == AutomaticallyVerified || s == ManuallyVerified
+}
@@ -0,0 +1,143 @@
+package plaid
+
+import (
+	"strings"
+)
+
+// EndpointAvailability describes the product an endpoint belongs to and the environments it
+// can be called in.
+type EndpointAvailability struct {
+	Product     string
+	Sandbox     bool
+	Development bool
+	Production  bool
+}
+
+// in reports whether the endpoint is available in the environment with the given name.
+func (a EndpointAvailability) in(environment string) bool {
+	switch environment {
+	case "sandbox":
+		return a.Sandbox
+	case "development":
+		return a.Development
+	case "production":
+		return a.Production
+	}
+	return true
+}
+
+// environments returns the names of the environments the endpoint is available in.
+func (a EndpointAvailability) environments() []string {
+	var names []string
+	for _, name := range []string{"sandbox", "development", "production"} {
+		if a.in(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// availabilities is the matrix of endpoints by product and environment, in the order of
+// the fields of EndpointAvailability: product, sandbox, development and production.
+var availabilities = map[string]EndpointAvailability{
+	"/item/get":                                   {"item", true, true, true},
+	"/item/remove":                                {"item", true, true, true},
+	"/item/webhook/update":                        {"item", true, true, true},
+	"/item/public_token/exchange":                 {"item", true, true, true},
+	"/webhook_verification_key/get":               {"item", true, true, true},
+	"/link/token/create":                          {"link", true, true, true},
+	"/link/token/get":                             {"link", true, true, true},
+	"/institutions/get":                           {"institutions", true, true, true},
+	"/institutions/get_by_id":                     {"institutions", true, true, true},
+	"/institutions/search":                        {"institutions", true, true, true},
+	"/accounts/get":                               {"accounts", true, true, true},
+	"/accounts/balance/get":                       {"balance", true, true, true},
+	"/auth/get":                                   {"auth", true, true, true},
+	"/identity/get":                               {"identity", true, true, true},
+	"/transactions/get":                           {"transactions", true, true, true},
+	"/transactions/sync":                          {"transactions", true, true, true},
+	"/transactions/recurring/get":                 {"transactions", true, true, true},
+	"/asset_report/create":                        {"assets", true, true, true},
+	"/asset_report/get":                           {"assets", true, true, true},
+	"/asset_report/pdf/get":                       {"assets", true, true, true},
+	"/asset_report/audit_copy/create":             {"assets", true, true, true},
+	"/asset_report/audit_copy/get":                {"assets", true, true, true},
+	"/asset_report/audit_copy/remove":             {"assets", true, true, true},
+	"/processor/token/create":                     {"processor", true, true, true},
+	"/processor/stripe/bank_account_token/create": {"processor", true, true, true},
+	"/signal/evaluate":                            {"signal", true, true, true},
+	"/signal/prepare":                             {"signal", true, true, true},
+	"/processor/signal/evaluate":                  {"signal", true, true, true},
+	"/transfer/intent/create":                     {"transfer", true, true, true},
+	"/transfer/intent/get":                        {"transfer", true, true, true},
+	// Plaid doesn't simulate the data connection apps of the permissions manager in Sandbox.
+	"/item/application/list":          {"item", false, true, true},
+	"/item/application/scopes/update": {"item", false, true, true},
+}
+
+// Availability returns the availability of an endpoint such as "/transactions/sync", and
+// false if the endpoint isn't known to this package. Every /sandbox/ endpoint is only
+// available in Sandbox.
+//
+// See https://plaid.com/docs/sandbox/ for the differences between the environments.
+func Availability(endpoint string) (EndpointAvailability, bool) {
+	if strings.HasPrefix(endpoint, "/sandbox/") {
+		return EndpointAvailability{Product: "sandbox", Sandbox: true}, true
+	}
+	availability, ok := availabilities[endpoint]
+	return availability, ok
+}
+
+// UnavailableEndpointError is returned without sending the request when an endpoint is
+// called in an environment it isn't available in, e.g. a /sandbox/ endpoint in production.
+type UnavailableEndpointError struct {
+	Endpoint    string
+	Product     string
+	Environment string
+	// Available are the environments the endpoint is available in.
+	Available []string
+}
+
+func (e *UnavailableEndpointError) Error() string {
+	return e.Endpoint + " (" + e.Product + ") is not available in " + e.Environment +
+		", only in " + strings.Join(e.Available, " and ")
+}
+
+// WithoutAvailabilityCheck makes the client send requests to endpoints regardless of the
+// environments Availability reports for them, e.g. if Plaid made an endpoint available
+// before this package caught up.
+func WithoutAvailabilityCheck() Option {
+	return func(c *Client) {
+		c.skipAvailability = true
+	}
+}
+
+// checkAvailability fails requests to endpoints that aren't available in the client's
+// environment. Clients with a custom base URL, e.g. of a proxy or a fake server, aren't
+// checked since their environment is unknown.
+func (c *Client) checkAvailability(endpoint string) error {
+	if c.skipAvailability {
+		return nil
+	}
+	var environment string
+	switch c.environment {
+	case Sandbox:
+		environment = "sandbox"
+	case Development:
+		environment = "development"
+	case Production:
+		environment = "production"
+	default:
+		return nil
+	}
+	availability, ok := Availability(endpoint)
+	if !ok || availability.in(environment) {
+		return nil
+	}
+	return &UnavailableEndpointError{
+		Endpoint:    endpoint,
+		Product:     availability.Product,
+		Environment: environment,
+		Available:   availability.environments(),
+	}
+}
@@ -0,0 +1,266 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/internal/pool"
+)
+
+// BackfillCheckpoint records the progress of the backfill of one item.
+type BackfillCheckpoint struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	// WindowStart and WindowEnd are the window of the range being fetched, see
+	// BackfillConfig.WindowDays.
+	WindowStart string `json:"window_start"`
+	WindowEnd   string `json:"window_end"`
+	// Offset is the number of transactions of the window already delivered.
+	Offset int  `json:"offset"`
+	Done   bool `json:"done"`
+}
+
+// CheckpointStore persists backfill checkpoints by access token, so that a backfill that
+// crashed resumes from its last completed page. Checkpoints are serializable to JSON.
+type CheckpointStore interface {
+	// LoadCheckpoint returns the checkpoint of an access token, or nil if there is none.
+	LoadCheckpoint(ctx context.Context, accessToken string) (*BackfillCheckpoint, error)
+	SaveCheckpoint(ctx context.Context, accessToken string, checkpoint BackfillCheckpoint) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps checkpoints in memory. It doesn't
+// survive restarts and is meant for tests and one-off backfills.
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]BackfillCheckpoint
+}
+
+// NewMemoryCheckpointStore instantiates an empty MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]BackfillCheckpoint{}}
+}
+
+// LoadCheckpoint implements CheckpointStore.
+func (s *MemoryCheckpointStore) LoadCheckpoint(ctx context.Context, accessToken string) (*BackfillCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	checkpoint, ok := s.checkpoints[accessToken]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint implements CheckpointStore.
+func (s *MemoryCheckpointStore) SaveCheckpoint(ctx context.Context, accessToken string,
+	checkpoint BackfillCheckpoint) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[accessToken] = checkpoint
+	return nil
+}
+
+// BackfillConfig configures a Backfiller.
+type BackfillConfig struct {
+	// Store persists progress. If nil, backfills always start from scratch.
+	Store CheckpointStore
+	// PageSize is the number of transactions requested per page. Defaults to 500, the
+	// maximum Plaid allows.
+	PageSize int
+	// WindowDays splits the range into windows of that many days that are fetched one
+	// after the other, since a single request over years of history of a large item can
+	// time out. Zero fetches the range as a single window.
+	WindowDays int
+	// Order is the order windows are fetched in. Every window is completely delivered
+	// before the next one starts; within a window, pages follow Plaid's order, newest first.
+	Order BackfillOrder
+	// OnPage receives every page of transactions. The page is only checkpointed once
+	// OnPage returns nil, so a page may be delivered again after a crash; OnPage should
+	// be idempotent, e.g. by upserting on transaction id.
+	OnPage func(ctx context.Context, accessToken string, transactions []Transaction) error
+}
+
+// BackfillOrder is the order a Backfiller fetches the windows of a range in.
+type BackfillOrder int
+
+const (
+	// OldestFirst fetches the oldest window first.
+	OldestFirst BackfillOrder = iota
+	// NewestFirst fetches the most recent window first, so recent history is available
+	// early in a long backfill.
+	NewestFirst
+)
+
+// Backfiller fetches the transaction history of items page by page, checkpointing its
+// progress so that long backfills survive restarts.
+type Backfiller struct {
+	client *Client
+	config BackfillConfig
+}
+
+// NewBackfiller instantiates a Backfiller that makes its requests through c.
+func NewBackfiller(c *Client, config BackfillConfig) *Backfiller {
+	if config.PageSize == 0 {
+		config.PageSize = 500
+	}
+	return &Backfiller{client: c, config: config}
+}
+
+// Backfill delivers the transactions of an item between startDate and endDate
+// ("2006-01-02"), resuming from the item's checkpoint if it has one for the same range. A
+// completed backfill of the same range is not repeated.
+func (b *Backfiller) Backfill(accessToken, startDate, endDate string) error {
+	return b.BackfillContext(context.Background(), accessToken, startDate, endDate)
+}
+
+// BackfillContext is like Backfill but carries a context.
+func (b *Backfiller) BackfillContext(ctx context.Context, accessToken, startDate, endDate string) error {
+	ctx, cancel := b.client.operation(ctx)
+	defer cancel()
+	windows, err := backfillWindows(startDate, endDate, b.config.WindowDays, b.config.Order)
+	if err != nil {
+		return err
+	}
+	checkpoint := BackfillCheckpoint{StartDate: startDate, EndDate: endDate}
+	if b.config.Store != nil {
+		saved, err := b.config.Store.LoadCheckpoint(ctx, accessToken)
+		if err != nil {
+			return err
+		}
+		if saved != nil && saved.StartDate == startDate && saved.EndDate == endDate {
+			checkpoint = *saved
+		}
+	}
+	if checkpoint.Done {
+		return nil
+	}
+
+	// Find the window to resume; if the windowing changed since the checkpoint was saved,
+	// start over.
+	first := 0
+	for i, window := range windows {
+		if window[0] == checkpoint.WindowStart && window[1] == checkpoint.WindowEnd {
+			first = i
+			break
+		}
+	}
+	if windows[first][0] != checkpoint.WindowStart || windows[first][1] != checkpoint.WindowEnd {
+		checkpoint.Offset = 0
+	}
+
+	checkpoint.WindowStart, checkpoint.WindowEnd = windows[first][0], windows[first][1]
+	for i := first; ; i++ {
+		last := i == len(windows)-1
+		if err := b.backfillWindow(ctx, accessToken, &checkpoint, last); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+		checkpoint.WindowStart, checkpoint.WindowEnd = windows[i+1][0], windows[i+1][1]
+		checkpoint.Offset = 0
+		if err := b.saveCheckpoint(ctx, accessToken, checkpoint); err != nil {
+			return err
+		}
+	}
+}
+
+func (b *Backfiller) saveCheckpoint(ctx context.Context, accessToken string, checkpoint BackfillCheckpoint) error {
+	if b.config.Store == nil {
+		return nil
+	}
+	return b.config.Store.SaveCheckpoint(ctx, accessToken, checkpoint)
+}
+
+// backfillWindow delivers the transactions of the checkpoint's window, starting at its
+// offset. last marks the final window, whose completion completes the backfill.
+func (b *Backfiller) backfillWindow(ctx context.Context, accessToken string, checkpoint *BackfillCheckpoint,
+	last bool) error {
+
+	for {
+		var res *postResponse
+		err := b.client.call(ctx, "/transactions/get", func(ctx context.Context) (err error) {
+			res, err = b.client.TransactionsContext(ctx, accessToken, checkpoint.WindowStart, checkpoint.WindowEnd,
+				TransactionOptionsJson{
+					Count:  b.config.PageSize,
+					Offset: checkpoint.Offset,
+				})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if len(res.Transactions) > 0 && b.config.OnPage != nil {
+			if err := b.config.OnPage(ctx, accessToken, res.Transactions); err != nil {
+				return err
+			}
+		}
+		checkpoint.Offset += len(res.Transactions)
+		windowDone := len(res.Transactions) == 0 || checkpoint.Offset >= res.TotalTransactions
+		checkpoint.Done = windowDone && last
+		if windowDone && !last {
+			// Backfill saves the start of the next window instead.
+			return nil
+		}
+		if err := b.saveCheckpoint(ctx, accessToken, *checkpoint); err != nil {
+			return err
+		}
+		if windowDone {
+			return nil
+		}
+	}
+}
+
+// backfillWindows splits a date range into consecutive windows of days days, ordered by
+// order. Windows don't overlap; every date of the range belongs to exactly one window.
+func backfillWindows(startDate, endDate string, days int, order BackfillOrder) ([][2]string, error) {
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, errors.New("backfill end date " + endDate + " is before start date " + startDate)
+	}
+	if days < 1 {
+		return [][2]string{{startDate, endDate}}, nil
+	}
+
+	var windows [][2]string
+	for windowStart := start; !windowStart.After(end); windowStart = windowStart.AddDate(0, 0, days) {
+		windowEnd := windowStart.AddDate(0, 0, days-1)
+		if windowEnd.After(end) {
+			windowEnd = end
+		}
+		windows = append(windows, [2]string{windowStart.Format("2006-01-02"), windowEnd.Format("2006-01-02")})
+	}
+	if order == NewestFirst {
+		for i, j := 0, len(windows)-1; i < j; i, j = i+1, j-1 {
+			windows[i], windows[j] = windows[j], windows[i]
+		}
+	}
+	return windows, nil
+}
+
+// BackfillAll backfills many items using up to concurrency items at a time. Failures don't
+// stop the other items; they are returned as a *MultiError, in the order of accessTokens.
+func (b *Backfiller) BackfillAll(accessTokens []string, startDate, endDate string, concurrency int) error {
+	return b.BackfillAllContext(context.Background(), accessTokens, startDate, endDate, concurrency)
+}
+
+// BackfillAllContext is like BackfillAll but carries a context.
+func (b *Backfiller) BackfillAllContext(ctx context.Context, accessTokens []string, startDate, endDate string,
+	concurrency int) error {
+
+	errs := make([]error, len(accessTokens))
+	pool.Each(len(accessTokens), concurrency, func(i int) {
+		errs[i] = b.BackfillContext(ctx, accessTokens[i], startDate, endDate)
+	})
+	return tokenErrors(accessTokens, errs)
+}
@@ -0,0 +1,132 @@
+package plaid
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// Overflow is what a bounded buffer between the client and a slow consumer does when it is
+// full, see Backpressure.
+type Overflow int
+
+const (
+	// OverflowBlock waits until the consumer makes room, slowing the producer down to the
+	// consumer's pace.
+	OverflowBlock Overflow = iota
+	// OverflowDropOldest discards the oldest buffered item to make room for the new one, and
+	// counts it as dropped.
+	OverflowDropOldest
+	// OverflowError ends the delivery with ErrBufferFull, so that the consumer learns it
+	// fell behind and can start over from a known state.
+	OverflowError
+)
+
+// ErrBufferFull is the error of a delivery ended because its buffer was full, see
+// OverflowError.
+var ErrBufferFull = errors.New("buffer full")
+
+// Backpressure bounds the buffer of a channel a helper delivers to, so that a slow consumer
+// of a long-running helper can't make the client's memory grow without limit.
+type Backpressure struct {
+	// Buffer is the number of items buffered. Defaults to 64.
+	Buffer int
+	// Overflow is what happens when the buffer is full. Defaults to OverflowBlock.
+	Overflow Overflow
+}
+
+func (b Backpressure) withDefaults() Backpressure {
+	if b.Buffer <= 0 {
+		b.Buffer = 64
+	}
+	return b
+}
+
+// bounded is the sending side of a channel with a Backpressure. Sends and close may be
+// called concurrently: close wakes up blocked sends, and sends after close are discarded.
+type bounded[T any] struct {
+	items    chan T
+	overflow Overflow
+	dropped  uint64
+	onDrop   func() // called for every item dropped, if set
+
+	mu     sync.Mutex // held by sends, so that close doesn't close items under them
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newBounded[T any](b Backpressure, onDrop func()) *bounded[T] {
+	b = b.withDefaults()
+	return &bounded[T]{items: make(chan T, b.Buffer), overflow: b.Overflow, onDrop: onDrop, closed: make(chan struct{})}
+}
+
+// send delivers item according to the overflow behavior. It returns ErrBufferFull if the
+// buffer is full with OverflowError, errClosed once the channel is closed and errAborted if
+// abort is closed while waiting for room.
+func (b *bounded[T]) send(item T, abort <-chan struct{}) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	select {
+	case <-b.closed:
+		return errClosed
+	default:
+	}
+
+	switch b.overflow {
+	case OverflowDropOldest:
+		for {
+			select {
+			case b.items <- item:
+				return nil
+			default:
+			}
+			// The consumer may take the oldest item first, in which case there is room now.
+			select {
+			case <-b.items:
+				atomic.AddUint64(&b.dropped, 1)
+				if b.onDrop != nil {
+					b.onDrop()
+				}
+			default:
+			}
+		}
+	case OverflowError:
+		select {
+		case b.items <- item:
+			return nil
+		default:
+			return ErrBufferFull
+		}
+	default:
+		select {
+		case b.items <- item:
+			return nil
+		case <-b.closed:
+			return errClosed
+		case <-abort:
+			return errAborted
+		}
+	}
+}
+
+// close closes the channel once the send in progress, if any, returned.
+func (b *bounded[T]) close() {
+	b.once.Do(func() {
+		close(b.closed)
+		b.mu.Lock()
+		close(b.items)
+		b.mu.Unlock()
+	})
+}
+
+// Dropped returns the number of items discarded by OverflowDropOldest.
+func (b *bounded[T]) Dropped() uint64 {
+	return atomic.LoadUint64(&b.dropped)
+}
+
+// errClosed and errAborted are returned by sends that couldn't deliver their item. They
+// never reach callers.
+var (
+	errClosed  = errors.New("closed")
+	errAborted = errors.New("aborted")
+)
@@ -0,0 +1,107 @@
+package plaid
+
+import (
+	"testing"
+	"time"
+)
+
+// received drains the buffered items of b.
+func received(b *bounded[int]) []int {
+	var items []int
+	for {
+		select {
+		case item := <-b.items:
+			items = append(items, item)
+		default:
+			return items
+		}
+	}
+}
+
+func TestBoundedOverflow(t *testing.T) {
+	t.Run("drop oldest", func(t *testing.T) {
+		drops := 0
+		b := newBounded[int](Backpressure{Buffer: 2, Overflow: OverflowDropOldest}, func() { drops++ })
+		for i := 1; i <= 4; i++ {
+			if err := b.send(i, nil); err != nil {
+				t.Fatalf("send(%d) = %v", i, err)
+			}
+		}
+		if got := received(b); len(got) != 2 || got[0] != 3 || got[1] != 4 {
+			t.Errorf("received %v, want the newest items [3 4]", got)
+		}
+		if b.Dropped() != 2 || drops != 2 {
+			t.Errorf("Dropped = %d with %d calls to onDrop, want 2", b.Dropped(), drops)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		b := newBounded[int](Backpressure{Buffer: 1, Overflow: OverflowError}, nil)
+		if err := b.send(1, nil); err != nil {
+			t.Fatalf("send(1) = %v", err)
+		}
+		if err := b.send(2, nil); err != ErrBufferFull {
+			t.Fatalf("send to a full buffer = %v, want ErrBufferFull", err)
+		}
+		if got := received(b); len(got) != 1 || got[0] != 1 {
+			t.Errorf("received %v, want [1]", got)
+		}
+	})
+	t.Run("block", func(t *testing.T) {
+		b := newBounded[int](Backpressure{Buffer: 1}, nil)
+		if err := b.send(1, nil); err != nil {
+			t.Fatalf("send(1) = %v", err)
+		}
+		sent := make(chan error, 1)
+		go func() { sent <- b.send(2, nil) }()
+		select {
+		case err := <-sent:
+			t.Fatalf("send to a full buffer returned %v instead of blocking", err)
+		case <-time.After(10 * time.Millisecond):
+		}
+		if item := <-b.items; item != 1 {
+			t.Fatalf("received %d, want 1", item)
+		}
+		if err := <-sent; err != nil {
+			t.Fatalf("blocked send = %v once there was room", err)
+		}
+		if item := <-b.items; item != 2 {
+			t.Fatalf("received %d, want 2", item)
+		}
+	})
+	t.Run("abort", func(t *testing.T) {
+		b := newBounded[int](Backpressure{Buffer: 1}, nil)
+		b.send(1, nil)
+		abort := make(chan struct{})
+		close(abort)
+		if err := b.send(2, abort); err != errAborted {
+			t.Fatalf("aborted send = %v, want errAborted", err)
+		}
+	})
+}
+
+func TestBoundedCloseUnblocksSend(t *testing.T) {
+	b := newBounded[int](Backpressure{Buffer: 1}, nil)
+	b.send(1, nil)
+	sent := make(chan error, 1)
+	go func() { sent <- b.send(2, nil) }()
+	time.Sleep(10 * time.Millisecond)
+	b.close()
+	select {
+	case err := <-sent:
+		if err != errClosed {
+			t.Fatalf("send blocked during close = %v, want errClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("close didn't unblock a blocked send")
+	}
+	if err := b.send(3, nil); err != errClosed {
+		t.Errorf("send after close = %v, want errClosed", err)
+	}
+	// Buffered items can still be received before C reports it is closed.
+	if item, ok := <-b.items; !ok || item != 1 {
+		t.Errorf("received %d, %v, want the buffered item 1", item, ok)
+	}
+	if _, ok := <-b.items; ok {
+		t.Error("items isn't closed")
+	}
+}
@@ -2,6 +2,7 @@ package plaid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 )
 
@@ -9,30 +10,45 @@ import (
 //
 // See https://plaid.com/docs/api/#balance.
 func (c *Client) Balance(accessToken string) (postRes *postResponse, err error) {
+	return c.BalanceContext(context.Background(), accessToken)
+}
+
+// BalanceContext is like Balance but carries a context.
+func (c *Client) BalanceContext(ctx context.Context, accessToken string) (postRes *postResponse, err error) {
 
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(balanceJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 	})
 	if err != nil {
 		return nil, err
 	}
-	postRes, _, err = c.postAndUnmarshal("/accounts/balance/get", bytes.NewReader(jsonText))
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/accounts/balance/get", bytes.NewReader(jsonText))
 	return postRes, err
 }
 
+// Accounts (POST /accounts/get) retrieves the accounts associated with an access token.
+//
+// See https://plaid.com/docs/api/accounts/#accountsget.
 func (c *Client) Accounts(accessToken string) (postRes *postResponse, err error) {
+	return c.AccountsContext(context.Background(), accessToken)
+}
+
+// AccountsContext is like Accounts but carries a context.
+func (c *Client) AccountsContext(ctx context.Context, accessToken string) (postRes *postResponse, err error) {
 
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(balanceJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 	})
 	if err != nil {
 		return nil, err
 	}
-	postRes, _, err = c.postAndUnmarshal("/accounts/get", bytes.NewReader(jsonText))
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/accounts/get", bytes.NewReader(jsonText))
 	return postRes, err
 }
 
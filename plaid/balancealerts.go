@@ -0,0 +1,172 @@
+package plaid
+
+import (
+	"sync"
+	"time"
+)
+
+// BalanceKind selects which balance of an account a BalanceRule evaluates.
+type BalanceKind string
+
+const (
+	// AvailableBalance is the amount that can be spent or withdrawn. It is the default.
+	// Accounts Plaid returns no available balance for are evaluated on their current
+	// balance instead, rather than on an available balance of 0.
+	AvailableBalance BalanceKind = "available"
+	// CurrentBalance is the total amount of funds in or owed by the account.
+	CurrentBalance BalanceKind = "current"
+)
+
+// BalanceRule is a threshold on an account's balance. A rule with both Below and DropPercent
+// set raises an alert for each of them.
+type BalanceRule struct {
+	// Name identifies the rule in its alerts.
+	Name string
+	// AccountIDs limits the rule to these accounts. Empty applies it to every account.
+	AccountIDs []AccountID
+	// Balance is the balance the rule evaluates. Defaults to AvailableBalance.
+	Balance BalanceKind
+
+	// Below, if set, raises an alert when the balance falls below it.
+	Below *float64
+	// DropPercent, if positive, raises an alert when the balance dropped by at least that
+	// many percent since the previous refresh, e.g. 20 for a drop from 100 to 80.
+	DropPercent float64
+}
+
+func (r *BalanceRule) applies(accountID AccountID) bool {
+	if len(r.AccountIDs) == 0 {
+		return true
+	}
+	for _, id := range r.AccountIDs {
+		if id == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *BalanceRule) balance(account Account) float64 {
+	if r.Balance == CurrentBalance || !account.Balances.HasAvailable() {
+		return account.Balances.Current
+	}
+	return account.Balances.Available
+}
+
+// BalanceAlertKind is the kind of threshold a BalanceAlert reports.
+type BalanceAlertKind string
+
+const (
+	// BalanceBelow reports a balance that fell below a rule's Below threshold.
+	BalanceBelow BalanceAlertKind = "below"
+	// BalanceDropped reports a balance that dropped by at least a rule's DropPercent.
+	BalanceDropped BalanceAlertKind = "dropped"
+)
+
+// BalanceAlert reports that an account's balance crossed the threshold of a BalanceRule.
+type BalanceAlert struct {
+	Kind        BalanceAlertKind
+	Rule        string
+	AccessToken string
+	AccountID   AccountID
+	Time        time.Time
+	// Balance is the balance that crossed the threshold and Previous the balance of the
+	// previous refresh, if there was one.
+	Balance     float64
+	Previous    float64
+	HasPrevious bool
+	// Threshold is the rule's Below amount for BalanceBelow, and its DropPercent for
+	// BalanceDropped.
+	Threshold float64
+}
+
+// BalanceAlertConfig configures a BalanceAlerter.
+type BalanceAlertConfig struct {
+	Rules   []BalanceRule
+	OnAlert func(alert BalanceAlert)
+}
+
+// BalanceAlerter evaluates threshold rules against refreshed balances and raises an alert
+// when an account crosses one, e.g. to notify users of a low balance. Alerts are raised
+// once per crossing: an account that stays below a threshold is only alerted about again
+// after its balance recovered above it.
+//
+// The balances of an item are remembered until it is evaluated without some of its accounts
+// or forgotten with Forget, e.g. when the item is removed.
+//
+// Its OnBalance method plugs into RefresherConfig.OnBalance:
+//
+//	alerter := plaid.NewBalanceAlerter(client, plaid.BalanceAlertConfig{Rules: rules, OnAlert: notify})
+//	refresher := plaid.NewRefresher(client, plaid.RefresherConfig{OnBalance: alerter.OnBalance, ...})
+type BalanceAlerter struct {
+	client *Client
+	config BalanceAlertConfig
+
+	mu       sync.Mutex
+	previous map[string]map[AccountID]Account // access token -> account id -> account of the previous refresh
+}
+
+// NewBalanceAlerter instantiates a BalanceAlerter. c is only used for its clock.
+func NewBalanceAlerter(c *Client, config BalanceAlertConfig) *BalanceAlerter {
+	return &BalanceAlerter{client: c, config: config, previous: map[string]map[AccountID]Account{}}
+}
+
+// Forget drops the balances remembered for an item, e.g. once it is removed.
+func (a *BalanceAlerter) Forget(accessToken string) {
+	a.mu.Lock()
+	delete(a.previous, accessToken)
+	a.mu.Unlock()
+}
+
+// OnBalance evaluates the rules against the accounts of an item and delivers the resulting
+// alerts to the config's OnAlert.
+func (a *BalanceAlerter) OnBalance(accessToken string, accounts []Account) {
+	for _, alert := range a.Evaluate(accessToken, accounts) {
+		if a.config.OnAlert != nil {
+			a.config.OnAlert(alert)
+		}
+	}
+}
+
+// Evaluate evaluates the rules against the accounts of an item, remembers their balances for
+// the next evaluation, replacing those of the item's previous evaluation, and returns the
+// resulting alerts.
+func (a *BalanceAlerter) Evaluate(accessToken string, accounts []Account) []BalanceAlert {
+	now := a.client.clock.Now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	previousAccounts := a.previous[accessToken]
+	current := make(map[AccountID]Account, len(accounts))
+	a.previous[accessToken] = current
+	var alerts []BalanceAlert
+	for _, account := range accounts {
+		previousAccount, hasPrevious := previousAccounts[account.AccountID]
+		current[account.AccountID] = account
+		for _, rule := range a.config.Rules {
+			if !rule.applies(account.AccountID) {
+				continue
+			}
+			balance, previous := rule.balance(account), rule.balance(previousAccount)
+			alert := BalanceAlert{
+				Rule:        rule.Name,
+				AccessToken: accessToken,
+				AccountID:   account.AccountID,
+				Time:        now,
+				Balance:     balance,
+				Previous:    previous,
+				HasPrevious: hasPrevious,
+			}
+			if rule.Below != nil && balance < *rule.Below && (!hasPrevious || previous >= *rule.Below) {
+				alert.Kind, alert.Threshold = BalanceBelow, *rule.Below
+				alerts = append(alerts, alert)
+			}
+			if rule.DropPercent > 0 && hasPrevious && previous > 0 &&
+				(previous-balance)/previous*100 >= rule.DropPercent {
+				alert.Kind, alert.Threshold = BalanceDropped, rule.DropPercent
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,59 @@
+package plaid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestBalanceAlerterNullAvailable(t *testing.T) {
+	var accounts []Account
+	err := json.Unmarshal([]byte(`[
+		{"account_id": "credit", "balances": {"available": null, "current": 410}},
+		{"account_id": "checking", "balances": {"available": 0, "current": 110}}
+	]`), &accounts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accounts[0].Balances.HasAvailable() || !accounts[1].Balances.HasAvailable() {
+		t.Fatal("HasAvailable doesn't tell a null available balance from 0")
+	}
+
+	below := 50.0
+	alerter := NewBalanceAlerter(NewClient("id", "secret", Sandbox), BalanceAlertConfig{
+		Rules: []BalanceRule{{Name: "low", Below: &below}},
+	})
+	alerts := alerter.Evaluate("token", accounts)
+	if len(alerts) != 1 || alerts[0].AccountID != "checking" {
+		t.Fatalf("alerts = %+v, want one for checking only", alerts)
+	}
+
+	// The balances remembered for the item are replaced on every evaluation, so an
+	// account that went away and comes back is evaluated as new.
+	alerter.Evaluate("token", accounts[:1])
+	if alerts = alerter.Evaluate("token", accounts); len(alerts) != 1 || alerts[0].HasPrevious {
+		t.Fatalf("alerts = %+v, want one without a previous balance", alerts)
+	}
+	alerter.Forget("token")
+	if len(alerter.previous) != 0 {
+		t.Fatalf("%d items remembered after Forget", len(alerter.previous))
+	}
+}
+
+func TestBalancesJSON(t *testing.T) {
+	for _, balances := range []string{
+		`{"limit":0,"available":null,"current":410,"iso_currency_code":"USD","unofficial_currency_code":""}`,
+		`{"limit":0,"available":0,"current":410,"iso_currency_code":"USD","unofficial_currency_code":""}`,
+	} {
+		var b Balances
+		if err := json.Unmarshal([]byte(balances), &b); err != nil {
+			t.Fatal(err)
+		}
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(encoded) != balances {
+			t.Errorf("%s encoded again as %s", balances, encoded)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package plaid
+
+import (
+	"time"
+)
+
+// DailyBalance is the balance of an account at the end of a day.
+type DailyBalance struct {
+	Date    string // YYYY-MM-DD
+	Balance float64
+}
+
+// BalanceHistoryOptions represents options associated with reconstructing balance history.
+type BalanceHistoryOptions struct {
+	// StartDate is the first day of the history. Defaults to the date of the earliest
+	// transaction of the account.
+	StartDate string
+	// EndDate is the day the account's current balance applies to and the last day of the
+	// history. Transactions after it are ignored. Defaults to today in Location.
+	EndDate string
+	// Location is the time zone today is determined in when EndDate is empty, e.g. the time
+	// zone of the account's owner. Defaults to UTC.
+	Location *time.Location
+	// IncludePending counts pending transactions. Plaid includes pending transactions in
+	// the current balance of most depository accounts, so this is usually wanted there.
+	IncludePending bool
+}
+
+// BalanceHistory approximates the daily balance history of an account by walking its
+// current balance back through its transactions. It is useful for charts where no balance
+// history is available from Plaid.
+//
+// Only transactions of the account in the account's currency are considered. For credit
+// and loan accounts the balance is the amount owed, so outflows increase it.
+func BalanceHistory(account Account, transactions []Transaction,
+	options BalanceHistoryOptions) ([]DailyBalance, error) {
+
+	endDate := options.EndDate
+	if endDate == "" {
+		endDate = DateIn(time.Now(), dateLocation(options.Location))
+	}
+	end, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return nil, err
+	}
+	startDate := options.StartDate
+
+	// Net amount per day, signed so that it is the change the day caused to the balance.
+	sign := -1.0
+	if account.Type == "credit" || account.Type == "loan" {
+		sign = 1.0
+	}
+	currency := account.currencyCode()
+	changes := map[string]float64{}
+	for _, t := range transactions {
+		if t.AccountID != account.AccountID || t.Date > endDate {
+			continue
+		}
+		if t.Pending && !options.IncludePending {
+			continue
+		}
+		if code := t.CurrencyCode(); currency != "" && code != "" && code != currency {
+			continue
+		}
+		changes[t.Date] += sign * t.Amount64()
+		if options.StartDate == "" && (startDate == "" || t.Date < startDate) {
+			startDate = t.Date
+		}
+	}
+	if startDate == "" || startDate > endDate {
+		startDate = endDate
+	}
+
+	start, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return nil, err
+	}
+	days := int(end.Sub(start).Hours()/24) + 1
+	history := make([]DailyBalance, days)
+	balance := account.Balances.Current
+	for i := days - 1; i >= 0; i-- {
+		date := start.AddDate(0, 0, i).Format("2006-01-02")
+		history[i] = DailyBalance{Date: date, Balance: balance}
+		balance -= changes[date]
+	}
+	return history, nil
+}
+
+// currencyCode returns the ISO currency code of the account's balances, or the unofficial
+// one for currencies without an ISO code.
+func (a Account) currencyCode() string {
+	if a.Balances.IsoCurrencyCode != "" {
+		return a.Balances.IsoCurrencyCode
+	}
+	return a.Balances.UnofficialCurrencyCode
+}
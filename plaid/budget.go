@@ -0,0 +1,216 @@
+package plaid
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// PageBudget bounds how long a paginated helper keeps fetching pages. Once the budget is
+// exhausted the helper returns what it fetched so far along with a way to resume, rather
+// than an error, so that request-scoped handlers can answer in time with partial results.
+//
+// The budget is also exhausted by the deadline of the helper's context, so a handler can
+// pass its request context and a zero PageBudget.
+type PageBudget struct {
+	// Deadline, if set, is the time after which no page is started.
+	Deadline time.Time
+	// MaxPages, if positive, is the number of pages after which the helper stops.
+	MaxPages int
+}
+
+// pageBudget tracks the consumption of a PageBudget by one call of a paginated helper.
+type pageBudget struct {
+	budget   PageBudget
+	clock    Clock
+	deadline time.Time
+	pages    int
+	// slowest is the duration of the slowest page so far, used to predict whether the
+	// next page would still finish before the deadline.
+	slowest time.Duration
+	started time.Time
+}
+
+func (c *Client) newPageBudget(ctx context.Context, budget PageBudget) *pageBudget {
+	b := &pageBudget{budget: budget, clock: c.clock, deadline: budget.Deadline}
+	if deadline, ok := ctx.Deadline(); ok && (b.deadline.IsZero() || deadline.Before(b.deadline)) {
+		b.deadline = deadline
+	}
+	return b
+}
+
+// context returns the context to fetch a page with, which is canceled at the budget's
+// deadline.
+func (b *pageBudget) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if b.budget.Deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, b.budget.Deadline)
+}
+
+// next reports whether another page may be started. A page is only started if one as slow
+// as the slowest so far would finish before the deadline.
+func (b *pageBudget) next() bool {
+	if b.budget.MaxPages > 0 && b.pages >= b.budget.MaxPages {
+		return false
+	}
+	b.started = b.clock.Now()
+	return b.deadline.IsZero() || b.started.Add(b.slowest).Before(b.deadline)
+}
+
+// done records that a page was fetched.
+func (b *pageBudget) done() {
+	b.pages++
+	if elapsed := b.clock.Now().Sub(b.started); elapsed > b.slowest {
+		b.slowest = elapsed
+	}
+}
+
+// exhausted reports whether a page whose context expired failed because the budget's
+// deadline passed while it was in flight, rather than because of the caller canceling ctx
+// or another error. A page that only exceeded the per-call timeout of the client's
+// Deadlines didn't exhaust the budget.
+func (b *pageBudget) exhausted(ctx context.Context, err error) bool {
+	if !errors.Is(err, context.DeadlineExceeded) || b.deadline.IsZero() {
+		return false
+	}
+	return ctx.Err() == nil || errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// TransactionsPage is the result of TransactionsWithBudget.
+type TransactionsPage struct {
+	Transactions      []Transaction
+	Item              Item
+	TotalTransactions int
+	// ResumeToken is empty if all transactions were fetched. Otherwise passing it to
+	// TransactionsWithBudget continues with the remaining transactions.
+	ResumeToken string
+}
+
+// Complete reports whether all transactions were fetched.
+func (p *TransactionsPage) Complete() bool {
+	return p.ResumeToken == ""
+}
+
+// transactionsResume is the decoded form of a TransactionsPage's ResumeToken.
+type transactionsResume struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Offset    int    `json:"offset"`
+}
+
+// TransactionsWithBudget fetches the pages of an item's transactions between two dates
+// until all of them are fetched or budget is exhausted. resumeToken is empty on the first
+// call, and the ResumeToken of the previous page to continue; the page only holds the
+// transactions fetched by the call.
+//
+// Pages are requested while the item's transactions may change, so like any offset based
+// pagination the transactions of resumed calls can overlap or miss transactions that moved
+// between pages. Use TransactionsSyncWithBudget where that matters.
+func (c *Client) TransactionsWithBudget(accessToken, startDate, endDate string, budget PageBudget,
+	resumeToken string) (*TransactionsPage, error) {
+
+	return c.TransactionsWithBudgetContext(context.Background(), accessToken, startDate, endDate, budget,
+		resumeToken)
+}
+
+// TransactionsWithBudgetContext is like TransactionsWithBudget but carries a context.
+func (c *Client) TransactionsWithBudgetContext(ctx context.Context, accessToken, startDate, endDate string,
+	budget PageBudget, resumeToken string) (*TransactionsPage, error) {
+
+	resume := transactionsResume{StartDate: startDate, EndDate: endDate}
+	if resumeToken != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(resumeToken)
+		if err != nil {
+			return nil, errors.New("invalid resume token")
+		}
+		if err = json.Unmarshal(raw, &resume); err != nil {
+			return nil, errors.New("invalid resume token")
+		}
+		if resume.StartDate != startDate || resume.EndDate != endDate {
+			return nil, errors.New("resume token is for a different date range")
+		}
+	}
+
+	page := &TransactionsPage{}
+	b := c.newPageBudget(ctx, budget)
+	for b.next() {
+		pageCtx, cancel := b.context(ctx)
+		var res *postResponse
+		err := c.call(pageCtx, "/transactions/get", func(ctx context.Context) (err error) {
+			res, err = c.TransactionsContext(ctx, accessToken, startDate, endDate,
+				TransactionOptionsJson{Count: 500, Offset: resume.Offset})
+			return err
+		})
+		expired := pageCtx.Err() != nil
+		cancel()
+		if expired && b.exhausted(ctx, err) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		b.done()
+		page.Transactions = append(page.Transactions, res.Transactions...)
+		page.Item, page.TotalTransactions = res.Item, res.TotalTransactions
+		resume.Offset += len(res.Transactions)
+		if len(res.Transactions) == 0 || resume.Offset >= res.TotalTransactions {
+			return page, nil
+		}
+	}
+	raw, err := json.Marshal(resume)
+	if err != nil {
+		return nil, err
+	}
+	page.ResumeToken = base64.RawURLEncoding.EncodeToString(raw)
+	return page, nil
+}
+
+// TransactionsSyncWithBudget is like TransactionsSyncAll but stops when budget is
+// exhausted. The response then has HasMore set, and its NextCursor continues with the
+// remaining changes.
+//
+// Unlike TransactionsSyncAll it can't restart the pagination if the item's transactions
+// change while the pages are fetched, since earlier pages may already have been returned.
+// When resuming fails with TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION, the caller has to
+// restart from the cursor it started the pagination with.
+func (c *Client) TransactionsSyncWithBudget(accessToken, cursor string,
+	budget PageBudget) (*TransactionsSyncResponse, error) {
+
+	return c.TransactionsSyncWithBudgetContext(context.Background(), accessToken, cursor, budget)
+}
+
+// TransactionsSyncWithBudgetContext is like TransactionsSyncWithBudget but carries a context.
+func (c *Client) TransactionsSyncWithBudgetContext(ctx context.Context, accessToken, cursor string,
+	budget PageBudget) (*TransactionsSyncResponse, error) {
+
+	all := &TransactionsSyncResponse{NextCursor: cursor, HasMore: true}
+	b := c.newPageBudget(ctx, budget)
+	for b.next() {
+		pageCtx, cancel := b.context(ctx)
+		var res *TransactionsSyncResponse
+		err := c.call(pageCtx, "/transactions/sync", func(ctx context.Context) (err error) {
+			res, err = c.TransactionsSyncContext(ctx, accessToken, all.NextCursor, 500)
+			return err
+		})
+		expired := pageCtx.Err() != nil
+		cancel()
+		if expired && b.exhausted(ctx, err) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		b.done()
+		all.Added = append(all.Added, res.Added...)
+		all.Modified = append(all.Modified, res.Modified...)
+		all.Removed = append(all.Removed, res.Removed...)
+		all.NextCursor, all.HasMore, all.RequestID = res.NextCursor, res.HasMore, res.RequestID
+		if !res.HasMore {
+			break
+		}
+	}
+	return all, nil
+}
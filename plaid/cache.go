@@ -0,0 +1,85 @@
+package plaid
+
+import (
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+const (
+	// DefaultInstitutionCacheSize is the number of institutions the client caches unless
+	// configured otherwise with WithInstitutionCache.
+	DefaultInstitutionCacheSize = 1000
+	// DefaultCategoryCacheSize is the number of category lists, one per environment and API
+	// version, the client caches unless configured otherwise with WithCategoryCache.
+	DefaultCategoryCacheSize = 4
+	// DefaultCooldownCacheSize is the number of items whose institution, and of
+	// institutions and items whose cooldown, WithInstitutionCooldown remembers unless
+	// configured otherwise with WithInstitutionCooldownCache.
+	DefaultCooldownCacheSize = 10000
+)
+
+// CacheStats reports the usage of one of the client's caches, see core.CacheStats.
+type CacheStats = core.CacheStats
+
+// WithInstitutionCache sets the number of institutions the client caches for
+// InstitutionSupports and how long they are reused. A size below 1 disables the cache.
+func WithInstitutionCache(size int, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.institutions = newLRUCache[Institution]("institutions", size, ttl)
+	}
+}
+
+// WithCategoryCache sets the number of category lists the client caches for CategoriesGet,
+// one per environment and API version, and how long they are reused. A size below 1
+// disables the cache.
+func WithCategoryCache(size int, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.categories = newLRUCache[[]Category]("categories", size, ttl)
+	}
+}
+
+// WithInstitutionCooldownCache sets the number of items and institutions
+// WithInstitutionCooldown keeps track of. When full, the least recently used are forgotten,
+// so an item may briefly be sent requests again while its institution is cooling down. It
+// can be passed before or after WithInstitutionCooldown, and discards any state of the
+// cooldown.
+func WithInstitutionCooldownCache(size int) Option {
+	return func(c *Client) {
+		c.cooldownCacheSize = size
+		if c.cooldown != nil {
+			c.cooldown = newInstitutionCooldown(c.cooldown.period, size)
+		}
+	}
+}
+
+// CacheStats returns the usage of each of the client's caches.
+func (c *Client) CacheStats() []CacheStats {
+	stats := []CacheStats{c.institutions.Stats(), c.categories.Stats()}
+	if c.cooldown != nil {
+		stats = append(stats, c.cooldown.institutions.Stats(), c.cooldown.until.Stats())
+	}
+	return stats
+}
+
+// lruCache is a core.LRU that reports its hits and misses as events.
+type lruCache[V any] struct {
+	*core.LRU[V]
+	name string
+}
+
+func newLRUCache[V any](name string, capacity int, ttl time.Duration) *lruCache[V] {
+	return &lruCache[V]{LRU: core.NewLRU[V](name, capacity, ttl), name: name}
+}
+
+// get returns the value cached under key unless it has expired at now, reporting the hit or
+// miss to emit.
+func (c *lruCache[V]) get(key string, now time.Time, emit func(Event)) (V, bool) {
+	value, ok := c.Get(key, now)
+	if ok {
+		emit(Event{Type: EventCacheHit, Cache: c.name})
+	} else {
+		emit(Event{Type: EventCacheMiss, Cache: c.name})
+	}
+	return value, ok
+}
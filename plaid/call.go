@@ -0,0 +1,37 @@
+package plaid
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Call (POST endpoint) sends any request to Plaid and decodes the response into response,
+// for endpoints and fields this package doesn't model yet, e.g. with the generated structs
+// of package openapi. The client's credentials are added to the request unless it sets
+// client_id or secret itself, and the request goes through the client's throttling, hooks
+// and error handling like any other.
+//
+// See https://plaid.com/docs/api/.
+func (c *Client) Call(endpoint string, request, response interface{}) error {
+	return c.CallContext(context.Background(), endpoint, request, response)
+}
+
+// CallContext is like Call but carries a context.
+func (c *Client) CallContext(ctx context.Context, endpoint string, request, response interface{}) error {
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	fields := map[string]json.RawMessage{}
+	if err = json.Unmarshal(encoded, &fields); err != nil {
+		return err
+	}
+	creds := c.creds.Load()
+	if value, ok := fields["client_id"]; !ok || string(value) == `""` {
+		fields["client_id"], _ = json.Marshal(creds.clientID)
+	}
+	if value, ok := fields["secret"]; !ok || string(value) == `""` {
+		fields["secret"], _ = json.Marshal(creds.secret)
+	}
+	return c.postAndDecode(ctx, endpoint, fields, response)
+}
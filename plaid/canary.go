@@ -0,0 +1,170 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// CanaryHealthy and CanaryTransportError are the outcomes a CanaryReport counts besides
+// Plaid error codes.
+const (
+	CanaryHealthy        = "HEALTHY"
+	CanaryTransportError = "TRANSPORT_ERROR"
+)
+
+// CanaryConfig configures a Canary.
+type CanaryConfig struct {
+	// Tokens supplies the access tokens to sample from.
+	Tokens TokenStore
+	// Interval is the time between checks. Defaults to 15 minutes.
+	Interval time.Duration
+	// SampleSize is the number of tokens checked per run. Defaults to 20.
+	SampleSize int
+
+	OnReport func(report CanaryReport)
+	OnError  func(err error)
+}
+
+// CanaryReport is the outcome of one check of a sample of tokens.
+type CanaryReport struct {
+	Time    time.Time
+	Sampled int
+	// Outcomes counts the sampled items by outcome: CanaryHealthy, the error code of an item
+	// in an error state or of a failed request, e.g. "ITEM_LOGIN_REQUIRED" or
+	// "INVALID_API_KEYS", or CanaryTransportError.
+	Outcomes map[string]int
+}
+
+// Healthy returns the number of sampled items that were healthy.
+func (r *CanaryReport) Healthy() int {
+	return r.Outcomes[CanaryHealthy]
+}
+
+// ErrorRate returns the share of sampled items that were not healthy, between 0 and 1.
+func (r *CanaryReport) ErrorRate() float64 {
+	if r.Sampled == 0 {
+		return 0
+	}
+	return float64(r.Sampled-r.Healthy()) / float64(r.Sampled)
+}
+
+// TopErrors returns the error outcomes ordered by descending count.
+func (r *CanaryReport) TopErrors() []string {
+	var codes []string
+	for code := range r.Outcomes {
+		if code != CanaryHealthy {
+			codes = append(codes, code)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if r.Outcomes[codes[i]] != r.Outcomes[codes[j]] {
+			return r.Outcomes[codes[i]] > r.Outcomes[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+	return codes
+}
+
+// Canary periodically fetches a random sample of items from /item/get and reports the
+// distribution of their outcomes, to catch fleet-wide problems such as revoked consents or
+// rotated secrets before users notice them. Reports are delivered through the callbacks of
+// its CanaryConfig.
+//
+// A Canary checks once when started and then every Interval. It is either driven by Run or,
+// as a Component, by Start and Close.
+type Canary struct {
+	client    *Client
+	config    CanaryConfig
+	lifecycle lifecycle
+}
+
+// NewCanary instantiates a Canary that makes its requests through c.
+func NewCanary(c *Client, config CanaryConfig) *Canary {
+	if config.Interval == 0 {
+		config.Interval = 15 * time.Minute
+	}
+	if config.SampleSize <= 0 {
+		config.SampleSize = 20
+	}
+	return &Canary{client: c, config: config}
+}
+
+// Run checks until ctx is done and then returns ctx.Err().
+func (c *Canary) Run(ctx context.Context) error {
+	c.run(ctx, nil)
+	return ctx.Err()
+}
+
+// Start checks in the background until ctx is done or Close is called.
+func (c *Canary) Start(ctx context.Context) error {
+	return c.lifecycle.start(func(stop <-chan struct{}) {
+		c.run(ctx, stop)
+	})
+}
+
+// Close stops a Canary started with Start and waits for an in-flight check to finish.
+func (c *Canary) Close() error {
+	return c.lifecycle.close()
+}
+
+func (c *Canary) run(ctx context.Context, stop <-chan struct{}) {
+	for {
+		report, err := c.Check(ctx)
+		if err != nil {
+			if c.config.OnError != nil {
+				c.config.OnError(err)
+			}
+		} else if c.config.OnReport != nil {
+			c.config.OnReport(*report)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-c.client.clock.After(c.config.Interval):
+		}
+	}
+}
+
+// Check samples tokens once and returns the report.
+func (c *Canary) Check(ctx context.Context) (*CanaryReport, error) {
+	tokens, err := c.config.Tokens.AccessTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sample := append([]string(nil), tokens...)
+	rand.Shuffle(len(sample), func(i, j int) { sample[i], sample[j] = sample[j], sample[i] })
+	if len(sample) > c.config.SampleSize {
+		sample = sample[:c.config.SampleSize]
+	}
+
+	report := &CanaryReport{Time: c.client.clock.Now(), Outcomes: map[string]int{}}
+	for _, token := range sample {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		report.Sampled++
+		report.Outcomes[c.outcome(ctx, token)]++
+	}
+	return report, nil
+}
+
+// outcome checks a single item.
+func (c *Canary) outcome(ctx context.Context, accessToken string) string {
+	res, err := c.client.ItemGetContext(ctx, accessToken)
+	switch {
+	case err == nil && res.Item.Error != nil && res.Item.Error.ErrorCode != "":
+		return res.Item.Error.ErrorCode
+	case err == nil:
+		return CanaryHealthy
+	}
+	var plaidErr plaidError
+	if errors.As(err, &plaidErr) && plaidErr.ErrorCode != "" {
+		return plaidErr.ErrorCode
+	}
+	return CanaryTransportError
+}
@@ -0,0 +1,133 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+)
+
+// AuthFlow is a way of verifying a user's account and routing numbers through Plaid.
+type AuthFlow string
+
+const (
+	// AuthFlowInstantAuth logs in to the institution and verifies the account right away.
+	AuthFlowInstantAuth AuthFlow = "INSTANT_AUTH"
+	// AuthFlowInstantMatch logs in to the institution and matches the account number the
+	// user enters.
+	AuthFlowInstantMatch AuthFlow = "INSTANT_MATCH"
+	// AuthFlowAutomatedMicroDeposits logs in to the institution and verifies the account
+	// with micro-deposits that Plaid confirms automatically.
+	AuthFlowAutomatedMicroDeposits AuthFlow = "AUTOMATED_MICRO_DEPOSITS"
+	// AuthFlowSameDayMicroDeposits verifies the account with micro-deposits the user
+	// confirms manually. It works for any valid routing number.
+	AuthFlowSameDayMicroDeposits AuthFlow = "SAME_DAY_MICRO_DEPOSITS"
+)
+
+// CapabilityReport aggregates the Auth capabilities of the institutions behind a routing
+// number. A capability is reported if any of the institutions supports it.
+type CapabilityReport struct {
+	RoutingNumber  string
+	InstitutionIDs []string
+
+	// Auth reports whether any institution supports the Auth product at all.
+	Auth                   bool
+	InstantAuth            bool
+	InstantMatch           bool
+	AutomatedMicroDeposits bool
+	SameDayMicroDeposits   bool
+}
+
+// Flows returns the supported flows, most seamless first.
+func (r *CapabilityReport) Flows() []AuthFlow {
+	var flows []AuthFlow
+	if r.InstantAuth {
+		flows = append(flows, AuthFlowInstantAuth)
+	}
+	if r.InstantMatch {
+		flows = append(flows, AuthFlowInstantMatch)
+	}
+	if r.AutomatedMicroDeposits {
+		flows = append(flows, AuthFlowAutomatedMicroDeposits)
+	}
+	if r.SameDayMicroDeposits {
+		flows = append(flows, AuthFlowSameDayMicroDeposits)
+	}
+	return flows
+}
+
+// Flow returns the most seamless supported flow.
+func (r *CapabilityReport) Flow() AuthFlow {
+	flows := r.Flows()
+	if len(flows) == 0 {
+		return ""
+	}
+	return flows[0]
+}
+
+// RoutingNumberCapabilities looks up the US institutions that use a routing number and
+// reports which Auth flows they support, so onboarding can pick one before sending the
+// user to Link.
+func (c *Client) RoutingNumberCapabilities(routingNumber string) (*CapabilityReport, error) {
+	return c.RoutingNumberCapabilitiesContext(context.Background(), routingNumber)
+}
+
+// RoutingNumberCapabilitiesContext is like RoutingNumberCapabilities but carries a context.
+func (c *Client) RoutingNumberCapabilitiesContext(ctx context.Context, routingNumber string) (*CapabilityReport, error) {
+	if !ValidRoutingNumber(routingNumber) {
+		return nil, errors.New("invalid routing number " + routingNumber)
+	}
+	report := &CapabilityReport{
+		RoutingNumber: routingNumber,
+		// Same Day Micro-deposits don't depend on the institution being supported.
+		SameDayMicroDeposits: true,
+	}
+	options := &InstitutionsGetOptions{
+		RoutingNumbers:      []string{routingNumber},
+		IncludeAuthMetadata: true,
+	}
+	const count = 500
+	for offset := 0; ; offset += count {
+		institutions, total, err := c.InstitutionsGetContext(ctx, count, offset, []string{"US"}, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, institution := range institutions {
+			report.add(institution)
+		}
+		if len(institutions) == 0 || offset+len(institutions) >= total {
+			return report, nil
+		}
+	}
+}
+
+func (r *CapabilityReport) add(institution Institution) {
+	r.InstitutionIDs = append(r.InstitutionIDs, institution.InstitutionID)
+	for _, product := range institution.Products {
+		if product == "auth" {
+			r.Auth = true
+		}
+	}
+	if institution.AuthMetadata == nil || institution.AuthMetadata.SupportedMethods == nil {
+		return
+	}
+	methods := institution.AuthMetadata.SupportedMethods
+	r.InstantAuth = r.InstantAuth || methods.InstantAuth
+	r.InstantMatch = r.InstantMatch || methods.InstantMatch
+	r.AutomatedMicroDeposits = r.AutomatedMicroDeposits || methods.AutomatedMicroDeposits
+}
+
+// ValidRoutingNumber reports whether s is a well-formed ABA routing number: nine digits
+// with a valid check digit.
+func ValidRoutingNumber(s string) bool {
+	if len(s) != 9 {
+		return false
+	}
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i := 0; i < 9; i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+		sum += int(s[i]-'0') * weights[i%3]
+	}
+	return sum%10 == 0
+}
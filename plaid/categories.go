@@ -1,5 +1,14 @@
 package plaid
 
+import (
+	"context"
+	"time"
+)
+
+// categoryCacheTTL is how long categories are reused before they are fetched again, unless
+// configured otherwise with WithCategoryCache.
+const categoryCacheTTL = 24 * time.Hour
+
 // GetCategories returns information for all categories.
 // See https://plaid.com/docs/api/#category-overview.
 func GetCategories(environment environmentURL) (categories []category, err error) {
@@ -19,3 +28,38 @@ type category struct {
 	ID        string   `json:"id"`        // e.g.: "13001000"
 	Type      string   `json:"type"`      // e.g.: "place"
 }
+
+// CategoriesGet (POST /categories/get) retrieves Plaid's transaction categories. They
+// rarely change, so the client caches them for a day by default, see WithCategoryCache.
+//
+// See https://plaid.com/docs/api/products/transactions/#categoriesget.
+func (c *Client) CategoriesGet() ([]Category, error) {
+	return c.CategoriesGetContext(context.Background())
+}
+
+// CategoriesGetContext is like CategoriesGet but carries a context.
+func (c *Client) CategoriesGetContext(ctx context.Context) ([]Category, error) {
+	key := string(c.environment) + " " + c.apiVersion
+	now := c.clock.Now()
+	if categories, ok := c.categories.get(key, now, c.emit); ok {
+		return append([]Category(nil), categories...), nil
+	}
+	var res struct {
+		Categories []Category `json:"categories"`
+		RequestID  string     `json:"request_id"`
+	}
+	if err := c.postAndDecode(ctx, "/categories/get", struct{}{}, &res); err != nil {
+		return nil, err
+	}
+	c.categories.Add(key, res.Categories, now)
+	return append([]Category(nil), res.Categories...), nil
+}
+
+// Category is a transaction category.
+//
+// See https://plaid.com/docs/api/products/transactions/#categories-get-response-categories.
+type Category struct {
+	CategoryID string   `json:"category_id"` // e.g. "13001000"
+	Group      string   `json:"group"`       // "place" or "special"
+	Hierarchy  []string `json:"hierarchy"`   // e.g. ["Food and Drink", "Bar"]
+}
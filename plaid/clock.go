@@ -0,0 +1,24 @@
+package plaid
+
+import "github.com/wearevest/plaidgo/plaid/core"
+
+// Clock is the source of time used by a Client and the components built on it, e.g. for
+// throttling and for backing off between polls. Tests can substitute a fake clock through
+// WithClock to run deterministically instead of sleeping; see core.Clock.
+type Clock = core.Clock
+
+// WithClock makes a Client use the given clock instead of the system clock. Pass the same
+// clock to the Clock fields of the webhooks package's Router and Consumer.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// Clock returns the client's clock, see WithClock.
+func (c *Client) Clock() Clock {
+	return c.clock
+}
+
+// systemClock is the Clock backed by the time package.
+type systemClock = core.SystemClock
@@ -2,18 +2,32 @@ package plaid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 )
 
 // ConnectAddUser (POST /connect) submits a set of user credentials to add a Connect user.
 //
 // See https://plaid.com/docs/api/#add-user.
+//
+// Deprecated: use Link with LinkTokenCreate and TransactionsSync instead.
 func (c *Client) ConnectAddUser(username, password, pin, institutionType string,
 	options *ConnectOptions) (postRes *postResponse, mfaRes *mfaResponse, err error) {
 
+	return c.ConnectAddUserContext(context.Background(), username, password, pin, institutionType, options)
+}
+
+// ConnectAddUserContext is like ConnectAddUser but carries a context.
+//
+// Deprecated: use Link with LinkTokenCreate and TransactionsSync instead.
+func (c *Client) ConnectAddUserContext(ctx context.Context, username, password, pin, institutionType string,
+	options *ConnectOptions) (postRes *postResponse, mfaRes *mfaResponse, err error) {
+
+	c.deprecated("ConnectAddUser", "Link with LinkTokenCreate and TransactionsSync")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(connectJson{
-		ClientID: c.clientID,
-		Secret:   c.secret,
+		ClientID: creds.clientID,
+		Secret:   creds.secret,
 		Type:     institutionType,
 		Username: username,
 		Password: password,
@@ -23,74 +37,126 @@ func (c *Client) ConnectAddUser(username, password, pin, institutionType string,
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/connect", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/connect", bytes.NewReader(jsonText))
 }
 
 // ConnectStepSendMethod (POST /connect/step) specifies a particular send method for MFA,
 // e.g. `{"mask":"xxx-xxx-5309"}`.
 //
 // See https://plaid.com/docs/api/#mfa-authentication.
+//
+// Deprecated: use Link with LinkTokenCreate instead.
 func (c *Client) ConnectStepSendMethod(accessToken, key, value string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.ConnectStepSendMethodContext(context.Background(), accessToken, key, value)
+}
+
+// ConnectStepSendMethodContext is like ConnectStepSendMethod but carries a context.
+//
+// Deprecated: use Link with LinkTokenCreate instead.
+func (c *Client) ConnectStepSendMethodContext(ctx context.Context, accessToken, key, value string) (postRes *postResponse,
+	mfaRes *mfaResponse, err error) {
+
+	c.deprecated("ConnectStepSendMethod", "Link with LinkTokenCreate")
 	sendMethod := map[string]string{key: value}
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(connectStepSendMethodJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		Options:     connectStepOptions{sendMethod},
 	})
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/connect/step", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/connect/step", bytes.NewReader(jsonText))
 }
 
 // ConnectStep (POST /connect/step) submits an MFA answer for a given access token.
 //
 // See https://plaid.com/docs/api/#mfa-authentication.
+//
+// Deprecated: use Link with LinkTokenCreate instead.
 func (c *Client) ConnectStep(accessToken, answer string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.ConnectStepContext(context.Background(), accessToken, answer)
+}
+
+// ConnectStepContext is like ConnectStep but carries a context.
+//
+// Deprecated: use Link with LinkTokenCreate instead.
+func (c *Client) ConnectStepContext(ctx context.Context, accessToken, answer string) (postRes *postResponse,
+	mfaRes *mfaResponse, err error) {
+
+	c.deprecated("ConnectStep", "Link with LinkTokenCreate")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(connectStepJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		MFA:         answer,
 	})
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/connect/step", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/connect/step", bytes.NewReader(jsonText))
 }
 
 // ConnectGet (POST /connect/get) retrieves account and transaction data for a given access token.
 //
 // See https://plaid.com/docs/api/#get-transactions.
+//
+// Deprecated: use TransactionsSync instead.
 func (c *Client) ConnectGet(accessToken string, options *ConnectGetOptions) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.ConnectGetContext(context.Background(), accessToken, options)
+}
+
+// ConnectGetContext is like ConnectGet but carries a context.
+//
+// Deprecated: use TransactionsSync instead.
+func (c *Client) ConnectGetContext(ctx context.Context, accessToken string, options *ConnectGetOptions) (
+	postRes *postResponse, mfaRes *mfaResponse, err error) {
+
+	c.deprecated("ConnectGet", "TransactionsSync")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(connectGetJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		Options:     options,
 	})
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/connect/get", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/connect/get", bytes.NewReader(jsonText))
 }
 
 // ConnectUpdate (PATCH /connect) updates user credentials for a given access token.
 //
 // See https://plaid.com/docs/api/#update-user.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
 func (c *Client) ConnectUpdate(username, password, pin, accessToken string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.ConnectUpdateContext(context.Background(), username, password, pin, accessToken)
+}
+
+// ConnectUpdateContext is like ConnectUpdate but carries a context.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
+func (c *Client) ConnectUpdateContext(ctx context.Context, username, password, pin, accessToken string) (
+	postRes *postResponse, mfaRes *mfaResponse, err error) {
+
+	c.deprecated("ConnectUpdate", "Link in update mode, see LinkTokenCreateOptions.AccessToken")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(connectUpdateJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		Username:    username,
 		Password:    password,
 		PIN:         pin,
@@ -99,18 +165,31 @@ func (c *Client) ConnectUpdate(username, password, pin, accessToken string) (pos
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.patchAndUnmarshal("/connect", bytes.NewReader(jsonText))
+	return c.patchAndUnmarshal(ctx, "/connect", bytes.NewReader(jsonText))
 }
 
 // ConnectUpdateStep (PATCH /connect/step) updates user credentials and MFA for a given access token.
 //
 // See https://plaid.com/docs/api/#update-user.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
 func (c *Client) ConnectUpdateStep(username, password, pin, mfa, accessToken string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.ConnectUpdateStepContext(context.Background(), username, password, pin, mfa, accessToken)
+}
+
+// ConnectUpdateStepContext is like ConnectUpdateStep but carries a context.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
+func (c *Client) ConnectUpdateStepContext(ctx context.Context, username, password, pin, mfa, accessToken string) (
+	postRes *postResponse, mfaRes *mfaResponse, err error) {
+
+	c.deprecated("ConnectUpdateStep", "Link in update mode, see LinkTokenCreateOptions.AccessToken")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(connectUpdateStepJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		Username:    username,
 		Password:    password,
 		PIN:         pin,
@@ -120,22 +199,33 @@ func (c *Client) ConnectUpdateStep(username, password, pin, mfa, accessToken str
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.patchAndUnmarshal("/connect/step", bytes.NewReader(jsonText))
+	return c.patchAndUnmarshal(ctx, "/connect/step", bytes.NewReader(jsonText))
 }
 
 // ConnectDelete (DELETE /connect) deletes data for a given access token.
 //
 // See https://plaid.com/docs/api/#delete-user.
+//
+// Deprecated: use ItemRemove instead.
 func (c *Client) ConnectDelete(accessToken string) (deleteRes *deleteResponse, err error) {
+	return c.ConnectDeleteContext(context.Background(), accessToken)
+}
+
+// ConnectDeleteContext is like ConnectDelete but carries a context.
+//
+// Deprecated: use ItemRemove instead.
+func (c *Client) ConnectDeleteContext(ctx context.Context, accessToken string) (deleteRes *deleteResponse, err error) {
+	c.deprecated("ConnectDelete", "ItemRemove")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(connectDeleteJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return c.deleteAndUnmarshal("/connect", bytes.NewReader(jsonText))
+	return c.deleteAndUnmarshal(ctx, "/connect", bytes.NewReader(jsonText))
 }
 
 // ConnectOptions represents options associated with adding an Connect user.
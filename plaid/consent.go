@@ -0,0 +1,148 @@
+package plaid
+
+import (
+	"context"
+	"time"
+)
+
+// ConsentMonitorConfig configures a ConsentMonitor.
+type ConsentMonitorConfig struct {
+	// Tokens supplies the access tokens of the items to monitor.
+	Tokens TokenStore
+	// Interval is the time between scans. Defaults to 24 hours.
+	Interval time.Duration
+	// LeadTime is how long before its consent expires an item is warned about. Defaults
+	// to 7 days.
+	LeadTime time.Duration
+
+	// OnWarning is called on every scan for every item whose consent expires within
+	// LeadTime or has expired.
+	OnWarning func(warning ConsentExpiryWarning)
+	OnError   func(accessToken string, err error)
+}
+
+// ConsentExpiryWarning reports an item whose access consent is about to expire or has
+// expired. Send the user through Link in update mode to renew it.
+type ConsentExpiryWarning struct {
+	AccessToken   string
+	ItemID        ItemID
+	InstitutionID string
+	ExpiresAt     time.Time
+	// Remaining is the time left until ExpiresAt, negative once the consent has expired.
+	Remaining time.Duration
+}
+
+// Expired reports whether the consent had already expired when the warning was issued.
+func (w *ConsentExpiryWarning) Expired() bool {
+	return w.Remaining <= 0
+}
+
+// ConsentMonitor periodically fetches a set of items from /item/get and warns about those
+// whose consent_expiration_time is approaching, so that re-consent can be requested before
+// data access stops. Warnings are delivered through the callbacks of its
+// ConsentMonitorConfig.
+//
+// A ConsentMonitor scans once when started and then every Interval. It is either driven
+// by Run or, as a Component, by Start and Close.
+type ConsentMonitor struct {
+	client    *Client
+	config    ConsentMonitorConfig
+	lifecycle lifecycle
+}
+
+// NewConsentMonitor instantiates a ConsentMonitor that makes its requests through c.
+func NewConsentMonitor(c *Client, config ConsentMonitorConfig) *ConsentMonitor {
+	if config.Interval == 0 {
+		config.Interval = 24 * time.Hour
+	}
+	if config.LeadTime == 0 {
+		config.LeadTime = 7 * 24 * time.Hour
+	}
+	return &ConsentMonitor{client: c, config: config}
+}
+
+// Run scans until ctx is done and then returns ctx.Err().
+func (m *ConsentMonitor) Run(ctx context.Context) error {
+	m.run(ctx, nil)
+	return ctx.Err()
+}
+
+// Start scans in the background until ctx is done or Close is called.
+func (m *ConsentMonitor) Start(ctx context.Context) error {
+	return m.lifecycle.start(func(stop <-chan struct{}) {
+		m.run(ctx, stop)
+	})
+}
+
+// Close stops a ConsentMonitor started with Start and waits for an in-flight scan to finish.
+func (m *ConsentMonitor) Close() error {
+	return m.lifecycle.close()
+}
+
+func (m *ConsentMonitor) run(ctx context.Context, stop <-chan struct{}) {
+	for {
+		m.scan(ctx, stop)
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-m.client.clock.After(m.config.Interval):
+		}
+	}
+}
+
+// scan checks every item once.
+func (m *ConsentMonitor) scan(ctx context.Context, stop <-chan struct{}) {
+	tokens, err := m.config.Tokens.AccessTokens(ctx)
+	if err != nil {
+		m.reportError("", err)
+		return
+	}
+	for _, token := range tokens {
+		if ctx.Err() != nil || stopped(stop) {
+			return
+		}
+		warning, err := m.Check(ctx, token)
+		if err != nil {
+			m.reportError(token, err)
+			continue
+		}
+		if warning != nil && m.config.OnWarning != nil {
+			m.config.OnWarning(*warning)
+		}
+	}
+}
+
+// Check fetches an item and returns a warning if its consent expires within LeadTime or
+// has expired, or nil otherwise.
+func (m *ConsentMonitor) Check(ctx context.Context, accessToken string) (*ConsentExpiryWarning, error) {
+	res, err := m.client.ItemGetContext(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if res.Item.ConsentExpirationTime == "" {
+		return nil, nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, res.Item.ConsentExpirationTime)
+	if err != nil {
+		return nil, err
+	}
+	remaining := expiresAt.Sub(m.client.clock.Now())
+	if remaining > m.config.LeadTime {
+		return nil, nil
+	}
+	return &ConsentExpiryWarning{
+		AccessToken:   accessToken,
+		ItemID:        res.Item.ItemId,
+		InstitutionID: res.Item.InstitutionId,
+		ExpiresAt:     expiresAt,
+		Remaining:     remaining,
+	}, nil
+}
+
+func (m *ConsentMonitor) reportError(accessToken string, err error) {
+	if m.config.OnError != nil {
+		m.config.OnError(accessToken, err)
+	}
+}
@@ -0,0 +1,120 @@
+package plaid
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrInstitutionCoolingDown is matched by the errors returned for requests short-circuited
+// by WithInstitutionCooldown, e.g. errors.Is(err, plaid.ErrInstitutionCoolingDown). Use
+// errors.As with an *InstitutionCoolingDownError for the details.
+var ErrInstitutionCoolingDown = errors.New("institution is cooling down")
+
+// InstitutionCoolingDownError is returned instead of sending a request for an item whose
+// institution recently reported being down.
+type InstitutionCoolingDownError struct {
+	// InstitutionID is empty if the item's institution is not known yet, in which case
+	// only the item is cooling down.
+	InstitutionID string
+	Until         time.Time
+}
+
+func (e *InstitutionCoolingDownError) Error() string {
+	if e.InstitutionID == "" {
+		return "item's institution is cooling down until " + e.Until.Format(time.RFC3339)
+	}
+	return "institution " + e.InstitutionID + " is cooling down until " + e.Until.Format(time.RFC3339)
+}
+
+// Is makes the error match ErrInstitutionCoolingDown.
+func (e *InstitutionCoolingDownError) Is(target error) bool {
+	return target == ErrInstitutionCoolingDown
+}
+
+// WithInstitutionCooldown makes the client stop sending requests for the items of an
+// institution for period after one of them failed with INSTITUTION_DOWN or
+// INSTITUTION_NOT_RESPONDING, returning an *InstitutionCoolingDownError instead. Items are
+// mapped to their institution from the item in earlier successful responses; until an
+// item's institution is known, only the failed item itself cools down.
+func WithInstitutionCooldown(period time.Duration) Option {
+	return func(c *Client) {
+		size := c.cooldownCacheSize
+		if size == 0 {
+			size = DefaultCooldownCacheSize
+		}
+		c.cooldown = newInstitutionCooldown(period, size)
+	}
+}
+
+func newInstitutionCooldown(period time.Duration, size int) *institutionCooldown {
+	return &institutionCooldown{
+		period:       period,
+		institutions: newLRUCache[string]("item_institutions", size, 0),
+		until:        newLRUCache[time.Time]("institution_cooldowns", size, 0),
+	}
+}
+
+// institutionCooldown keeps track of institutions that are down. Items are identified by
+// the tokenHash of their access token.
+type institutionCooldown struct {
+	period time.Duration
+
+	mu           sync.Mutex
+	institutions *lruCache[string]    // token hash -> institution id
+	until        *lruCache[time.Time] // cooldown key -> end of cooldown
+}
+
+// key returns the key an item's cooldown is tracked under and the item's institution, if
+// known. It must be called with mu held.
+func (c *institutionCooldown) key(token string, emit func(Event)) (string, string) {
+	if institutionID, ok := c.institutions.get(token, time.Time{}, emit); ok {
+		return "institution:" + institutionID, institutionID
+	}
+	return "item:" + token, ""
+}
+
+// check returns an error if the item of token is cooling down at now.
+func (c *institutionCooldown) check(token string, now time.Time, emit func(Event)) error {
+	if c == nil || token == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key, institutionID := c.key(token, emit)
+	until, ok := c.until.Get(key, now)
+	if !ok {
+		return nil
+	}
+	if !now.Before(until) {
+		c.until.Remove(key)
+		emit(Event{Type: EventCooldownEnded, InstitutionID: institutionID, Until: until})
+		return nil
+	}
+	return &InstitutionCoolingDownError{InstitutionID: institutionID, Until: until}
+}
+
+// observe learns the institution of an item from a successful response, or starts a
+// cooldown if the response reports the institution as down.
+func (c *institutionCooldown) observe(token string, statusCode int, summary responseSummary, now time.Time,
+	emit func(Event)) {
+
+	if c == nil || token == "" || statusCode == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if statusCode == 200 {
+		if summary.Item.InstitutionID != "" {
+			c.institutions.Add(token, summary.Item.InstitutionID, now)
+		}
+		return
+	}
+	if summary.ErrorCode == "INSTITUTION_DOWN" || summary.ErrorCode == "INSTITUTION_NOT_RESPONDING" {
+		key, institutionID := c.key(token, emit)
+		if _, cooling := c.until.Get(key, now); !cooling {
+			emit(Event{Type: EventCooldownStarted, InstitutionID: institutionID, Until: now.Add(c.period)})
+		}
+		c.until.Add(key, now.Add(c.period), now)
+	}
+}
@@ -0,0 +1,11 @@
+package core
+
+// UserAddress is the postal address of a user, as sent to Plaid when creating link tokens,
+// identity verifications and transfers.
+type UserAddress struct {
+	Street     string `json:"street,omitempty"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"` // ISO 3166-1 alpha-2, e.g. "US"
+}
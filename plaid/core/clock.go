@@ -0,0 +1,32 @@
+package core
+
+import "time"
+
+// Clock is the source of time of the plaid package's Client and of the subpackages'
+// components that wait or timestamp, e.g. the webhooks Router. Tests can substitute a fake
+// clock to run deterministically instead of sleeping.
+type Clock interface {
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the
+	// returned channel, like time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock backed by the time package.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// ClockOr returns clock, or the SystemClock if clock is nil.
+func ClockOr(clock Clock) Clock {
+	if clock == nil {
+		return SystemClock{}
+	}
+	return clock
+}
@@ -0,0 +1,33 @@
+// Package core holds the types shared by the plaid package and its per-product
+// subpackages, starting with Plaid's error format.
+//
+// The plaid package is being split into this package, the transport and retry packages the
+// Client is built on, and product subpackages such as auth, identity, transactions, transfer
+// and webhooks, so that programs only compile the products they use. Types move out of plaid
+// one product at a time; plaid keeps an alias for every type it gave up, so existing code
+// continues to compile unchanged. The subpackages may import core but never plaid.
+package core
+
+import (
+	"fmt"
+)
+
+// Error is an error returned by the Plaid API.
+//
+// See https://plaid.com/docs/errors/.
+type Error struct {
+	// List of all errors: https://github.com/plaid/support/blob/master/errors.md
+	ErrorCode      string `json:"error_code"`
+	ErrorType      string `json:"error_type"`
+	ErrorMessage   string `json:"error_message"`
+	DisplayMessage string `json:"display_message"`
+	RequestID      string `json:"request_id"`
+
+	// StatusCode needs to manually set from the http response
+	StatusCode int
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("Plaid Error - http status: %s, code: %s, message: %s, display: %s",
+		e.ErrorCode, e.ErrorType, e.ErrorMessage, e.DisplayMessage)
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// DecodeError is returned when a response or webhook body can't be decoded. Path locates
+// the offending value in the body, e.g. "mfa[1].answers[0]", and is empty when the body as a
+// whole is at fault, e.g. because it was cut off.
+type DecodeError struct {
+	Path string
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Path == "" {
+		return "can't decode JSON: " + e.Err.Error()
+	}
+	return "can't decode JSON at " + e.Path + ": " + e.Err.Error()
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Decode unmarshals data into v like json.Unmarshal, but reports failures as a
+// *DecodeError carrying the JSON path of the value that failed to decode. It also rejects
+// IDs that are empty strings, see ItemID.
+func Decode(data []byte, v interface{}) error {
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return validateIDs(data, v)
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return &DecodeError{Path: PathAt(data, syntaxErr.Offset), Err: err}
+	case errors.As(err, &typeErr):
+		return &DecodeError{Path: PathAt(data, typeErr.Offset), Err: err}
+	}
+	return &DecodeError{Err: err}
+}
+
+// MissingFieldError returns a *DecodeError for a required field that is absent or null.
+func MissingFieldError(path string) error {
+	return &DecodeError{Path: path, Err: errors.New("missing required field")}
+}
+
+// pathFrame is an object or array PathAt is inside of.
+type pathFrame struct {
+	array     bool
+	index     int    // of the current element of an array
+	key       string // of the current member of an object
+	expectKey bool
+}
+
+// PathAt returns the JSON path of the value at or before offset in data, e.g.
+// "accounts[2].balances.current", the way the offsets of json.SyntaxError and
+// json.UnmarshalTypeError point into a document. It returns the path of the value being
+// read where data stops being valid JSON, and "" for the document as a whole.
+func PathAt(data []byte, offset int64) string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var stack []pathFrame
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return formatPath(stack)
+		}
+		if len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if key, ok := token.(string); ok && !top.array && top.expectKey {
+				top.key, top.expectKey = key, false
+				continue
+			}
+		}
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			if decoder.InputOffset() >= offset {
+				return formatPath(stack)
+			}
+			advance(stack)
+			continue
+		}
+		if decoder.InputOffset() >= offset {
+			return formatPath(stack)
+		}
+		if delim, ok := token.(json.Delim); ok {
+			stack = append(stack, pathFrame{array: delim == '[', expectKey: delim == '{'})
+			continue
+		}
+		advance(stack)
+	}
+}
+
+// advance moves the innermost frame past the value just read.
+func advance(stack []pathFrame) {
+	if len(stack) == 0 {
+		return
+	}
+	top := &stack[len(stack)-1]
+	if top.array {
+		top.index++
+	} else {
+		top.expectKey = true
+	}
+}
+
+func formatPath(stack []pathFrame) string {
+	var path []byte
+	for i, frame := range stack {
+		if frame.array {
+			path = append(path, '[')
+			path = strconv.AppendInt(path, int64(frame.index), 10)
+			path = append(path, ']')
+			continue
+		}
+		if frame.expectKey {
+			// Between members the path is that of the object.
+			break
+		}
+		if i > 0 {
+			path = append(path, '.')
+		}
+		path = append(path, frame.key...)
+	}
+	return string(path)
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPathAt(t *testing.T) {
+	data := []byte(`{"accounts": [{"account_id": "a", "balances": {"current": 1}}, {"mask": [1, 2]}], "item": null}`)
+	tests := []struct {
+		name   string
+		data   []byte
+		offset int64
+		want   string
+	}{
+		{name: "start", data: data, offset: 0, want: ""},
+		{name: "member of element", data: data, offset: 32, want: "accounts[0].account_id"},
+		{name: "nested member", data: data, offset: 59, want: "accounts[0].balances.current"},
+		{name: "nested array", data: data, offset: 77, want: "accounts[1].mask[1]"},
+		{name: "closed array", data: data, offset: 78, want: "accounts[1].mask"},
+		{name: "after array", data: data, offset: 94, want: "item"},
+		{name: "truncated", data: []byte(`{"accounts": [{"balances": {"curr`), offset: 100, want: "accounts[0].balances"},
+		{name: "invalid", data: []byte(`{"a": [1, x]}`), offset: 100, want: "a[1]"},
+		{name: "scalar", data: []byte(`7`), offset: 1, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PathAt(tt.data, tt.offset); got != tt.want {
+				t.Errorf("PathAt(%d) = %q, want %q", tt.offset, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodePath(t *testing.T) {
+	var v struct {
+		Accounts []struct {
+			Mask string `json:"mask"`
+		} `json:"accounts"`
+	}
+	err := Decode([]byte(`{"accounts": [{"mask": "0000"}, {"mask": 1234}]}`), &v)
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Path != "accounts[1].mask" {
+		t.Fatalf("got %v, want a *DecodeError at accounts[1].mask", err)
+	}
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// FlexString is a string field that Plaid sometimes sends as a number, depending on the
+// institution: masks such as 1234 instead of "1234", store numbers and postal codes. It
+// decodes strings as they are, numbers to their literal text, e.g. 0.50 to "0.50", and
+// booleans to "true" or "false", and always encodes as a string. Like for a plain string,
+// null leaves the field unchanged.
+//
+// Convert it with string(s) where a plain string is needed.
+type FlexString string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *FlexString) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return errors.New("empty JSON value for a string")
+	}
+	switch data[0] {
+	case '"':
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		*s = FlexString(text)
+	case 'n':
+		if string(data) != "null" {
+			return errors.New("invalid JSON value " + string(data) + " for a string")
+		}
+	case 't', 'f':
+		var b bool
+		if err := json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+		*s = FlexString(data)
+	case '{', '[':
+		return errors.New("can't decode a JSON object or array into a string")
+	default:
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return err
+		}
+		*s = FlexString(number)
+	}
+	return nil
+}
+
+// String returns s as a plain string.
+func (s FlexString) String() string {
+	return string(s)
+}
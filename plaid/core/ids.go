@@ -0,0 +1,196 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ItemID identifies an item, i.e. a login at an institution.
+//
+// The ID types are distinct so that passing an account ID where an item ID is expected
+// doesn't compile. IDs are validated when a response or webhook is decoded with Decode: an
+// ID only decodes from a non-empty JSON string or null, and anything else in its place is a
+// *DecodeError rather than a silently converted or empty value. An absent ID is the empty
+// string, and is encoded as null like Plaid does.
+type ItemID string
+
+// AccountID identifies an account of an item. It changes if the item is removed and the
+// account linked again.
+type AccountID string
+
+// TransactionID identifies a transaction. A pending transaction and the posted transaction
+// replacing it have different IDs; the posted one refers to the pending one.
+type TransactionID string
+
+// Validate reports an error if the ID is empty.
+func (id ItemID) Validate() error {
+	return validateID(string(id), "item id")
+}
+
+// Validate reports an error if the ID is empty.
+func (id AccountID) Validate() error {
+	return validateID(string(id), "account id")
+}
+
+// Validate reports an error if the ID is empty.
+func (id TransactionID) Validate() error {
+	return validateID(string(id), "transaction id")
+}
+
+func validateID(id, name string) error {
+	if id == "" {
+		return errors.New(name + " must not be empty")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id ItemID) MarshalJSON() ([]byte, error) {
+	return marshalID(string(id))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id AccountID) MarshalJSON() ([]byte, error) {
+	return marshalID(string(id))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (id TransactionID) MarshalJSON() ([]byte, error) {
+	return marshalID(string(id))
+}
+
+func marshalID(id string) ([]byte, error) {
+	if id == "" {
+		return []byte("null"), nil
+	}
+	return json.Marshal(id)
+}
+
+// idTypes are the ID types Decode validates.
+var idTypes = map[reflect.Type]bool{
+	reflect.TypeOf(ItemID("")):        true,
+	reflect.TypeOf(AccountID("")):     true,
+	reflect.TypeOf(TransactionID("")): true,
+}
+
+// holdsIDs caches whether values of a type may hold IDs, so that validateIDs only walks the
+// parts of a value that do.
+var holdsIDs sync.Map // reflect.Type -> bool
+
+// validateIDs returns a *DecodeError for the first ID that data, decoded into v, holds as an
+// empty string. Other invalid IDs, such as numbers, already fail to decode.
+func validateIDs(data []byte, v interface{}) error {
+	if !bytes.Contains(data, []byte(`""`)) {
+		return nil
+	}
+	var raw interface{}
+	if json.Unmarshal(data, &raw) != nil {
+		return nil
+	}
+	if path, ok := findEmptyID(reflect.ValueOf(v), raw, nil); ok {
+		return &DecodeError{Path: formatPath(path), Err: errors.New("empty ID")}
+	}
+	return nil
+}
+
+// findEmptyID walks v along raw, the same JSON decoded generically, and returns the path of
+// the first ID raw holds as an empty string.
+func findEmptyID(v reflect.Value, raw interface{}, path []pathFrame) ([]pathFrame, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if idTypes[v.Type()] {
+		value, ok := raw.(string)
+		return path, ok && value == ""
+	}
+	if !mayHoldIDs(v.Type(), map[reflect.Type]bool{}) {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		object, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			switch {
+			case name == "-" || !field.IsExported() && !field.Anonymous:
+				continue
+			case field.Anonymous && name == "":
+				// Embedded struct fields are promoted into the object.
+				if found, ok := findEmptyID(v.Field(i), object, path); ok {
+					return found, true
+				}
+				continue
+			case name == "":
+				name = field.Name
+			}
+			member, ok := object[name]
+			if !ok {
+				continue
+			}
+			if found, ok := findEmptyID(v.Field(i), member, append(path, pathFrame{key: name})); ok {
+				return found, true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		array, ok := raw.([]interface{})
+		if !ok {
+			return nil, false
+		}
+		for i := 0; i < v.Len() && i < len(array); i++ {
+			if found, ok := findEmptyID(v.Index(i), array[i], append(path, pathFrame{array: true, index: i})); ok {
+				return found, true
+			}
+		}
+	case reflect.Map:
+		object, ok := raw.(map[string]interface{})
+		if !ok || v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		for _, key := range v.MapKeys() {
+			name := key.String()
+			if found, ok := findEmptyID(v.MapIndex(key), object[name], append(path, pathFrame{key: name})); ok {
+				return found, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// mayHoldIDs reports whether values of t may hold IDs. visiting guards against recursive
+// types.
+func mayHoldIDs(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if cached, ok := holdsIDs.Load(t); ok {
+		return cached.(bool)
+	}
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+	holds := idTypes[t]
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		holds = mayHoldIDs(t.Elem(), visiting)
+	case reflect.Map:
+		holds = t.Key().Kind() == reflect.String && mayHoldIDs(t.Elem(), visiting)
+	case reflect.Interface:
+		holds = true
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !holds; i++ {
+			holds = mayHoldIDs(t.Field(i).Type, visiting)
+		}
+	}
+	delete(visiting, t)
+	holdsIDs.Store(t, holds)
+	return holds
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestDecodeIDs(t *testing.T) {
+	type account struct {
+		AccountID AccountID `json:"account_id"`
+		ItemID    ItemID    `json:"item_id"`
+	}
+	type response struct {
+		Accounts []account `json:"accounts"`
+	}
+	cases := []struct {
+		body string
+		want ItemID
+		path string // of the DecodeError, if any
+	}{
+		{`{"accounts": [{"account_id": "a1", "item_id": "i1"}]}`, "i1", ""},
+		{`{"accounts": [{"account_id": "a1", "item_id": null}]}`, "", ""},
+		{`{"accounts": [{"account_id": "a1"}]}`, "", ""},
+		{`{"accounts": [{"account_id": "a1"}, {"account_id": "a2", "item_id": ""}]}`, "", "accounts[1].item_id"},
+		{`{"accounts": [{"account_id": 42, "item_id": "i1"}]}`, "", "accounts[0].account_id"},
+		{`{"accounts": [{"account_id": "a1", "item_id": {"id": "i1"}}]}`, "", "accounts[0].item_id"},
+	}
+	for _, c := range cases {
+		var res response
+		err := Decode([]byte(c.body), &res)
+		var decodeErr *DecodeError
+		switch {
+		case c.path == "" && err != nil:
+			t.Errorf("%s: %v", c.body, err)
+		case c.path == "" && res.Accounts[0].ItemID != c.want:
+			t.Errorf("%s: item id %q, want %q", c.body, res.Accounts[0].ItemID, c.want)
+		case c.path != "" && !errors.As(err, &decodeErr):
+			t.Errorf("%s: error %v, want a *DecodeError", c.body, err)
+		case c.path != "" && decodeErr.Path != c.path:
+			t.Errorf("%s: error at %q, want %q", c.body, decodeErr.Path, c.path)
+		}
+	}
+}
+
+func TestEncodeIDs(t *testing.T) {
+	encoded, err := json.Marshal(struct {
+		ItemID               ItemID        `json:"item_id"`
+		PendingTransactionID TransactionID `json:"pending_transaction_id"`
+	}{ItemID: "i1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"item_id":"i1","pending_transaction_id":null}`; string(encoded) != want {
+		t.Errorf("encoded %s, want %s", encoded, want)
+	}
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"strings"
+)
+
+// Localizer translates the user-facing strings of the client, such as error messages,
+// remediation labels and MFA prompts, so that products can show them in the user's
+// language without forking the client's message tables.
+//
+// key identifies the string, e.g. "error.ITEM_LOGIN_REQUIRED" or "mfa.list.phone", and
+// english is its English text. Placeholders in braces, e.g. "{mask}", are filled in after
+// translation and must be kept. A Localizer without a translation for key returns
+// english.
+type Localizer interface {
+	Localize(key, english string) string
+}
+
+// LocalizerFunc adapts a function to a Localizer.
+type LocalizerFunc func(key, english string) string
+
+// Localize implements Localizer.
+func (f LocalizerFunc) Localize(key, english string) string {
+	return f(key, english)
+}
+
+// Localize translates english with l, or returns it unchanged if l is nil, and then fills
+// in the placeholders given as name-value pairs, e.g. "mask", "1234".
+func Localize(l Localizer, key, english string, placeholders ...string) string {
+	text := english
+	if l != nil {
+		text = l.Localize(key, english)
+	}
+	if len(placeholders) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, len(placeholders))
+	for i := 0; i+1 < len(placeholders); i += 2 {
+		pairs = append(pairs, "{"+placeholders[i]+"}", placeholders[i+1])
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// LocalizedUserMessage is like UserMessage but translates the vetted message with l. A nil
+// l returns the English message.
+func (e Error) LocalizedUserMessage(l Localizer) string {
+	if l == nil {
+		return e.UserMessage()
+	}
+	return e.UserMessageIn(l.Localize)
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// CacheStats reports the usage of a cache.
+type CacheStats struct {
+	Name      string // e.g. "institutions"
+	Capacity  int
+	Size      int
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64 // entries dropped to make room, not counting expired ones
+}
+
+// HitRate returns the fraction of lookups that were hits, or 0 if there were none.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// LRU is a concurrency-safe cache that holds up to a fixed number of entries and evicts the
+// least recently used one when full. It backs every cache of plaid and its subpackages, so
+// that none of them grows without bound.
+type LRU[V any] struct {
+	name     string
+	capacity int
+	ttl      time.Duration // zero if entries don't expire
+
+	mu                      sync.Mutex
+	entries                 map[string]*list.Element
+	order                   *list.List // of *lruEntry[V], most recently used first
+	hits, misses, evictions uint64
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+	added time.Time
+}
+
+// NewLRU returns an LRU named name, for CacheStats, holding up to capacity entries for ttl
+// each. A capacity below 1 disables the cache, and a ttl of zero keeps entries until they
+// are evicted.
+func NewLRU[V any](name string, capacity int, ttl time.Duration) *LRU[V] {
+	return &LRU[V]{
+		name:     name,
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Get returns the value cached under key unless it has expired at now.
+func (c *LRU[V]) Get(key string, now time.Time) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if ok && c.ttl > 0 && now.Sub(element.Value.(*lruEntry[V]).added) >= c.ttl {
+		c.remove(element)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		var zero V
+		return zero, false
+	}
+	c.hits++
+	c.order.MoveToFront(element)
+	return element.Value.(*lruEntry[V]).value, true
+}
+
+// Add caches value under key, evicting the least recently used entry if the cache is full.
+func (c *LRU[V]) Add(key string, value V, now time.Time) {
+	if c.capacity < 1 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		element.Value = &lruEntry[V]{key: key, value: value, added: now}
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value, added: now})
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+}
+
+// Remove drops the value cached under key, if any.
+func (c *LRU[V]) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+func (c *LRU[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry[V]).key)
+}
+
+// Stats returns the usage of the cache.
+func (c *LRU[V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{
+		Name:      c.name,
+		Capacity:  c.capacity,
+		Size:      c.order.Len(),
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	c := NewLRU[int]("test", 2, time.Hour)
+	c.Add("a", 1, now)
+	c.Add("b", 2, now)
+	if v, ok := c.Get("a", now); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v, want 1, true", v, ok)
+	}
+	// b is now the least recently used.
+	c.Add("c", 3, now)
+	if _, ok := c.Get("b", now); ok {
+		t.Fatal("Get(b) hit, want it evicted")
+	}
+	if v, ok := c.Get("c", now.Add(time.Hour-1)); !ok || v != 3 {
+		t.Fatalf("Get(c) = %v, %v, want 3, true", v, ok)
+	}
+	if _, ok := c.Get("c", now.Add(time.Hour)); ok {
+		t.Fatal("Get(c) hit, want it expired")
+	}
+	c.Remove("a")
+	want := CacheStats{Name: "test", Capacity: 2, Size: 0, Hits: 2, Misses: 2, Evictions: 1}
+	if stats := c.Stats(); stats != want {
+		t.Fatalf("Stats = %+v, want %+v", stats, want)
+	}
+	if rate := want.HitRate(); rate != 0.5 {
+		t.Fatalf("HitRate = %v, want 0.5", rate)
+	}
+}
+
+func TestLRUDisabled(t *testing.T) {
+	c := NewLRU[int]("test", 0, 0)
+	c.Add("a", 1, time.Time{})
+	if _, ok := c.Get("a", time.Time{}); ok {
+		t.Fatal("Get(a) hit, want a disabled cache to hold nothing")
+	}
+}
@@ -0,0 +1,72 @@
+package core
+
+// DefaultUserMessage is the user message of errors no vetted message is known for.
+const DefaultUserMessage = "Something went wrong. Please try again later."
+
+// DefaultUserMessageKey is the key DefaultUserMessage is translated under.
+const DefaultUserMessageKey = "error.DEFAULT"
+
+// userMessages are the vetted user messages by error code.
+var userMessages = map[string]string{
+	"ITEM_LOGIN_REQUIRED":             "Your bank connection needs to be refreshed. Please sign in to your bank again.",
+	"PENDING_EXPIRATION":              "Your bank connection is about to expire. Please sign in to your bank again.",
+	"INSUFFICIENT_CREDENTIALS":        "Please finish signing in to your bank to connect your account.",
+	"ACCESS_NOT_GRANTED":              "Please grant access to your account data to keep your bank connected.",
+	"NO_ACCOUNTS":                     "No eligible accounts were found. Please sign in to your bank again and choose an account.",
+	"NO_AUTH_ACCOUNTS":                "No checking or savings accounts were found. Please connect a different account.",
+	"NO_INVESTMENT_ACCOUNTS":          "No investment accounts were found. Please connect a different account.",
+	"NO_LIABILITY_ACCOUNTS":           "No loan or credit accounts were found. Please connect a different account.",
+	"INVALID_CREDENTIALS":             "The username or password you entered is incorrect. Please try again.",
+	"INVALID_MFA":                     "The security answer or code you entered is incorrect. Please try again.",
+	"INVALID_SEND_METHOD":             "That way of receiving a security code isn't available. Please choose another one.",
+	"INVALID_UPDATED_USERNAME":        "The username you entered doesn't match your bank connection. Please try again.",
+	"ITEM_LOCKED":                     "Your bank account is locked. Please contact your bank to unlock it, then sign in again.",
+	"USER_SETUP_REQUIRED":             "Your bank requires you to finish setting up your account on its website first.",
+	"ITEM_NOT_SUPPORTED":              "This account can't be connected. Please try another account or connection method.",
+	"MFA_NOT_SUPPORTED":               "Your bank's security checks aren't supported. Please try another connection method.",
+	"PRODUCTS_NOT_SUPPORTED":          "This account can't be used for this feature. Please try another account.",
+	"USER_PERMISSION_REVOKED":         "You revoked access to your bank connection. Please connect your bank again to continue.",
+	"INVALID_ACCESS_TOKEN":            "Your bank connection was lost. Please connect your bank again.",
+	"ITEM_NOT_FOUND":                  "Your bank connection was lost. Please connect your bank again.",
+	"INSTITUTION_NO_LONGER_SUPPORTED": "Your bank is no longer supported. Please connect another account.",
+	"PRODUCT_NOT_READY":               "We're still fetching your account data. Please check back in a few minutes.",
+	"INSTITUTION_DOWN":                "Your bank is temporarily unavailable. Please try again later.",
+	"INSTITUTION_NOT_RESPONDING":      "Your bank is temporarily unavailable. Please try again later.",
+	"INSTITUTION_NOT_AVAILABLE":       "Your bank is temporarily unavailable. Please try again later.",
+	"PLANNED_MAINTENANCE":             "Bank connections are under maintenance. Please try again later.",
+	"RATE_LIMIT_EXCEEDED":             "Something went wrong. Please try again in a minute.",
+	"INTERNAL_SERVER_ERROR":           "Something went wrong. Please try again in a minute.",
+}
+
+// userMessagesByType apply to errors whose code has no entry in userMessages.
+var userMessagesByType = map[string]string{
+	"INSTITUTION_ERROR":   "Your bank is temporarily unavailable. Please try again later.",
+	"RATE_LIMIT_EXCEEDED": "Something went wrong. Please try again in a minute.",
+}
+
+// UserMessage returns a message about the error that is safe to show to end users: Plaid's
+// display message if it sent one, and otherwise a vetted English message for the error's
+// code or type. It never returns ErrorMessage, which is meant for developers and can name
+// fields, tokens or internals.
+func (e Error) UserMessage() string {
+	return e.UserMessageIn(nil)
+}
+
+// UserMessageIn is like UserMessage, but passes the vetted message to translate along with
+// its key, "error." followed by the error code or type, or DefaultUserMessageKey. Plaid's
+// display message is returned untranslated. A nil translate returns the English message.
+func (e Error) UserMessageIn(translate func(key, english string) string) string {
+	if e.DisplayMessage != "" {
+		return e.DisplayMessage
+	}
+	key, message := DefaultUserMessageKey, DefaultUserMessage
+	if m, ok := userMessages[e.ErrorCode]; ok {
+		key, message = "error."+e.ErrorCode, m
+	} else if m, ok := userMessagesByType[e.ErrorType]; ok {
+		key, message = "error."+e.ErrorType, m
+	}
+	if translate == nil {
+		return message
+	}
+	return translate(key, message)
+}
@@ -0,0 +1,224 @@
+package plaid
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BillingModel is how Plaid bills the requests of a product.
+//
+// See https://plaid.com/docs/account/billing/.
+type BillingModel string
+
+const (
+	// BillingFree requests aren't billed, e.g. /item/get or /institutions/get.
+	BillingFree BillingModel = "free"
+	// BillingPerRequest requests are billed every time they succeed, e.g.
+	// /accounts/balance/get.
+	BillingPerRequest BillingModel = "per_request"
+	// BillingOneTime products are billed once per item, on the first successful request.
+	BillingOneTime BillingModel = "one_time"
+	// BillingSubscription products are billed monthly per item, however many requests are
+	// made for it.
+	BillingSubscription BillingModel = "subscription"
+)
+
+// CostTag is the product and billing model a request is accounted to.
+type CostTag struct {
+	// Product is e.g. "transactions", or empty for platform endpoints such as /item/get.
+	Product string
+	Billing BillingModel
+}
+
+// costTags maps endpoint prefixes to their tags. The longest matching prefix wins; endpoints
+// matching none are free.
+var costTags = map[string]CostTag{
+	"/accounts/balance/get":   {"balance", BillingPerRequest},
+	"/auth/":                  {"auth", BillingOneTime},
+	"/identity/":              {"identity", BillingOneTime},
+	"/identity_verification/": {"identity_verification", BillingPerRequest},
+	"/transactions/":          {"transactions", BillingSubscription},
+	"/investments/":           {"investments", BillingSubscription},
+	"/liabilities/":           {"liabilities", BillingSubscription},
+	"/asset_report/":          {"assets", BillingPerRequest},
+	"/credit/":                {"income", BillingOneTime},
+	"/transfer/":              {"transfer", BillingPerRequest},
+	"/signal/":                {"signal", BillingPerRequest},
+	"/sandbox/":               {"", BillingFree},
+}
+
+// CostTagOf returns the product and billing model of requests to endpoint.
+func CostTagOf(endpoint string) CostTag {
+	tag, matchLen := CostTag{Billing: BillingFree}, -1
+	for prefix, t := range costTags {
+		if strings.HasPrefix(endpoint, prefix) && len(prefix) > matchLen {
+			tag, matchLen = t, len(prefix)
+		}
+	}
+	return tag
+}
+
+type tenantKey struct{}
+
+// ContextWithTenant returns a copy of ctx carrying the tenant that requests made with it
+// are accounted to, see CostAccountingHook.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant set with ContextWithTenant, or "" if there is none.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// CostUsage is a request as accounted by CostAccountingHook.
+type CostUsage struct {
+	Time     time.Time
+	Tenant   string
+	Endpoint string
+	CostTag
+	// Billable reports whether the request may be billed: it succeeded and its product isn't
+	// free. Whether a one-time or subscription product is actually billed depends on the
+	// item's earlier requests, which the client doesn't know.
+	Billable bool
+}
+
+// CostRecorder receives the usage accounted by CostAccountingHook, e.g. to increment
+// metrics labeled by tenant and product. CostCounter is one.
+type CostRecorder interface {
+	RecordCost(ctx context.Context, usage CostUsage)
+}
+
+// CostAccountingHook returns a hook that tags every request with its product and billing
+// model and reports it to recorder, along with its tenant. tenant returns the tenant of a
+// request's context and defaults to TenantFromContext.
+func CostAccountingHook(recorder CostRecorder, tenant func(ctx context.Context) string) Hook {
+	if tenant == nil {
+		tenant = TenantFromContext
+	}
+	return HookFunc(func(ctx context.Context, event RequestEvent) {
+		tag := CostTagOf(event.Endpoint)
+		recorder.RecordCost(ctx, CostUsage{
+			Time:     event.Start,
+			Tenant:   tenant(ctx),
+			Endpoint: event.Endpoint,
+			CostTag:  tag,
+			Billable: event.StatusCode == 200 && event.Err == nil && tag.Billing != BillingFree,
+		})
+	})
+}
+
+// CostCount is the number of requests of a tenant for a product.
+type CostCount struct {
+	Tenant string
+	CostTag
+	Requests int
+	// Billable is the number of those requests that may be billed.
+	Billable int
+}
+
+// CostCounter is a CostRecorder that counts requests per tenant and product, for
+// chargeback reports, and calls OnRunaway when a tenant's request volume for a product
+// exceeds Threshold within Window, e.g. because a sync loop went haywire. Counts are kept
+// in memory.
+type CostCounter struct {
+	// Threshold, if positive, is the number of requests per tenant and product within
+	// Window above which OnRunaway is called, once per window.
+	Threshold int
+	// Window defaults to an hour.
+	Window    time.Duration
+	OnRunaway func(tenant string, tag CostTag, requests int)
+
+	mu      sync.Mutex
+	counts  map[costKey]*CostCount
+	windows map[costKey]*costWindow
+}
+
+type costKey struct {
+	tenant string
+	tag    CostTag
+}
+
+type costWindow struct {
+	start    time.Time
+	requests int
+	alerted  bool
+}
+
+// RecordCost implements CostRecorder.
+func (c *CostCounter) RecordCost(ctx context.Context, usage CostUsage) {
+	key := costKey{usage.Tenant, usage.CostTag}
+	c.mu.Lock()
+	if c.counts == nil {
+		c.counts, c.windows = map[costKey]*CostCount{}, map[costKey]*costWindow{}
+	}
+	count, ok := c.counts[key]
+	if !ok {
+		count = &CostCount{Tenant: usage.Tenant, CostTag: usage.CostTag}
+		c.counts[key] = count
+	}
+	count.Requests++
+	if usage.Billable {
+		count.Billable++
+	}
+	if c.Threshold <= 0 || c.OnRunaway == nil {
+		c.mu.Unlock()
+		return
+	}
+	window := c.Window
+	if window <= 0 {
+		window = time.Hour
+	}
+	w, ok := c.windows[key]
+	if !ok || usage.Time.Sub(w.start) >= window {
+		w = &costWindow{start: usage.Time}
+		c.windows[key] = w
+	}
+	w.requests++
+	runaway := w.requests > c.Threshold && !w.alerted
+	if runaway {
+		w.alerted = true
+	}
+	requests := w.requests
+	c.mu.Unlock()
+	if runaway {
+		c.OnRunaway(usage.Tenant, usage.CostTag, requests)
+	}
+}
+
+// Counts returns the counts so far, ordered by tenant and product.
+func (c *CostCounter) Counts() []CostCount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sortedCounts()
+}
+
+// Reset clears the counts, e.g. at the start of a billing period, and returns them.
+func (c *CostCounter) Reset() []CostCount {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	counts := c.sortedCounts()
+	c.counts, c.windows = nil, nil
+	return counts
+}
+
+func (c *CostCounter) sortedCounts() []CostCount {
+	counts := make([]CostCount, 0, len(c.counts))
+	for _, count := range c.counts {
+		counts = append(counts, *count)
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Tenant != counts[j].Tenant {
+			return counts[i].Tenant < counts[j].Tenant
+		}
+		if counts[i].Product != counts[j].Product {
+			return counts[i].Product < counts[j].Product
+		}
+		return counts[i].Billing < counts[j].Billing
+	})
+	return counts
+}
@@ -0,0 +1,6 @@
+package plaid
+
+import "github.com/wearevest/plaidgo/plaid/transactions"
+
+// Counterparty is a party involved in a transaction, see transactions.Counterparty.
+type Counterparty = transactions.Counterparty
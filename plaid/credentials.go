@@ -0,0 +1,75 @@
+package plaid
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync/atomic"
+)
+
+// credentials are the API keys requests are authenticated with. A request loads them once
+// from Client.creds and reads all of its keys from that snapshot, so that it can't combine
+// the client id of one UpdateCredentials with the secret of another.
+type credentials struct {
+	clientID string
+	secret   string
+	// secondarySecret is tried when Plaid rejects secret, so that secrets can be rotated
+	// without failing requests.
+	secondarySecret string
+}
+
+// newCredentials returns an atomically replaceable holder of credentials.
+func newCredentials(creds credentials) *atomic.Pointer[credentials] {
+	holder := &atomic.Pointer[credentials]{}
+	holder.Store(&creds)
+	return holder
+}
+
+// WithSecondarySecret makes the client retry a request that Plaid rejected with
+// INVALID_API_KEYS once with secret. Pass the old secret while the new one is rolled out,
+// or the new one while the old one is being revoked, to rotate secrets without redeploying
+// or failing requests.
+func WithSecondarySecret(secret string) Option {
+	return func(c *Client) {
+		creds := *c.creds.Load()
+		creds.secondarySecret = secret
+		// Replace rather than update the holder, so that the client this option is applied
+		// to with With is not affected.
+		c.creds = newCredentials(creds)
+	}
+}
+
+// UpdateCredentials atomically replaces the client id and secrets of the client, and of the
+// clients derived from it with With, for all subsequent requests. secondarySecret may be
+// empty, see WithSecondarySecret. Requests in flight complete with the old credentials.
+func (c *Client) UpdateCredentials(clientID, secret, secondarySecret string) {
+	c.creds.Store(&credentials{clientID: clientID, secret: secret, secondarySecret: secondarySecret})
+}
+
+// rejectedKeys reports whether a response rejected the request's API keys.
+func rejectedKeys(statusCode int, raw []byte) bool {
+	if statusCode == 200 {
+		return false
+	}
+	var body struct {
+		ErrorCode string `json:"error_code"`
+	}
+	return json.Unmarshal(raw, &body) == nil && body.ErrorCode == "INVALID_API_KEYS"
+}
+
+// replaceSecret returns a JSON request body with its secret replaced, or false if the body
+// has no secret or already uses the given one.
+func replaceSecret(jsonText []byte, secret string) ([]byte, bool, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonText))
+	decoder.UseNumber()
+	var body map[string]interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return nil, false, err
+	}
+	current, ok := body["secret"].(string)
+	if !ok || current == secret {
+		return nil, false, nil
+	}
+	body["secret"] = secret
+	jsonText, err := json.Marshal(body)
+	return jsonText, err == nil, err
+}
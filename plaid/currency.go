@@ -0,0 +1,71 @@
+package plaid
+
+import "errors"
+
+// RateProvider supplies historical exchange rates, e.g. backed by a central bank's
+// reference rates, so that transactions in several currencies can be totaled in one.
+type RateProvider interface {
+	// Rate returns the number of units of currency to that one unit of currency from was
+	// worth on date, formatted YYYY-MM-DD. It returns ErrNoRate to leave amounts in from
+	// unconverted.
+	Rate(from, to, date string) (float64, error)
+}
+
+// ErrNoRate is returned by a RateProvider that has no rate for a currency pair, to leave
+// the amounts in that currency as they are rather than fail the conversion.
+var ErrNoRate = errors.New("no exchange rate")
+
+// RateProviderFunc adapts a function to a RateProvider.
+type RateProviderFunc func(from, to, date string) (float64, error)
+
+// Rate implements RateProvider.
+func (f RateProviderFunc) Rate(from, to, date string) (float64, error) {
+	return f(from, to, date)
+}
+
+// NoConversion is a RateProvider without rates: it leaves amounts and their currencies
+// unchanged, so rollups total the amounts of different currencies as they are.
+var NoConversion RateProvider = RateProviderFunc(func(from, to, date string) (float64, error) {
+	return 0, ErrNoRate
+})
+
+// ConvertTransactions returns copies of transactions with their amounts converted to base
+// at the rate of their date, and their currency codes set to base. Transactions without a
+// currency code are assumed to be in base already. Transactions rates has no rate for, see
+// ErrNoRate, keep their amount and currency. A nil rates returns unchanged copies.
+func ConvertTransactions(transactions []Transaction, base string, rates RateProvider) ([]Transaction, error) {
+	converted := make([]Transaction, len(transactions))
+	if rates == nil {
+		copy(converted, transactions)
+		return converted, nil
+	}
+	for i, t := range transactions {
+		amount, applied, err := convertAmount(t, base, rates)
+		if err != nil {
+			return nil, err
+		}
+		if applied {
+			t.Amount = float32(amount)
+			t.IsoCurrencyCode, t.UnofficialCurrencyCode = base, ""
+		}
+		converted[i] = t
+	}
+	return converted, nil
+}
+
+// convertAmount returns a transaction's amount in base, and whether a rate was applied to
+// it. The amount is returned unconverted if it is in base already or there is no rate.
+func convertAmount(t Transaction, base string, rates RateProvider) (float64, bool, error) {
+	from := t.CurrencyCode()
+	if base == "" || rates == nil || from == "" || from == base {
+		return t.Amount64(), false, nil
+	}
+	rate, err := rates.Rate(from, base, t.Date)
+	if errors.Is(err, ErrNoRate) {
+		return t.Amount64(), false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return t.Amount64() * rate, true, nil
+}
@@ -0,0 +1,133 @@
+package plaid
+
+import (
+	"time"
+)
+
+// DateWindow is an inclusive range of Plaid dates ("2006-01-02"), e.g. the start and end
+// date of a /transactions/get request.
+//
+// Plaid dates are calendar dates without a time zone. Deriving them from a time.Time with
+// Format uses the time's location, and stepping back with AddDate or by multiples of 24
+// hours from an instant near midnight lands on the wrong day across daylight saving
+// changes; the functions below do calendar arithmetic on the date in the given location
+// instead.
+type DateWindow struct {
+	StartDate string
+	EndDate   string
+}
+
+// DateIn returns the Plaid date of t in loc. A nil loc uses t's location.
+func DateIn(t time.Time, loc *time.Location) string {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return t.Format("2006-01-02")
+}
+
+// ParseDateIn returns the start of a Plaid date in loc, which is UTC if nil.
+func ParseDateIn(date string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.ParseInLocation("2006-01-02", date, loc)
+}
+
+// LastDays returns the window of the n days up to and including the day of now in loc,
+// e.g. the last 7 days. A nil loc uses now's location.
+func LastDays(now time.Time, loc *time.Location, n int) DateWindow {
+	today := civilDate(now, loc)
+	return DateWindow{
+		StartDate: today.AddDate(0, 0, 1-n).Format("2006-01-02"),
+		EndDate:   today.Format("2006-01-02"),
+	}
+}
+
+// MonthToDate returns the window from the first day of the month of now in loc up to and
+// including the day of now. A nil loc uses now's location.
+func MonthToDate(now time.Time, loc *time.Location) DateWindow {
+	today := civilDate(now, loc)
+	return DateWindow{
+		StartDate: today.AddDate(0, 0, 1-today.Day()).Format("2006-01-02"),
+		EndDate:   today.Format("2006-01-02"),
+	}
+}
+
+// PreviousMonth returns the window of the whole month before the month of now in loc. A nil
+// loc uses now's location.
+func PreviousMonth(now time.Time, loc *time.Location) DateWindow {
+	today := civilDate(now, loc)
+	first := today.AddDate(0, 0, 1-today.Day())
+	return DateWindow{
+		StartDate: first.AddDate(0, -1, 0).Format("2006-01-02"),
+		EndDate:   first.AddDate(0, 0, -1).Format("2006-01-02"),
+	}
+}
+
+// Days returns the number of days in the window, or 0 if it is malformed or empty.
+func (w DateWindow) Days() int {
+	start, errStart := time.Parse("2006-01-02", w.StartDate)
+	end, errEnd := time.Parse("2006-01-02", w.EndDate)
+	if errStart != nil || errEnd != nil || end.Before(start) {
+		return 0
+	}
+	// Both dates are UTC midnights, so the difference is a whole number of days.
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// Contains reports whether date is within the window.
+func (w DateWindow) Contains(date string) bool {
+	// Plaid dates order lexically.
+	return date >= w.StartDate && date <= w.EndDate
+}
+
+// Bounds returns the instants the window spans in loc: the start of its first day and the
+// start of the day after its last day, which is exclusive. Days across daylight saving
+// changes are 23 or 25 hours long. A nil loc is UTC.
+func (w DateWindow) Bounds(loc *time.Location) (start, end time.Time, err error) {
+	if start, err = ParseDateIn(w.StartDate, loc); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if end, err = ParseDateIn(w.EndDate, loc); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+// WithLocation makes the components of a client, such as the Refresher and the DualReader,
+// determine the current date in loc instead of the local time zone of the process, e.g.
+// the time zone of the users whose transactions they fetch.
+func WithLocation(loc *time.Location) Option {
+	return func(c *Client) {
+		c.location = loc
+	}
+}
+
+// daysBack returns the window from days days before the current date in the client's
+// location up to and including the current date.
+func (c *Client) daysBack(days int) DateWindow {
+	loc := c.location
+	if loc == nil {
+		loc = time.Local
+	}
+	return LastDays(c.clock.Now(), loc, days+1)
+}
+
+// dateLocation returns loc, or UTC if loc is nil, for the helpers without a client that
+// default to today.
+func dateLocation(loc *time.Location) *time.Location {
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// civilDate returns the date of t in loc as midnight UTC, so that calendar arithmetic on
+// it isn't affected by daylight saving changes.
+func civilDate(t time.Time, loc *time.Location) time.Time {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
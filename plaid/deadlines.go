@@ -0,0 +1,68 @@
+package plaid
+
+import (
+	"context"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/retry"
+)
+
+// Deadlines bounds the helpers of this package that make many requests, such as
+// TransactionsSyncAll, SyncToStore, TransactionsConcurrently, the Backfiller and the
+// InstitutionSyncer. Each call and the operation as a whole are bounded separately, so
+// that one slow page is retried instead of failing a long backfill, and a backfill stuck
+// on a slow institution can't run forever.
+//
+// Use Client.With to give a single subsystem its own deadlines.
+type Deadlines struct {
+	// PerCall bounds each request a helper makes. A request that exceeds it is retried
+	// up to Retries times while the operation has time left. Zero leaves requests bounded
+	// only by the http.Client's timeout.
+	PerCall time.Duration
+	// Retries is the number of times a request that exceeded PerCall is retried. Nil
+	// defaults to DefaultRetries; point it to 0 to disable retries.
+	Retries *int
+	// Operation bounds one call of a helper, e.g. the backfill of one item. Zero leaves it
+	// bounded only by the context passed to the helper.
+	Operation time.Duration
+}
+
+// DefaultRetries is the number of times a request that exceeded Deadlines.PerCall is
+// retried unless Deadlines.Retries says otherwise.
+const DefaultRetries = 2
+
+// PerCallTimeoutReason is the Reason of the EventRetry emitted when a request is retried
+// after exceeding Deadlines.PerCall.
+const PerCallTimeoutReason = "PER_CALL_TIMEOUT"
+
+// WithDeadlines sets the deadlines of the client's multi-request helpers.
+func WithDeadlines(deadlines Deadlines) Option {
+	if deadlines.Retries != nil {
+		// Don't let the caller change the retries through the pointer later on.
+		retries := *deadlines.Retries
+		deadlines.Retries = &retries
+	}
+	return func(c *Client) {
+		c.deadlines = deadlines
+	}
+}
+
+// operation returns ctx bounded by the Operation deadline.
+func (c *Client) operation(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.deadlines.Operation <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.deadlines.Operation)
+}
+
+// call runs a request of a helper with the PerCall timeout, retrying it if it exceeded the
+// timeout while ctx is still alive.
+func (c *Client) call(ctx context.Context, endpoint string, request func(ctx context.Context) error) error {
+	policy := retry.Policy{PerCall: c.deadlines.PerCall, Retries: DefaultRetries}
+	if c.deadlines.Retries != nil {
+		policy.Retries = *c.deadlines.Retries
+	}
+	return policy.Do(ctx, endpoint, request, func() {
+		c.emit(Event{Type: EventRetry, Endpoint: endpoint, Reason: PerCallTimeoutReason})
+	})
+}
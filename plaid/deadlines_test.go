@@ -0,0 +1,34 @@
+package plaid
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDeadlinesRetries(t *testing.T) {
+	none := 0
+	tests := []struct {
+		name    string
+		retries *int
+		want    int
+	}{
+		{"default", nil, DefaultRetries + 1},
+		{"disabled", &none, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient("id", "secret", Sandbox,
+				WithDeadlines(Deadlines{PerCall: time.Millisecond, Retries: tt.retries}))
+			attempts := 0
+			err := c.call(context.Background(), "/accounts/get", func(ctx context.Context) error {
+				attempts++
+				<-ctx.Done()
+				return ctx.Err()
+			})
+			if err == nil || attempts != tt.want {
+				t.Fatalf("call = %v after %d attempts, want a timeout after %d", err, attempts, tt.want)
+			}
+		})
+	}
+}
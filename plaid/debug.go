@@ -0,0 +1,113 @@
+package plaid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// RequestRecord is a sanitized record of a request kept by the debug buffer. It holds no
+// request or response bodies, tokens or secrets.
+type RequestRecord struct {
+	Time       time.Time     `json:"time"`
+	Method     string        `json:"method"`
+	Endpoint   string        `json:"endpoint"`
+	Duration   time.Duration `json:"duration"`
+	StatusCode int           `json:"status_code,omitempty"`
+	RequestID  string        `json:"request_id,omitempty"`
+	ErrorType  string        `json:"error_type,omitempty"`
+	ErrorCode  string        `json:"error_code,omitempty"`
+	// Err is the transport error, if the request failed before a response was received.
+	Err string `json:"err,omitempty"`
+
+	token string // hash of the request's access token, see tokenHash
+}
+
+// WithDebugBuffer makes the client keep a record of its last size requests, available from
+// RecentRequests and included in support bundles.
+func WithDebugBuffer(size int) Option {
+	return func(c *Client) {
+		c.debug = &debugBuffer{records: make([]RequestRecord, 0, size), size: size}
+	}
+}
+
+// RecentRequests returns the requests kept by the debug buffer, oldest first. It returns
+// nil if the client has no debug buffer.
+func (c *Client) RecentRequests() []RequestRecord {
+	return c.debug.snapshot("")
+}
+
+// debugBuffer is a ring buffer of request records.
+type debugBuffer struct {
+	mu      sync.Mutex
+	records []RequestRecord
+	size    int
+	next    int // index of the oldest record once the buffer is full
+}
+
+func (b *debugBuffer) record(request SlowRequest, summary responseSummary, token string) {
+	if b == nil || b.size < 1 {
+		return
+	}
+	record := RequestRecord{
+		Time:       request.Start,
+		Method:     request.Method,
+		Endpoint:   request.Endpoint,
+		Duration:   request.Duration,
+		StatusCode: request.StatusCode,
+		RequestID:  request.RequestID,
+		ErrorType:  summary.ErrorType,
+		ErrorCode:  summary.ErrorCode,
+		token:      token,
+	}
+	if request.Err != nil {
+		record.Err = request.Err.Error()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.records) < b.size {
+		b.records = append(b.records, record)
+		return
+	}
+	b.records[b.next] = record
+	b.next = (b.next + 1) % b.size
+}
+
+// snapshot returns the records oldest first, limited to the requests made with the access
+// token of the given hash unless it is empty.
+func (b *debugBuffer) snapshot(token string) []RequestRecord {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	records := []RequestRecord{}
+	for i := range b.records {
+		record := b.records[(b.next+i)%len(b.records)]
+		if token == "" || record.token == token {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// tokenHash identifies an access token in the debug buffer without keeping the token.
+func tokenHash(accessToken string) string {
+	if accessToken == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:8])
+}
+
+// requestTokenHash returns the tokenHash of the access token of a JSON request body.
+func requestTokenHash(body []byte) string {
+	var request struct {
+		AccessToken string `json:"access_token"`
+	}
+	json.Unmarshal(body, &request)
+	return tokenHash(request.AccessToken)
+}
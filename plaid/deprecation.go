@@ -0,0 +1,42 @@
+package plaid
+
+import (
+	"sync"
+)
+
+// Logger receives the warnings of a client, such as the first call of a deprecated method.
+// *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger sends the client's warnings to logger instead of the standard logger of package
+// log. A nil logger discards them.
+func WithLogger(logger Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// deprecationWarnings holds the names of the deprecated methods that were warned about, so
+// that each is only logged once per process however many clients call it.
+var deprecationWarnings sync.Map
+
+// deprecated records a call of a deprecated method: it emits EventDeprecatedCall and logs a
+// warning naming the replacement on the method's first call.
+//
+// Methods are deprecated once a replacement exists, and then keep working as thin wrappers
+// for at least two minor releases, so that services can upgrade one call site at a time.
+func (c *Client) deprecated(method, replacement string) {
+	c.emit(Event{Type: EventDeprecatedCall, Reason: method})
+	// Clients that discard warnings don't count, so that a client with a logger still
+	// warns about methods only called by such clients before.
+	if c.logger == nil {
+		return
+	}
+	if _, warned := deprecationWarnings.LoadOrStore(method, struct{}{}); warned {
+		return
+	}
+	c.logger.Printf("plaid: %s is deprecated and will be removed in a future release; use %s instead",
+		method, replacement)
+}
@@ -0,0 +1,171 @@
+package plaid
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DriftRecorder records fields of Plaid responses that the package's structs don't
+// capture. Running a recorder during sandbox contract tests gives early warning of fields
+// Plaid added to its responses.
+//
+// Recording decodes every successful response a second time, so it is meant for tests
+// rather than production traffic.
+type DriftRecorder struct {
+	mu     sync.Mutex
+	fields map[string]map[string]int // endpoint -> field path -> occurrences
+}
+
+// UnknownField is a response field that was not captured by the package's structs.
+type UnknownField struct {
+	Endpoint string
+	Path     string // e.g. "accounts[].balances.last_updated_datetime"
+	Count    int
+}
+
+// NewDriftRecorder instantiates an empty DriftRecorder.
+func NewDriftRecorder() *DriftRecorder {
+	return &DriftRecorder{fields: map[string]map[string]int{}}
+}
+
+// WithDriftRecorder makes a Client report unknown response fields to r.
+func WithDriftRecorder(r *DriftRecorder) Option {
+	return func(c *Client) {
+		c.drift = r
+	}
+}
+
+// Report returns the unknown fields recorded so far, ordered by endpoint and path.
+func (r *DriftRecorder) Report() []UnknownField {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var report []UnknownField
+	for endpoint, paths := range r.fields {
+		for path, count := range paths {
+			report = append(report, UnknownField{Endpoint: endpoint, Path: path, Count: count})
+		}
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Endpoint != report[j].Endpoint {
+			return report[i].Endpoint < report[j].Endpoint
+		}
+		return report[i].Path < report[j].Path
+	})
+	return report
+}
+
+// record compares a raw response body to the struct it was decoded into.
+func (r *DriftRecorder) record(endpoint string, raw []byte, decoded interface{}) {
+	if r == nil {
+		return
+	}
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return
+	}
+	var unknown []string
+	collectUnknown(value, reflect.TypeOf(decoded), "", &unknown)
+	if len(unknown) == 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	paths, ok := r.fields[endpoint]
+	if !ok {
+		paths = map[string]int{}
+		r.fields[endpoint] = paths
+	}
+	for _, path := range unknown {
+		paths[path]++
+	}
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// fieldUnmarshalers are the types whose UnmarshalJSON decodes an object into their own
+// fields, through a type without their methods, so that their unknown keys are collected
+// like those of types without an UnmarshalJSON.
+var fieldUnmarshalers = map[reflect.Type]bool{
+	reflect.TypeOf(Balances{}): true,
+}
+
+// collectUnknown appends the paths of all object keys in value that have no matching
+// field in t.
+func collectUnknown(value interface{}, t reflect.Type, path string, unknown *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) && !fieldUnmarshalers[t] {
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		fields := jsonFields(t)
+		for key, child := range v {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				*unknown = append(*unknown, joinPath(path, key))
+				continue
+			}
+			collectUnknown(child, field, joinPath(path, key), unknown)
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for _, child := range v {
+			collectUnknown(child, t.Elem(), path+"[]", unknown)
+		}
+	}
+}
+
+// jsonFields returns the types of the fields encoding/json decodes into for a struct
+// type, keyed by lower-cased JSON name. Fields of embedded structs are promoted.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for key, fieldType := range jsonFields(embedded) {
+					if _, ok := fields[key]; !ok {
+						fields[key] = fieldType
+					}
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,23 @@
+package plaid
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDriftRecorderBalances(t *testing.T) {
+	r := NewDriftRecorder()
+	raw := []byte(`{
+		"accounts": [{
+			"account_id": "a1",
+			"balances": {"available": null, "current": 10, "last_updated_datetime": "2024-03-01T00:00:00Z"}
+		}]
+	}`)
+	r.record("/accounts/get", raw, &struct {
+		Accounts []Account `json:"accounts"`
+	}{})
+	want := []UnknownField{{Endpoint: "/accounts/get", Path: "accounts[].balances.last_updated_datetime", Count: 1}}
+	if got := r.Report(); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Report = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,223 @@
+package plaid
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sort"
+	"time"
+)
+
+// DualReadConfig configures a DualReader.
+type DualReadConfig struct {
+	// Tokens supplies the access tokens to compare.
+	Tokens TokenStore
+	// SampleRate is the fraction of items compared, between 0 and 1. The sample is
+	// stable: an item is either always or never compared. Zero compares every item.
+	SampleRate float64
+	// Interval is the time between comparison cycles. Defaults to 24 hours.
+	Interval time.Duration
+	// Days is how many days of transactions are compared. Defaults to 30.
+	Days int
+
+	OnReport func(report DualReadReport)
+	OnError  func(accessToken string, err error)
+}
+
+// DualReadReport is the comparison of an item's transactions as returned by
+// /transactions/get and /transactions/sync.
+type DualReadReport struct {
+	ItemID    ItemID
+	StartDate string
+	EndDate   string
+	GetCount  int
+	SyncCount int
+
+	// MissingFromSync are returned by /transactions/get only, MissingFromGet by
+	// /transactions/sync only.
+	MissingFromSync []Transaction
+	MissingFromGet  []Transaction
+	Mismatches      []TransactionMismatch
+}
+
+// TransactionMismatch is a transaction both endpoints returned with different fields.
+type TransactionMismatch struct {
+	TransactionID TransactionID
+	Fields        []string // e.g. "amount", "pending"
+	Get           Transaction
+	Sync          Transaction
+}
+
+// Consistent reports whether both endpoints returned the same transactions.
+func (r *DualReadReport) Consistent() bool {
+	return len(r.MissingFromSync) == 0 && len(r.MissingFromGet) == 0 && len(r.Mismatches) == 0
+}
+
+// DualReader compares /transactions/get with /transactions/sync for a sample of items, to
+// establish data parity before migrating to sync. Reports are delivered through the
+// callbacks of its DualReadConfig.
+//
+// A DualReader is either driven by Run or, as a Component, by Start and Close.
+type DualReader struct {
+	client    *Client
+	config    DualReadConfig
+	lifecycle lifecycle
+}
+
+// NewDualReader instantiates a DualReader that makes its requests through c.
+func NewDualReader(c *Client, config DualReadConfig) *DualReader {
+	if config.Interval == 0 {
+		config.Interval = 24 * time.Hour
+	}
+	if config.Days == 0 {
+		config.Days = 30
+	}
+	return &DualReader{client: c, config: config}
+}
+
+// Run compares until ctx is done and then returns ctx.Err().
+func (d *DualReader) Run(ctx context.Context) error {
+	d.run(ctx, nil)
+	return ctx.Err()
+}
+
+// Start compares in the background until ctx is done or Close is called.
+func (d *DualReader) Start(ctx context.Context) error {
+	return d.lifecycle.start(func(stop <-chan struct{}) {
+		d.run(ctx, stop)
+	})
+}
+
+// Close stops a DualReader started with Start and waits for in-flight comparisons to finish.
+func (d *DualReader) Close() error {
+	return d.lifecycle.close()
+}
+
+func (d *DualReader) run(ctx context.Context, stop <-chan struct{}) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-d.client.clock.After(d.config.Interval):
+		}
+		tokens, err := d.config.Tokens.AccessTokens(ctx)
+		if err != nil {
+			d.reportError("", err)
+			continue
+		}
+		for _, token := range tokens {
+			if ctx.Err() != nil || stopped(stop) {
+				return
+			}
+			if !d.sampled(token) {
+				continue
+			}
+			report, err := d.Compare(ctx, token)
+			if err != nil {
+				d.reportError(token, err)
+				continue
+			}
+			if d.config.OnReport != nil {
+				d.config.OnReport(*report)
+			}
+		}
+	}
+}
+
+// sampled reports whether an item belongs to the sample.
+func (d *DualReader) sampled(accessToken string) bool {
+	if d.config.SampleRate <= 0 || d.config.SampleRate >= 1 {
+		return true
+	}
+	h := fnv.New64a()
+	h.Write([]byte(accessToken))
+	return float64(h.Sum64()) < d.config.SampleRate*math.MaxUint64
+}
+
+// Compare fetches an item's transactions of the last Days days from both endpoints and
+// compares them by transaction id.
+func (d *DualReader) Compare(ctx context.Context, accessToken string) (*DualReadReport, error) {
+	window := d.client.daysBack(d.config.Days)
+	report := &DualReadReport{StartDate: window.StartDate, EndDate: window.EndDate}
+	fromGet, item, err := d.client.allTransactions(ctx, accessToken, report.StartDate, report.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	report.ItemID = item.ItemId
+	synced, err := d.client.TransactionsSyncAllContext(ctx, accessToken, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// A sync from an empty cursor only adds transactions, but apply modifications and
+	// removals in case Plaid reports some.
+	fromSync := map[TransactionID]Transaction{}
+	for _, t := range append(synced.Added, synced.Modified...) {
+		if t.Date >= report.StartDate && t.Date <= report.EndDate {
+			fromSync[t.TransactionID] = t
+		}
+	}
+	for _, removed := range synced.Removed {
+		delete(fromSync, removed.TransactionID)
+	}
+	report.GetCount, report.SyncCount = len(fromGet), len(fromSync)
+
+	for _, t := range fromGet {
+		s, ok := fromSync[t.TransactionID]
+		if !ok {
+			report.MissingFromSync = append(report.MissingFromSync, t)
+			continue
+		}
+		delete(fromSync, t.TransactionID)
+		if fields := transactionDiff(t, s); len(fields) > 0 {
+			report.Mismatches = append(report.Mismatches,
+				TransactionMismatch{TransactionID: t.TransactionID, Fields: fields, Get: t, Sync: s})
+		}
+	}
+	for _, s := range fromSync {
+		report.MissingFromGet = append(report.MissingFromGet, s)
+	}
+	sort.Slice(report.MissingFromGet, func(i, j int) bool {
+		return report.MissingFromGet[i].TransactionID < report.MissingFromGet[j].TransactionID
+	})
+	return report, nil
+}
+
+// transactionDiff returns the names of the fields that differ between two versions of a
+// transaction.
+func transactionDiff(a, b Transaction) []string {
+	var fields []string
+	if a.AccountID != b.AccountID {
+		fields = append(fields, "account_id")
+	}
+	if a.Amount != b.Amount {
+		fields = append(fields, "amount")
+	}
+	if a.Date != b.Date {
+		fields = append(fields, "date")
+	}
+	if a.Name != b.Name {
+		fields = append(fields, "name")
+	}
+	if a.MerchantName != b.MerchantName {
+		fields = append(fields, "merchant_name")
+	}
+	if a.Pending != b.Pending {
+		fields = append(fields, "pending")
+	}
+	if a.PendingTransactionID != b.PendingTransactionID {
+		fields = append(fields, "pending_transaction_id")
+	}
+	if a.CurrencyCode() != b.CurrencyCode() {
+		fields = append(fields, "iso_currency_code")
+	}
+	return fields
+}
+
+func (d *DualReader) reportError(accessToken string, err error) {
+	if d.config.OnError != nil {
+		d.config.OnError(accessToken, err)
+	}
+}
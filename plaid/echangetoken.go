@@ -2,38 +2,88 @@ package plaid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 )
 
-// ExchangeToken (POST /exchange_token) exchanges a public token for an access token.
+// ItemPublicTokenExchange (POST /item/public_token/exchange) exchanges the public token Link
+// returned for an access token and the item's id.
 //
-// See https://github.com/plaid/link
-func (c *Client) ExchangeToken(publicToken string) (postRes *postResponse, err error) {
-	jsonText, err := json.Marshal(exchangeJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+// See https://plaid.com/docs/api/tokens/#itempublic_tokenexchange.
+func (c *Client) ItemPublicTokenExchange(publicToken string) (*ItemPublicTokenExchangeResponse, error) {
+	return c.ItemPublicTokenExchangeContext(context.Background(), publicToken)
+}
+
+// ItemPublicTokenExchangeContext is like ItemPublicTokenExchange but carries a context.
+func (c *Client) ItemPublicTokenExchangeContext(ctx context.Context,
+	publicToken string) (*ItemPublicTokenExchangeResponse, error) {
+
+	var res ItemPublicTokenExchangeResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/item/public_token/exchange", exchangeJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		PublicToken: publicToken,
-	})
+	}, &res)
 	if err != nil {
 		return nil, err
 	}
-	postRes, _, err = c.postAndUnmarshal("/item/public_token/exchange", bytes.NewReader(jsonText))
-	return postRes, err
+	return &res, nil
+}
+
+// ItemPublicTokenExchangeResponse is the response of ItemPublicTokenExchange.
+type ItemPublicTokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	ItemID      ItemID `json:"item_id"`
+	RequestID   string `json:"request_id"`
+}
+
+// ExchangeToken (POST /item/public_token/exchange) exchanges a public token for an access
+// token.
+//
+// Deprecated: use ItemPublicTokenExchange instead.
+func (c *Client) ExchangeToken(publicToken string) (postRes *postResponse, err error) {
+	return c.ExchangeTokenContext(context.Background(), publicToken)
+}
+
+// ExchangeTokenContext is like ExchangeToken but carries a context.
+//
+// Deprecated: use ItemPublicTokenExchange instead.
+func (c *Client) ExchangeTokenContext(ctx context.Context, publicToken string) (postRes *postResponse, err error) {
+	c.deprecated("ExchangeToken", "ItemPublicTokenExchange")
+	res, err := c.ItemPublicTokenExchangeContext(ctx, publicToken)
+	if err != nil {
+		return nil, err
+	}
+	return &postResponse{AccessToken: res.AccessToken, ItemID: res.ItemID, RequestID: res.RequestID}, nil
 }
 
 // ExchangeTokenAccount (POST /exchange_token) exchanges a public token and account id to receive a
 // bank account token.
-func (c *Client) ExchangeTokenAccount(publicToken string, accountId string) (postRes *postResponse, err error) {
+//
+// Deprecated: use ItemPublicTokenExchange and StripeBankAccountTokenCreate instead.
+func (c *Client) ExchangeTokenAccount(publicToken string, accountId AccountID) (postRes *postResponse, err error) {
+	return c.ExchangeTokenAccountContext(context.Background(), publicToken, accountId)
+}
+
+// ExchangeTokenAccountContext is like ExchangeTokenAccount but carries a context.
+//
+// Deprecated: use ItemPublicTokenExchange and StripeBankAccountTokenCreate instead.
+func (c *Client) ExchangeTokenAccountContext(ctx context.Context, publicToken string, accountId AccountID) (postRes *postResponse,
+	err error) {
+
+	c.deprecated("ExchangeTokenAccount", "ItemPublicTokenExchange and StripeBankAccountTokenCreate")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(exchangeAccountJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		PublicToken: publicToken,
 		AccountId:   accountId,
 	})
 	if err != nil {
 		return nil, err
 	}
-	postRes, _, err = c.postAndUnmarshal("/exchange_token", bytes.NewReader(jsonText))
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/exchange_token", bytes.NewReader(jsonText))
 	return postRes, err
 }
 
@@ -44,8 +94,8 @@ type exchangeJson struct {
 }
 
 type exchangeAccountJson struct {
-	ClientID    string `json:"client_id"`
-	Secret      string `json:"secret"`
-	PublicToken string `json:"public_token"`
-	AccountId   string `json:"account_id"`
+	ClientID    string    `json:"client_id"`
+	Secret      string    `json:"secret"`
+	PublicToken string    `json:"public_token"`
+	AccountId   AccountID `json:"account_id"`
 }
@@ -0,0 +1,48 @@
+package plaid
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// NewClientFromEnv instantiates a Client from the PLAID_CLIENT_ID, PLAID_SECRET and
+// PLAID_ENV environment variables. PLAID_ENV must be one of "sandbox", "development"
+// or "production". If PLAID_BASE_URL is set it overrides the URL implied by PLAID_ENV,
+// e.g. to point the client at a proxy or a fake server.
+func NewClientFromEnv() (*Client, error) {
+	clientID := os.Getenv("PLAID_CLIENT_ID")
+	if clientID == "" {
+		return nil, errors.New("PLAID_CLIENT_ID must be set")
+	}
+	secret := os.Getenv("PLAID_SECRET")
+	if secret == "" {
+		return nil, errors.New("PLAID_SECRET must be set")
+	}
+	env := os.Getenv("PLAID_ENV")
+	if env == "" {
+		return nil, errors.New("PLAID_ENV must be set")
+	}
+	environment, err := parseEnvironment(env)
+	if err != nil {
+		return nil, err
+	}
+	var options []Option
+	if baseURL := os.Getenv("PLAID_BASE_URL"); baseURL != "" {
+		options = append(options, WithBaseURL(baseURL))
+	}
+	return NewClient(clientID, secret, environment, options...), nil
+}
+
+// parseEnvironment maps an environment name to its URL.
+func parseEnvironment(name string) (environmentURL, error) {
+	switch strings.ToLower(name) {
+	case "sandbox":
+		return Sandbox, nil
+	case "development":
+		return Development, nil
+	case "production":
+		return Production, nil
+	}
+	return "", errors.New("PLAID_ENV must be one of sandbox, development or production, got " + name)
+}
@@ -1,21 +1,38 @@
 package plaid
 
 import (
-	"fmt"
+	"errors"
+
+	"github.com/wearevest/plaidgo/plaid/core"
 )
 
-type plaidError struct {
-	// List of all errors: https://github.com/plaid/support/blob/master/errors.md
-	ErrorCode      string `json:"error_code"`
-	ErrorType      string `json:"error_type"`
-	ErrorMessage   string `json:"error_message"`
-	DisplayMessage string `json:"display_message"`
+// plaidError is the error returned for requests Plaid rejected.
+type plaidError = core.Error
+
+// DecodeError is returned when a response body can't be decoded, with the JSON path of the
+// offending value.
+type DecodeError = core.DecodeError
 
-	// StatusCode needs to manually set from the http response
-	StatusCode int
+// UserMessage returns a message about err that is safe to show to end users. For errors
+// Plaid returned it is the error's UserMessage; for any other error, such as a network
+// failure or a timeout, it is a generic message, so that messages meant for developers
+// never reach users.
+func UserMessage(err error) string {
+	return LocalizedUserMessage(err, nil)
 }
 
-func (e plaidError) Error() string {
-	return fmt.Sprintf("Plaid Error - http status: %s, code: %s, message: %s, display: %s",
-		e.ErrorCode, e.ErrorType, e.ErrorMessage, e.DisplayMessage)
+// LocalizedUserMessage is like UserMessage but translates the message with l. Plaid's
+// display messages are returned untranslated.
+func LocalizedUserMessage(err error, l Localizer) string {
+	var plaidErr plaidError
+	if errors.As(err, &plaidErr) {
+		return plaidErr.LocalizedUserMessage(l)
+	}
+	return core.Localize(l, core.DefaultUserMessageKey, core.DefaultUserMessage)
 }
+
+// Localizer translates the user-facing strings of the client, see core.Localizer.
+type Localizer = core.Localizer
+
+// LocalizerFunc adapts a function to a Localizer.
+type LocalizerFunc = core.LocalizerFunc
@@ -0,0 +1,197 @@
+package plaid
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType identifies the kind of an Event.
+type EventType string
+
+const (
+	// EventRequestCompleted is emitted for every request, successful or not.
+	EventRequestCompleted EventType = "request_completed"
+	// EventRetry is emitted when the client repeats a request or a sequence of requests.
+	EventRetry EventType = "retry"
+	// EventRateLimited is emitted when Plaid answers a request with a 429.
+	EventRateLimited EventType = "rate_limited"
+	// EventThrottleChanged is emitted when adaptive throttling changes an endpoint group's
+	// rate, see WithThrottle.
+	EventThrottleChanged EventType = "throttle_changed"
+	// EventCooldownStarted and EventCooldownEnded are emitted when requests for an
+	// institution's items start and stop being short-circuited, see
+	// WithInstitutionCooldown.
+	EventCooldownStarted EventType = "cooldown_started"
+	EventCooldownEnded   EventType = "cooldown_ended"
+	// EventCacheHit and EventCacheMiss are emitted for lookups in the client's caches.
+	EventCacheHit  EventType = "cache_hit"
+	EventCacheMiss EventType = "cache_miss"
+	// EventDeprecatedCall is emitted for every call of a deprecated method, with the name of
+	// the method as Reason.
+	EventDeprecatedCall EventType = "deprecated_call"
+	// EventDeduplicated is emitted when a request joins an identical one in flight instead
+	// of being sent, see WithSingleflight.
+	EventDeduplicated EventType = "deduplicated"
+)
+
+// Event describes something a client did. Which fields are set depends on Type.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Endpoint is set for request, retry, rate limit, throttle and deduplication events.
+	Endpoint string
+	// Request describes the request of EventRequestCompleted.
+	Request *RequestEvent
+	// Reason is why a request was retried, e.g. the error code that caused it, or the
+	// deprecated method of EventDeprecatedCall.
+	Reason string
+	// InstitutionID and Until describe a cooldown. InstitutionID is empty if only a single
+	// item cools down because its institution is not known.
+	InstitutionID string
+	Until         time.Time
+	// Rate is the new number of requests per second of EventThrottleChanged.
+	Rate float64
+	// Cache is the name of the cache of a cache event, see CacheStats.
+	Cache string
+}
+
+// EventStream delivers the events of the clients it is attached to with WithEventStream
+// to its subscribers, as a single integration point for dashboards over the clients'
+// behavior.
+//
+// Events of Subscribe are delivered without blocking the client: if a subscriber's buffer
+// is full the event is dropped for that subscriber and counted by Dropped. SubscribeWith
+// chooses what happens instead.
+type EventStream struct {
+	mu            sync.Mutex
+	subscribers   map[chan Event]struct{}
+	subscriptions map[*Subscription]struct{}
+	dropped       uint64
+}
+
+// NewEventStream instantiates an EventStream without subscribers.
+func NewEventStream() *EventStream {
+	return &EventStream{subscribers: map[chan Event]struct{}{}, subscriptions: map[*Subscription]struct{}{}}
+}
+
+// WithEventStream makes the client emit its events to stream. Several clients may share a
+// stream.
+func WithEventStream(stream *EventStream) Option {
+	return func(c *Client) {
+		c.events = stream
+	}
+}
+
+// Subscribe returns a channel receiving the stream's events, buffering up to buffer of
+// them, and a function that ends the subscription and closes the channel.
+func (s *EventStream) Subscribe(buffer int) (<-chan Event, func()) {
+	events := make(chan Event, buffer)
+	s.mu.Lock()
+	s.subscribers[events] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return events, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers, events)
+			s.mu.Unlock()
+			close(events)
+		})
+	}
+}
+
+// Subscription receives the events of an EventStream on C, through a buffer bounded by the
+// Backpressure it was subscribed with.
+type Subscription struct {
+	C <-chan Event
+
+	stream *EventStream
+	events *bounded[Event]
+	mu     sync.Mutex
+	err    error
+}
+
+// SubscribeWith is like Subscribe, but what happens when the subscription's buffer is full
+// is chosen by backpressure.Overflow:
+//
+//   - OverflowBlock makes the clients wait until the subscriber receives the event, so a
+//     stalled subscriber stalls the clients' requests. Use it where no event may be lost.
+//   - OverflowDropOldest discards the oldest buffered event, counted by Dropped.
+//   - OverflowError ends the subscription: C is closed and Err returns ErrBufferFull.
+func (s *EventStream) SubscribeWith(backpressure Backpressure) *Subscription {
+	events := newBounded[Event](backpressure, func() { atomic.AddUint64(&s.dropped, 1) })
+	sub := &Subscription{C: events.items, stream: s, events: events}
+	s.mu.Lock()
+	s.subscriptions[sub] = struct{}{}
+	s.mu.Unlock()
+	return sub
+}
+
+// Close ends the subscription and closes C. It may be called more than once.
+func (sub *Subscription) Close() {
+	// Closing first wakes up a client blocked on a full buffer.
+	sub.events.close()
+	sub.stream.mu.Lock()
+	delete(sub.stream.subscriptions, sub)
+	sub.stream.mu.Unlock()
+}
+
+// Err returns ErrBufferFull if the subscription ended because its buffer was full, and nil
+// otherwise.
+func (sub *Subscription) Err() error {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.err
+}
+
+// Dropped returns the number of events discarded by OverflowDropOldest.
+func (sub *Subscription) Dropped() uint64 {
+	return sub.events.Dropped()
+}
+
+// Dropped returns the number of events dropped because a subscriber's buffer was full,
+// over all subscribers including those of SubscribeWith.
+func (s *EventStream) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+func (s *EventStream) publish(event Event) {
+	s.mu.Lock()
+	for subscriber := range s.subscribers {
+		select {
+		case subscriber <- event:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	}
+	subscriptions := make([]*Subscription, 0, len(s.subscriptions))
+	for sub := range s.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	s.mu.Unlock()
+
+	// Blocking subscriptions are sent to without holding the lock, so that they can be
+	// closed while a client waits for them.
+	for _, sub := range subscriptions {
+		if err := sub.events.send(event, nil); err == ErrBufferFull {
+			atomic.AddUint64(&s.dropped, 1)
+			sub.mu.Lock()
+			sub.err = err
+			sub.mu.Unlock()
+			sub.Close()
+		}
+	}
+}
+
+// emit publishes an event to the client's event stream, if it has one.
+func (c *Client) emit(event Event) {
+	if c.events == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = c.clock.Now()
+	}
+	c.events.publish(event)
+}
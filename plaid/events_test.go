@@ -0,0 +1,88 @@
+package plaid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubscribeWith(t *testing.T) {
+	t.Run("drop oldest", func(t *testing.T) {
+		stream := NewEventStream()
+		sub := stream.SubscribeWith(Backpressure{Buffer: 1, Overflow: OverflowDropOldest})
+		defer sub.Close()
+		stream.publish(Event{Endpoint: "/accounts/get"})
+		stream.publish(Event{Endpoint: "/item/get"})
+		if event := <-sub.C; event.Endpoint != "/item/get" {
+			t.Errorf("received the event of %s, want the newest one", event.Endpoint)
+		}
+		if sub.Dropped() != 1 || stream.Dropped() != 1 {
+			t.Errorf("Dropped = %d, stream Dropped = %d, want 1", sub.Dropped(), stream.Dropped())
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		stream := NewEventStream()
+		sub := stream.SubscribeWith(Backpressure{Buffer: 1, Overflow: OverflowError})
+		stream.publish(Event{Endpoint: "/accounts/get"})
+		stream.publish(Event{Endpoint: "/item/get"})
+		if sub.Err() != ErrBufferFull {
+			t.Fatalf("Err = %v, want ErrBufferFull", sub.Err())
+		}
+		if event := <-sub.C; event.Endpoint != "/accounts/get" {
+			t.Errorf("received the event of %s, want the buffered one", event.Endpoint)
+		}
+		if _, ok := <-sub.C; ok {
+			t.Error("C isn't closed")
+		}
+		// The ended subscription isn't published to anymore.
+		stream.publish(Event{Endpoint: "/auth/get"})
+		if stream.Dropped() != 1 {
+			t.Errorf("stream Dropped = %d, want 1", stream.Dropped())
+		}
+	})
+	t.Run("block", func(t *testing.T) {
+		stream := NewEventStream()
+		sub := stream.SubscribeWith(Backpressure{Buffer: 1})
+		defer sub.Close()
+		stream.publish(Event{Endpoint: "/accounts/get"})
+		published := make(chan struct{})
+		go func() {
+			stream.publish(Event{Endpoint: "/item/get"})
+			close(published)
+		}()
+		select {
+		case <-published:
+			t.Fatal("publishing to a full subscription didn't block")
+		case <-time.After(10 * time.Millisecond):
+		}
+		for _, want := range []string{"/accounts/get", "/item/get"} {
+			if event := <-sub.C; event.Endpoint != want {
+				t.Errorf("received the event of %s, want %s", event.Endpoint, want)
+			}
+		}
+		<-published
+		if stream.Dropped() != 0 {
+			t.Errorf("stream Dropped = %d, want 0", stream.Dropped())
+		}
+	})
+}
+
+func TestSubscriptionCloseUnblocksPublish(t *testing.T) {
+	stream := NewEventStream()
+	sub := stream.SubscribeWith(Backpressure{Buffer: 1})
+	stream.publish(Event{Endpoint: "/accounts/get"})
+	published := make(chan struct{})
+	go func() {
+		stream.publish(Event{Endpoint: "/item/get"})
+		close(published)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sub.Close()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Close didn't unblock the client publishing to the subscription")
+	}
+	if sub.Err() != nil {
+		t.Errorf("Err = %v after Close, want nil", sub.Err())
+	}
+}
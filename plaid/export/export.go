@@ -0,0 +1,192 @@
+// Package export writes Plaid transactions in the formats of personal finance tools: QIF
+// for import into desktop finance applications, and the plain-text accounting formats of
+// Beancount and ledger-cli.
+//
+// The ledger formats are built on JournalEntries, which converts transactions into balanced
+// double-entry postings for use by bookkeeping code directly. Which ledger account a Plaid
+// account or a transaction's category is booked to is decided by the mapping functions of
+// Options, which default to DefaultAccount and DefaultCategory; ChartOfAccounts provides
+// table driven mappings.
+package export
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+// Options configure an export. The zero value is usable.
+type Options struct {
+	// Account maps a Plaid account to a ledger account name such as
+	// "Assets:Checking". Defaults to DefaultAccount.
+	Account func(account plaid.Account) string
+	// Category maps a transaction to the account or category it is booked against, such
+	// as "Expenses:FoodAndDrink:Coffee". Defaults to DefaultCategory, or QIFCategory for
+	// QIF exports.
+	Category func(transaction plaid.Transaction) string
+	// Currency is used for transactions without a currency code. Defaults to "USD".
+	Currency string
+	// Decimals returns the number of decimals amounts in a currency are rounded to and
+	// written with. Defaults to plaid.CurrencyDecimals, e.g. 2 for "USD" and 0 for "JPY".
+	Decimals func(currency string) int
+	// SkipPending leaves out pending transactions.
+	SkipPending bool
+}
+
+func (o *Options) account(account plaid.Account) string {
+	if o.Account != nil {
+		return o.Account(account)
+	}
+	return DefaultAccount(account)
+}
+
+func (o *Options) category(transaction plaid.Transaction, fallback func(plaid.Transaction) string) string {
+	if o.Category != nil {
+		return o.Category(transaction)
+	}
+	return fallback(transaction)
+}
+
+func (o *Options) currency(transaction plaid.Transaction) string {
+	switch {
+	case transaction.IsoCurrencyCode != "":
+		return transaction.IsoCurrencyCode
+	case transaction.UnofficialCurrencyCode != "":
+		return transaction.UnofficialCurrencyCode
+	case o.Currency != "":
+		return o.Currency
+	default:
+		return "USD"
+	}
+}
+
+func (o *Options) decimals(currency string) int {
+	if o.Decimals != nil {
+		return o.Decimals(currency)
+	}
+	return plaid.CurrencyDecimals(currency)
+}
+
+// transactions returns the transactions to export sorted by date.
+func (o *Options) transactions(transactions []plaid.Transaction) []plaid.Transaction {
+	var selected []plaid.Transaction
+	for _, t := range transactions {
+		if !(o.SkipPending && t.Pending) {
+			selected = append(selected, t)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].Date < selected[j].Date
+	})
+	return selected
+}
+
+// DefaultAccount names a ledger account after a Plaid account: depository and investment
+// accounts are assets, credit and loan accounts liabilities, e.g. "Assets:PlaidChecking"
+// or "Liabilities:PlaidCreditCard".
+func DefaultAccount(account plaid.Account) string {
+	name := accountComponent(account.Name)
+	if name == "" {
+		name = accountComponent(account.Subtype + " " + string(account.Mask))
+	}
+	if name == "" {
+		name = accountComponent(string(account.AccountID))
+	}
+	switch account.Type {
+	case "credit", "loan":
+		return "Liabilities:" + name
+	case "investment", "brokerage":
+		return "Assets:Investments:" + name
+	default:
+		return "Assets:" + name
+	}
+}
+
+// DefaultCategory books a transaction to an expense account named after its personal
+// finance category, e.g. "Expenses:FoodAndDrink:Coffee", or to an income account if money
+// came in. Transactions with only a legacy category use that category's hierarchy and the
+// others "Expenses:Uncategorized" or "Income:Uncategorized".
+func DefaultCategory(transaction plaid.Transaction) string {
+	root := "Expenses"
+	if transaction.Amount < 0 {
+		root = "Income"
+	}
+	path := categoryPath(transaction)
+	if len(path) > 0 && path[0] == "Income" {
+		root, path = "Income", path[1:]
+	}
+	components := []string{root}
+	for _, part := range path {
+		if component := accountComponent(part); component != "" {
+			components = append(components, component)
+		}
+	}
+	if len(components) == 1 {
+		components = append(components, "Uncategorized")
+	}
+	return strings.Join(components, ":")
+}
+
+// QIFCategory returns a transaction's category in QIF notation, e.g. "Food And
+// Drink:Coffee", or an empty string if it has none.
+func QIFCategory(transaction plaid.Transaction) string {
+	return strings.Join(categoryPath(transaction), ":")
+}
+
+// categoryPath returns the category hierarchy of a transaction as words, e.g.
+// ["Food And Drink", "Coffee"].
+func categoryPath(transaction plaid.Transaction) []string {
+	if category := transaction.PersonalFinanceCategory; category != nil && category.Primary != "" {
+		path := []string{words(category.Primary)}
+		if detailed := strings.TrimPrefix(category.Detailed, category.Primary+"_"); detailed != "" &&
+			detailed != category.Detailed {
+			path = append(path, words(detailed))
+		}
+		return path
+	}
+	return transaction.Category
+}
+
+// words turns an enum value such as "FOOD_AND_DRINK" into "Food And Drink".
+func words(value string) string {
+	parts := strings.Split(strings.ToLower(value), "_")
+	for i, part := range parts {
+		parts[i] = capitalize(part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// accountComponent turns text into a valid component of a Beancount or ledger account
+// name, e.g. "Food and Drink" into "FoodAndDrink".
+func accountComponent(text string) string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, field := range fields {
+		fields[i] = capitalize(field)
+	}
+	return strings.Join(fields, "")
+}
+
+func capitalize(word string) string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return ""
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// round rounds an amount to decimals, half away from zero.
+func round(value float64, decimals int) float64 {
+	return math.Round(value*math.Pow10(decimals)) / math.Pow10(decimals)
+}
+
+// amount formats an amount rounded to decimals.
+func amount(value float64, decimals int) string {
+	return strconv.FormatFloat(round(value, decimals), 'f', decimals, 64)
+}
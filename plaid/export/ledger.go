@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+// WriteBeancount writes transactions as a Beancount ledger, opening every account used on
+// the date of its first transaction. Each transaction is booked as the postings of its
+// JournalEntry; pending transactions are flagged with "!".
+//
+// See https://beancount.github.io/docs/beancount_language_syntax.html.
+func WriteBeancount(w io.Writer, accounts []plaid.Account, transactions []plaid.Transaction,
+	options *Options) error {
+
+	if options == nil {
+		options = &Options{}
+	}
+	entries := JournalEntries(accounts, transactions, options)
+	out := bufio.NewWriter(w)
+	opened := map[string]bool{}
+	for _, entry := range entries {
+		for _, posting := range entry.Postings {
+			if !opened[posting.Account] {
+				opened[posting.Account] = true
+				fmt.Fprintf(out, "%s open %s\n", entry.Date, posting.Account)
+			}
+		}
+	}
+	for _, entry := range entries {
+		flag := "*"
+		if entry.Pending {
+			flag = "!"
+		}
+		if entry.Payee != "" {
+			fmt.Fprintf(out, "\n%s %s %s %s\n", entry.Date, flag, strconv.Quote(entry.Payee), strconv.Quote(entry.Description))
+		} else {
+			fmt.Fprintf(out, "\n%s %s %s\n", entry.Date, flag, strconv.Quote(entry.Description))
+		}
+		fmt.Fprintf(out, "  plaid_transaction_id: %s\n", strconv.Quote(string(entry.TransactionID)))
+		for _, posting := range entry.Postings {
+			fmt.Fprintf(out, "  %s  %s %s\n", posting.Account, amount(posting.Amount, options.decimals(posting.Currency)), posting.Currency)
+		}
+	}
+	return out.Flush()
+}
+
+// WriteLedger writes transactions as a ledger-cli journal. Each transaction is booked as
+// the postings of its JournalEntry; pending transactions are marked with "!".
+//
+// See https://ledger-cli.org/doc/ledger3.html#Journal-Format.
+func WriteLedger(w io.Writer, accounts []plaid.Account, transactions []plaid.Transaction,
+	options *Options) error {
+
+	if options == nil {
+		options = &Options{}
+	}
+	out := bufio.NewWriter(w)
+	for i, entry := range JournalEntries(accounts, transactions, options) {
+		if i > 0 {
+			fmt.Fprint(out, "\n")
+		}
+		flag := "*"
+		if entry.Pending {
+			flag = "!"
+		}
+		payee := entry.Payee
+		if payee == "" {
+			payee = entry.Description
+		}
+		fmt.Fprintf(out, "%s %s %s\n", strings.Replace(entry.Date, "-", "/", -1), flag, ledgerText(payee))
+		fmt.Fprintf(out, "    ; plaid_transaction_id: %s\n", entry.TransactionID)
+		if entry.Description != payee {
+			fmt.Fprintf(out, "    ; %s\n", ledgerText(entry.Description))
+		}
+		for _, posting := range entry.Postings {
+			fmt.Fprintf(out, "    %s  %s %s\n", posting.Account, amount(posting.Amount, options.decimals(posting.Currency)), posting.Currency)
+		}
+	}
+	return out.Flush()
+}
+
+// ledgerText keeps text on a single line.
+func ledgerText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"math"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+// JournalEntry is a transaction as a balanced double-entry journal entry.
+type JournalEntry struct {
+	Date          string // YYYY-MM-DD
+	TransactionID plaid.TransactionID
+	Payee         string // merchant name, may be empty
+	Description   string
+	Pending       bool
+	Postings      []Posting
+}
+
+// Posting is one leg of a journal entry. Positive amounts are debits, negative amounts
+// credits.
+type Posting struct {
+	Account  string
+	Amount   float64
+	Currency string
+}
+
+// Balanced reports whether the postings of the entry sum to zero in every currency, in the
+// minor unit of the currency given by plaid.CurrencyDecimals.
+func (e *JournalEntry) Balanced() bool {
+	units := map[string]int64{}
+	for _, posting := range e.Postings {
+		decimals := plaid.CurrencyDecimals(posting.Currency)
+		units[posting.Currency] += int64(math.Round(posting.Amount * math.Pow10(decimals)))
+	}
+	for _, sum := range units {
+		if sum != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// JournalEntries converts transactions into balanced journal entries sorted by date. Each
+// transaction becomes two postings: one to the account mapped from its Plaid account and
+// one to the account mapped from its category, as configured by options. Money leaving a
+// Plaid account credits it and debits the category.
+func JournalEntries(accounts []plaid.Account, transactions []plaid.Transaction, options *Options) []JournalEntry {
+	if options == nil {
+		options = &Options{}
+	}
+	byID := make(map[plaid.AccountID]plaid.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.AccountID] = account
+	}
+	var entries []JournalEntry
+	for _, t := range options.transactions(transactions) {
+		account, ok := byID[t.AccountID]
+		if !ok {
+			account = plaid.Account{AccountID: t.AccountID}
+		}
+		currency := options.currency(t)
+		value := round(t.Amount64(), options.decimals(currency))
+		entries = append(entries, JournalEntry{
+			Date:          t.Date,
+			TransactionID: t.TransactionID,
+			Payee:         t.MerchantName,
+			Description:   t.Name,
+			Pending:       t.Pending,
+			Postings: []Posting{
+				{Account: options.account(account), Amount: -value, Currency: currency},
+				{Account: options.category(t, DefaultCategory), Amount: value, Currency: currency},
+			},
+		})
+	}
+	return entries
+}
+
+// ChartOfAccounts maps Plaid accounts and categories to the accounts of a chart of
+// accounts. Use its methods as the Account and Category functions of Options:
+//
+//	chart := export.ChartOfAccounts{
+//		Accounts:   map[plaid.AccountID]string{checkingID: "Assets:Bank:Operating"},
+//		Categories: map[string]string{"FOOD_AND_DRINK": "Expenses:Meals"},
+//	}
+//	options := &export.Options{Account: chart.Account, Category: chart.Category}
+type ChartOfAccounts struct {
+	// Accounts maps Plaid account ids to ledger accounts.
+	Accounts map[plaid.AccountID]string
+	// Categories maps categories to ledger accounts. Keys are detailed or primary personal
+	// finance categories such as "FOOD_AND_DRINK_COFFEE" or "FOOD_AND_DRINK", or legacy
+	// category ids such as "13005043"; the most specific match wins.
+	Categories map[string]string
+	// DefaultAccount and DefaultCategory are used for accounts and transactions without a
+	// mapping. They default to the package's DefaultAccount and DefaultCategory.
+	DefaultAccount  func(account plaid.Account) string
+	DefaultCategory func(transaction plaid.Transaction) string
+}
+
+// Account returns the ledger account of a Plaid account.
+func (c ChartOfAccounts) Account(account plaid.Account) string {
+	if mapped, ok := c.Accounts[account.AccountID]; ok {
+		return mapped
+	}
+	if c.DefaultAccount != nil {
+		return c.DefaultAccount(account)
+	}
+	return DefaultAccount(account)
+}
+
+// Category returns the ledger account a transaction is booked against.
+func (c ChartOfAccounts) Category(transaction plaid.Transaction) string {
+	var keys []string
+	if category := transaction.PersonalFinanceCategory; category != nil {
+		keys = append(keys, category.Detailed, category.Primary)
+	}
+	keys = append(keys, transaction.CategoryID)
+	for _, key := range keys {
+		if mapped, ok := c.Categories[key]; ok && key != "" {
+			return mapped
+		}
+	}
+	if c.DefaultCategory != nil {
+		return c.DefaultCategory(transaction)
+	}
+	return DefaultCategory(transaction)
+}
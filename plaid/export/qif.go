@@ -0,0 +1,60 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+// WriteQIF writes the transactions of account in Quicken Interchange Format. QIF files hold
+// a single account, so transactions of other accounts are left out. Money leaving the
+// account is written as a negative amount.
+func WriteQIF(w io.Writer, account plaid.Account, transactions []plaid.Transaction, options *Options) error {
+	if options == nil {
+		options = &Options{}
+	}
+	out := bufio.NewWriter(w)
+	qifType := "Bank"
+	if account.Type == "credit" {
+		qifType = "CCard"
+	}
+	fmt.Fprintf(out, "!Type:%s\n", qifType)
+	for _, t := range options.transactions(transactions) {
+		if t.AccountID != account.AccountID {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", t.Date)
+		if err != nil {
+			return fmt.Errorf("transaction %s: %v", t.TransactionID, err)
+		}
+		fmt.Fprintf(out, "D%s\n", date.Format("01/02/2006"))
+		fmt.Fprintf(out, "T%s\n", amount(-t.Amount64(), options.decimals(options.currency(t))))
+		if t.Pending {
+			fmt.Fprint(out, "C\n")
+		} else {
+			fmt.Fprint(out, "CX\n")
+		}
+		payee := t.MerchantName
+		if payee == "" {
+			payee = t.Name
+		}
+		fmt.Fprintf(out, "P%s\n", qifText(payee))
+		if t.Name != payee {
+			fmt.Fprintf(out, "M%s\n", qifText(t.Name))
+		}
+		if category := options.category(t, QIFCategory); category != "" {
+			fmt.Fprintf(out, "L%s\n", qifText(category))
+		}
+		fmt.Fprint(out, "^\n")
+	}
+	return out.Flush()
+}
+
+// qifText keeps text on a single line.
+func qifText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
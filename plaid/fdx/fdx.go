@@ -0,0 +1,503 @@
+// Package fdx converts the accounts, transactions and identities returned by the plaid
+// package into Financial Data Exchange (FDX) JSON structures, for platforms that re-expose
+// aggregated data over FDX APIs.
+//
+// The structures follow the FDX API 5.x schemas for accounts, transactions and customers.
+// Only the fields Plaid provides data for are populated.
+//
+// See https://financialdataexchange.org.
+package fdx
+
+import (
+	"math"
+	"strings"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+// Account categories, the discriminator of FDX account types.
+const (
+	DepositAccount    = "DEPOSIT_ACCOUNT"
+	LocAccount        = "LOC_ACCOUNT"
+	LoanAccount       = "LOAN_ACCOUNT"
+	InvestmentAccount = "INVESTMENT_ACCOUNT"
+)
+
+// Currency is an FDX currency.
+type Currency struct {
+	CurrencyCode string `json:"currencyCode"`
+}
+
+// Account is an FDX account. Balance fields are set according to AccountCategory: current
+// and available balances for deposit accounts, current balance, credit line and available
+// credit for lines of credit, principal balance for loans and current value for
+// investment accounts.
+type Account struct {
+	AccountID            string    `json:"accountId"`
+	AccountCategory      string    `json:"accountCategory"`
+	AccountType          string    `json:"accountType,omitempty"`
+	AccountNumberDisplay string    `json:"accountNumberDisplay,omitempty"`
+	ProductName          string    `json:"productName,omitempty"`
+	Nickname             string    `json:"nickname,omitempty"`
+	Status               string    `json:"status"`
+	Currency             *Currency `json:"currency,omitempty"`
+
+	CurrentBalance   *float64 `json:"currentBalance,omitempty"`
+	AvailableBalance *float64 `json:"availableBalance,omitempty"`
+	CreditLine       *float64 `json:"creditLine,omitempty"`
+	AvailableCredit  *float64 `json:"availableCredit,omitempty"`
+	PrincipalBalance *float64 `json:"principalBalance,omitempty"`
+	CurrentValue     *float64 `json:"currentValue,omitempty"`
+}
+
+// Transaction is an FDX transaction.
+type Transaction struct {
+	AccountCategory        string  `json:"accountCategory,omitempty"`
+	AccountID              string  `json:"accountId"`
+	TransactionID          string  `json:"transactionId"`
+	ReferenceTransactionID string  `json:"referenceTransactionId,omitempty"`
+	PostedTimestamp        string  `json:"postedTimestamp,omitempty"`
+	TransactionTimestamp   string  `json:"transactionTimestamp"`
+	Description            string  `json:"description"`
+	Memo                   string  `json:"memo,omitempty"`
+	DebitCreditMemo        string  `json:"debitCreditMemo"` // "DEBIT" or "CREDIT"
+	Category               string  `json:"category,omitempty"`
+	SubCategory            string  `json:"subCategory,omitempty"`
+	Status                 string  `json:"status"` // "PENDING" or "POSTED"
+	Amount                 float64 `json:"amount"`
+	Payee                  string  `json:"payee,omitempty"`
+	// ForeignCurrency is only set if it differs from the account's currency.
+	ForeignCurrency string `json:"foreignCurrency,omitempty"`
+}
+
+// Customer is an FDX customer, the holder of one or more accounts.
+type Customer struct {
+	CustomerID string            `json:"customerId,omitempty"`
+	Name       *CustomerName     `json:"name,omitempty"`
+	Addresses  []Address         `json:"addresses,omitempty"`
+	Telephones []Telephone       `json:"telephones,omitempty"`
+	Email      []string          `json:"email,omitempty"`
+	Accounts   []CustomerAccount `json:"accounts,omitempty"`
+}
+
+// CustomerName is the name of a customer.
+type CustomerName struct {
+	First  string `json:"first,omitempty"`
+	Middle string `json:"middle,omitempty"`
+	Last   string `json:"last,omitempty"`
+}
+
+// Address is an FDX postal address.
+type Address struct {
+	Line1      string `json:"line1"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postalCode,omitempty"`
+	Country    string `json:"country,omitempty"`
+	Type       string `json:"type,omitempty"` // "HOME" for the primary address
+}
+
+// Telephone is an FDX telephone number.
+type Telephone struct {
+	Type   string `json:"type"` // "HOME", "BUSINESS", "CELL" or "FAX"
+	Number string `json:"number"`
+}
+
+// CustomerAccount links a customer to an account.
+type CustomerAccount struct {
+	AccountID    string `json:"accountId"`
+	Relationship string `json:"relationship"` // "PRIMARY" or "JOINT"
+}
+
+// MoneyFields are the money fields of the FDX structures, see plaid.MarshalMoneyJSON.
+var MoneyFields = plaid.MoneyFields{
+	Amounts: []string{"amount", "currentBalance", "availableBalance", "creditLine", "availableCredit",
+		"principalBalance", "currentValue"},
+	Currencies: []string{"foreignCurrency", "currency.currencyCode"},
+}
+
+// Marshal encodes FDX structures as JSON with their amounts encoded by money, usually the
+// MoneyMarshaler of the client the data was fetched with. Transactions in their account's
+// currency don't name it, so their amounts are encoded as amounts with two decimals.
+func Marshal(v interface{}, money plaid.MoneyMarshaler) ([]byte, error) {
+	return plaid.MarshalMoneyJSON(v, money, MoneyFields)
+}
+
+// accountTypes maps Plaid account subtypes to FDX account types.
+var accountTypes = map[string]string{
+	"checking":       "CHECKING",
+	"savings":        "SAVINGS",
+	"money market":   "MONEYMARKET",
+	"cd":             "CD",
+	"credit card":    "CREDITCARD",
+	"line of credit": "LINEOFCREDIT",
+	"mortgage":       "MORTGAGE",
+	"home equity":    "HOMEEQUITYLOAN",
+	"student":        "STUDENTLOAN",
+	"auto":           "AUTOLOAN",
+	"consumer":       "PERSONALLOAN",
+	"brokerage":      "BROKERAGE",
+	"401k":           "401K",
+	"403b":           "403B",
+	"ira":            "IRA",
+	"roth":           "ROTH",
+	"hsa":            "HSA",
+	"529":            "529",
+}
+
+// FromAccount converts a Plaid account.
+func FromAccount(account plaid.Account) Account {
+	fdxAccount := Account{
+		AccountID:            string(account.AccountID),
+		AccountCategory:      accountCategory(account.Type),
+		AccountType:          accountTypes[account.Subtype],
+		AccountNumberDisplay: string(account.Mask),
+		ProductName:          account.OfficialName,
+		Nickname:             account.Name,
+		Status:               "OPEN",
+	}
+	if code := accountCurrency(account); code != "" {
+		fdxAccount.Currency = &Currency{CurrencyCode: code}
+	}
+	balances := account.Balances
+	switch fdxAccount.AccountCategory {
+	case DepositAccount:
+		fdxAccount.CurrentBalance = float(balances.Current)
+		fdxAccount.AvailableBalance = float(balances.Available)
+	case LocAccount:
+		fdxAccount.CurrentBalance = float(balances.Current)
+		if balances.Limit != 0 {
+			fdxAccount.CreditLine = float(balances.Limit)
+		}
+		fdxAccount.AvailableCredit = float(balances.Available)
+	case LoanAccount:
+		fdxAccount.PrincipalBalance = float(balances.Current)
+	case InvestmentAccount:
+		fdxAccount.CurrentValue = float(balances.Current)
+	}
+	return fdxAccount
+}
+
+// FromAccounts converts Plaid accounts.
+func FromAccounts(accounts []plaid.Account) []Account {
+	fdxAccounts := make([]Account, len(accounts))
+	for i, account := range accounts {
+		fdxAccounts[i] = FromAccount(account)
+	}
+	return fdxAccounts
+}
+
+func accountCategory(accountType string) string {
+	switch accountType {
+	case "credit":
+		return LocAccount
+	case "loan":
+		return LoanAccount
+	case "investment", "brokerage":
+		return InvestmentAccount
+	default:
+		return DepositAccount
+	}
+}
+
+func accountCurrency(account plaid.Account) string {
+	if account.Balances.IsoCurrencyCode != "" {
+		return account.Balances.IsoCurrencyCode
+	}
+	return account.Balances.UnofficialCurrencyCode
+}
+
+func float(f float64) *float64 {
+	return &f
+}
+
+// FromTransaction converts a Plaid transaction of account. Plaid reports money leaving the
+// account as a positive amount, FDX as a positive DEBIT.
+func FromTransaction(account plaid.Account, transaction plaid.Transaction) Transaction {
+	fdxTransaction := Transaction{
+		AccountCategory:        accountCategory(account.Type),
+		AccountID:              string(transaction.AccountID),
+		TransactionID:          string(transaction.TransactionID),
+		ReferenceTransactionID: string(transaction.PendingTransactionID),
+		TransactionTimestamp:   timestamp(transaction.Date),
+		Description:            transaction.Name,
+		Memo:                   transaction.OriginalDescription,
+		DebitCreditMemo:        "DEBIT",
+		Status:                 "POSTED",
+		Amount:                 math.Abs(transaction.Amount64()),
+		Payee:                  transaction.MerchantName,
+	}
+	if transaction.Amount < 0 {
+		fdxTransaction.DebitCreditMemo = "CREDIT"
+	}
+	if transaction.Pending {
+		fdxTransaction.Status = "PENDING"
+	} else {
+		fdxTransaction.PostedTimestamp = fdxTransaction.TransactionTimestamp
+	}
+	if category := transaction.PersonalFinanceCategory; category != nil {
+		fdxTransaction.Category = category.Primary
+		fdxTransaction.SubCategory = category.Detailed
+	} else if len(transaction.Category) > 0 {
+		fdxTransaction.Category = transaction.Category[0]
+		if len(transaction.Category) > 1 {
+			fdxTransaction.SubCategory = transaction.Category[len(transaction.Category)-1]
+		}
+	}
+	currency := transaction.IsoCurrencyCode
+	if currency == "" {
+		currency = transaction.UnofficialCurrencyCode
+	}
+	if currency != "" && currency != accountCurrency(account) {
+		fdxTransaction.ForeignCurrency = currency
+	}
+	return fdxTransaction
+}
+
+// FromTransactions converts Plaid transactions, looking up their accounts in accounts.
+// Transactions of accounts that are missing are converted as deposit account transactions.
+func FromTransactions(accounts []plaid.Account, transactions []plaid.Transaction) []Transaction {
+	byID := make(map[plaid.AccountID]plaid.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.AccountID] = account
+	}
+	fdxTransactions := make([]Transaction, len(transactions))
+	for i, transaction := range transactions {
+		fdxTransactions[i] = FromTransaction(byID[transaction.AccountID], transaction)
+	}
+	return fdxTransactions
+}
+
+// timestamp converts a Plaid date to an FDX timestamp.
+func timestamp(date string) string {
+	if date == "" {
+		return ""
+	}
+	return date + "T00:00:00Z"
+}
+
+// FromIdentity converts the owners of the accounts returned by /identity/get into
+// customers. Owners are matched across accounts by their full name and their contact
+// details: two owners are the same customer if they share a name and an email address, phone
+// number or address, or share a name and neither has any contact details. An owner of
+// several accounts thus becomes a single customer with the contact details of every account,
+// while different people of the same name stay apart. Accounts with more than one owner are
+// linked to their customers as JOINT accounts.
+func FromIdentity(accounts []plaid.Account) []Customer {
+	var customers []Customer
+	var identities []ownerIdentity
+	for _, account := range accounts {
+		relationship := "PRIMARY"
+		if len(account.Owners) > 1 {
+			relationship = "JOINT"
+		}
+		for _, owner := range account.Owners {
+			identity := identify(owner)
+			i := 0
+			for ; i < len(identities); i++ {
+				if identities[i].matches(identity) {
+					break
+				}
+			}
+			if i == len(customers) {
+				customers = append(customers, FromOwner(owner))
+				identities = append(identities, identity)
+			} else {
+				mergeCustomer(&customers[i], FromOwner(owner))
+				identities[i].merge(identity)
+			}
+			customers[i].Accounts = append(customers[i].Accounts,
+				CustomerAccount{AccountID: string(account.AccountID), Relationship: relationship})
+		}
+	}
+	return customers
+}
+
+// ownerIdentity holds the normalized names and contact details owners are matched by.
+type ownerIdentity struct {
+	names, contacts map[string]bool
+}
+
+func identify(owner plaid.Owner) ownerIdentity {
+	identity := ownerIdentity{names: map[string]bool{}, contacts: map[string]bool{}}
+	for _, name := range owner.Names {
+		if name = normalize(name); name != "" {
+			identity.names[name] = true
+		}
+	}
+	for _, email := range owner.Emails {
+		if email := normalize(email.Data); email != "" {
+			identity.contacts["email:"+email] = true
+		}
+	}
+	for _, phone := range owner.PhoneNumbers {
+		if digits := phoneDigits(phone.Data); digits != "" {
+			identity.contacts["phone:"+digits] = true
+		}
+	}
+	for _, address := range owner.Addresses {
+		if address := normalize(address.Data.String()); address != "" {
+			identity.contacts["address:"+address] = true
+		}
+	}
+	return identity
+}
+
+// matches reports whether two owners are the same person. Owners without a name never match.
+func (id ownerIdentity) matches(other ownerIdentity) bool {
+	if !intersects(id.names, other.names) {
+		return false
+	}
+	if len(id.contacts) == 0 && len(other.contacts) == 0 {
+		return true
+	}
+	return intersects(id.contacts, other.contacts)
+}
+
+func (id ownerIdentity) merge(other ownerIdentity) {
+	for name := range other.names {
+		id.names[name] = true
+	}
+	for contact := range other.contacts {
+		id.contacts[contact] = true
+	}
+}
+
+func intersects(a, b map[string]bool) bool {
+	for key := range a {
+		if b[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize lower-cases s and collapses its whitespace.
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// phoneDigits returns the digits of a phone number, without the US country code.
+func phoneDigits(number string) string {
+	var digits strings.Builder
+	for _, r := range number {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	if d := digits.String(); len(d) == 11 && d[0] == '1' {
+		return d[1:]
+	}
+	return digits.String()
+}
+
+// mergeCustomer adds the contact details of other that customer doesn't have yet.
+func mergeCustomer(customer *Customer, other Customer) {
+	if customer.Name == nil {
+		customer.Name = other.Name
+	}
+	for _, address := range other.Addresses {
+		if !containsAddress(customer.Addresses, address) {
+			customer.Addresses = append(customer.Addresses, address)
+		}
+	}
+	for _, phone := range other.Telephones {
+		if !containsTelephone(customer.Telephones, phone) {
+			customer.Telephones = append(customer.Telephones, phone)
+		}
+	}
+	for _, email := range other.Email {
+		if !containsEmail(customer.Email, email) {
+			customer.Email = append(customer.Email, email)
+		}
+	}
+}
+
+func containsAddress(addresses []Address, address Address) bool {
+	for _, a := range addresses {
+		if normalize(a.Line1) == normalize(address.Line1) && normalize(a.City) == normalize(address.City) &&
+			normalize(a.PostalCode) == normalize(address.PostalCode) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsTelephone(telephones []Telephone, telephone Telephone) bool {
+	for _, t := range telephones {
+		if phoneDigits(t.Number) == phoneDigits(telephone.Number) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsEmail(emails []string, email string) bool {
+	for _, e := range emails {
+		if normalize(e) == normalize(email) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromOwner converts an account owner. Plaid returns full names, which are split into
+// first, middle and last name at spaces.
+func FromOwner(owner plaid.Owner) Customer {
+	var customer Customer
+	if len(owner.Names) > 0 {
+		customer.Name = splitName(owner.Names[0])
+	}
+	for _, address := range owner.Addresses {
+		fdxAddress := Address{
+			Line1:      address.Data.Street,
+			City:       address.Data.City,
+			Region:     address.Data.Region,
+			PostalCode: string(address.Data.PostalCode),
+			Country:    address.Data.Country,
+		}
+		if address.Primary {
+			fdxAddress.Type = "HOME"
+		}
+		customer.Addresses = append(customer.Addresses, fdxAddress)
+	}
+	for _, phone := range owner.PhoneNumbers {
+		customer.Telephones = append(customer.Telephones,
+			Telephone{Type: telephoneType(phone.Type), Number: phone.Data})
+	}
+	for _, email := range owner.Emails {
+		if email.Primary {
+			customer.Email = append([]string{email.Data}, customer.Email...)
+		} else {
+			customer.Email = append(customer.Email, email.Data)
+		}
+	}
+	return customer
+}
+
+func splitName(name string) *CustomerName {
+	parts := strings.Fields(name)
+	switch len(parts) {
+	case 0:
+		return nil
+	case 1:
+		return &CustomerName{Last: parts[0]}
+	default:
+		return &CustomerName{
+			First:  parts[0],
+			Middle: strings.Join(parts[1:len(parts)-1], " "),
+			Last:   parts[len(parts)-1],
+		}
+	}
+}
+
+func telephoneType(plaidType string) string {
+	switch plaidType {
+	case "work", "office":
+		return "BUSINESS"
+	case "mobile", "mobile1":
+		return "CELL"
+	default:
+		return "HOME"
+	}
+}
@@ -0,0 +1,51 @@
+package fdx
+
+import (
+	"testing"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+func TestFromIdentity(t *testing.T) {
+	owner := func(name, email, phone string) plaid.Owner {
+		o := plaid.Owner{Names: []string{name}}
+		if email != "" {
+			o.Emails = []plaid.OwnerEmail{{Data: email, Primary: true}}
+		}
+		if phone != "" {
+			o.PhoneNumbers = []plaid.OwnerPhoneNumber{{Data: phone, Type: "mobile"}}
+		}
+		return o
+	}
+	accounts := []plaid.Account{
+		{AccountID: "checking", Owners: []plaid.Owner{owner("Alberta Bobbeth Charleson", "alberta@example.com", "")}},
+		// The same person, matched on the email and contributing a phone number.
+		{AccountID: "savings", Owners: []plaid.Owner{owner("alberta  bobbeth charleson", "Alberta@example.com", "+1 (111) 222-3333")}},
+		// Someone else of the same name.
+		{AccountID: "credit", Owners: []plaid.Owner{owner("Alberta Bobbeth Charleson", "", "555-0100")}},
+		// The same first name as the first owner is not enough.
+		{AccountID: "joint", Owners: []plaid.Owner{
+			owner("Alberta Smith", "alberta@example.com", ""),
+			owner("Alberta Bobbeth Charleson", "", "1112223333"),
+		}},
+	}
+	customers := FromIdentity(accounts)
+	if len(customers) != 3 {
+		t.Fatalf("got %d customers, want 3: %+v", len(customers), customers)
+	}
+	first := customers[0]
+	if len(first.Accounts) != 3 || first.Accounts[0].AccountID != "checking" || first.Accounts[1].AccountID != "savings" ||
+		first.Accounts[2] != (CustomerAccount{AccountID: "joint", Relationship: "JOINT"}) {
+		t.Errorf("first customer's accounts = %+v, want checking, savings and joint", first.Accounts)
+	}
+	if len(first.Email) != 1 || len(first.Telephones) != 1 {
+		t.Errorf("first customer has emails %v and telephones %v, want the merged contact details without duplicates",
+			first.Email, first.Telephones)
+	}
+	if len(customers[1].Accounts) != 1 || customers[1].Accounts[0].AccountID != "credit" {
+		t.Errorf("second customer's accounts = %+v, want credit", customers[1].Accounts)
+	}
+	if customers[2].Name == nil || customers[2].Name.Last != "Smith" {
+		t.Errorf("third customer = %+v, want Alberta Smith", customers[2])
+	}
+}
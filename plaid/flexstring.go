@@ -0,0 +1,6 @@
+package plaid
+
+import "github.com/wearevest/plaidgo/plaid/core"
+
+// FlexString is a string field that Plaid sometimes sends as a number, see core.FlexString.
+type FlexString = core.FlexString
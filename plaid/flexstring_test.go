@@ -0,0 +1,118 @@
+package plaid
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+func TestFlexString(t *testing.T) {
+	tests := []struct {
+		name, json string
+		want       FlexString
+		wantErr    bool
+	}{
+		{name: "string", json: `"1234"`, want: "1234"},
+		{name: "escaped string", json: `"a\"bé"`, want: `a"bé`},
+		{name: "integer", json: `1234`, want: "1234"},
+		{name: "leading zeros kept as sent", json: `"0042"`, want: "0042"},
+		{name: "decimal", json: `0.50`, want: "0.50"},
+		{name: "negative", json: `-7`, want: "-7"},
+		{name: "exponent", json: `1e3`, want: "1e3"},
+		{name: "true", json: `true`, want: "true"},
+		{name: "false", json: `false`, want: "false"},
+		{name: "null leaves the field unchanged", json: `null`, want: "previous"},
+		{name: "object", json: `{"mask": "1234"}`, wantErr: true},
+		{name: "array", json: `["1234"]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := FlexString("previous")
+			err := json.Unmarshal([]byte(tt.json), &s)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %q, want an error", s)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tt.want {
+				t.Errorf("got %q, want %q", s, tt.want)
+			}
+		})
+	}
+}
+
+// TestFlexStringFields decodes the fields Plaid is known to send as numbers.
+func TestFlexStringFields(t *testing.T) {
+	var account Account
+	if err := json.Unmarshal([]byte(`{"account_id": "a", "mask": 4321}`), &account); err != nil {
+		t.Fatal(err)
+	}
+	if account.Mask != "4321" {
+		t.Errorf("got mask %q, want 4321", account.Mask)
+	}
+	var location Location
+	if err := json.Unmarshal([]byte(`{"postal_code": 94108, "zip": 2110, "store_number": 1235.0}`), &location); err != nil {
+		t.Fatal(err)
+	}
+	if location.PostalCode != "94108" || location.Zip != "2110" || location.StoreNumber != "1235.0" {
+		t.Errorf("got %+v", location)
+	}
+	encoded, err := json.Marshal(struct{ Mask FlexString }{"4321"})
+	if err != nil || string(encoded) != `{"Mask":"4321"}` {
+		t.Errorf("got %s, %v, want the mask encoded as a string", encoded, err)
+	}
+
+	err = core.Decode([]byte(`{"accounts": [{"mask": {"last4": "1234"}}]}`), &struct {
+		Accounts []Account `json:"accounts"`
+	}{})
+	var decodeErr *core.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("got %v, want a *core.DecodeError", err)
+	}
+}
+
+// FuzzFlexString checks that a FlexString decodes every valid JSON scalar, that decoding
+// a string gives the string itself, and that the result round-trips through encoding. The
+// seed corpus in testdata/fuzz holds values seen in institutions' masks and postal codes.
+func FuzzFlexString(f *testing.F) {
+	for _, seed := range []string{`"1234"`, `1234`, `0.50`, `true`, `null`, `{}`, `"12\u0000"`} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var s FlexString
+		err := json.Unmarshal(data, &s)
+		var value interface{}
+		if json.Unmarshal(data, &value) != nil {
+			return // not JSON: any error is fine
+		}
+		switch value := value.(type) {
+		case map[string]interface{}, []interface{}:
+			if err == nil {
+				t.Fatalf("decoded %s to %q, want an error", data, s)
+			}
+			return
+		case string:
+			if err != nil || string(s) != value {
+				t.Fatalf("decoded %s to %q, %v, want %q", data, s, err, value)
+			}
+		default:
+			if err != nil {
+				t.Fatalf("decoding %s: %v", data, err)
+			}
+		}
+		encoded, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var again FlexString
+		if err = json.Unmarshal(encoded, &again); err != nil || again != s {
+			t.Fatalf("%q round-tripped to %q, %v", s, again, err)
+		}
+	})
+}
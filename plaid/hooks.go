@@ -0,0 +1,171 @@
+package plaid
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Hook observes the requests a client sends, e.g. for logging, metrics, tracing or audit
+// trails.
+//
+// Hooks receive the context the request was made with: the ctx of a method's Context
+// variant, e.g. TransactionsContext, or of the component or helper that made the request,
+// and context.Background() for the variants without one. Request-scoped values such as a
+// tenant or trace id stored in that context with context.WithValue therefore reach the hook.
+// The context may already be done when the hook is called, e.g. if the request failed
+// because it was canceled.
+type Hook interface {
+	// AfterRequest is called synchronously once a request completed or failed, so it should
+	// hand off anything slow.
+	AfterRequest(ctx context.Context, event RequestEvent)
+}
+
+// HookFunc adapts a function to the Hook interface.
+type HookFunc func(ctx context.Context, event RequestEvent)
+
+// AfterRequest implements Hook.
+func (f HookFunc) AfterRequest(ctx context.Context, event RequestEvent) {
+	f(ctx, event)
+}
+
+// RequestEvent describes a request sent by a client.
+type RequestEvent struct {
+	Method     string
+	Endpoint   string
+	Start      time.Time
+	Duration   time.Duration
+	StatusCode int // 0 if no response was received
+	RequestID  string
+	ErrorType  string
+	ErrorCode  string
+	Err        error // transport error, if any
+
+	// TokenFingerprint identifies the access token the request was made with, see
+	// TokenFingerprint. It is empty for requests without an access token.
+	TokenFingerprint string
+	// ItemID is the item of the request if the response carried it.
+	ItemID ItemID
+	// ItemErrorCode is the error code of the item's error state if the response carried the
+	// item and it was in one.
+	ItemErrorCode string
+}
+
+// WithHook adds a hook that the client calls after every request. Several hooks are called
+// in the order they were added.
+func WithHook(hook Hook) Option {
+	return func(c *Client) {
+		hooks := make([]Hook, len(c.hooks), len(c.hooks)+1)
+		copy(hooks, c.hooks)
+		c.hooks = append(hooks, hook)
+	}
+}
+
+// TokenFingerprint returns a stable identifier of an access token that doesn't reveal the
+// token, for use in logs and audit trails.
+func TokenFingerprint(accessToken string) string {
+	return tokenHash(accessToken)
+}
+
+// AccessEvent records the use of an access token.
+type AccessEvent struct {
+	Time             time.Time
+	TokenFingerprint string
+	ItemID           ItemID // empty if the response didn't carry it
+	Endpoint         string
+	// Outcome is "SUCCESS", "PLAID_ERROR" or "TRANSPORT_ERROR".
+	Outcome   string
+	ErrorCode string
+	RequestID string
+}
+
+// AccessAuditHook returns a hook that calls fn every time the client uses an access token,
+// for building per-user data access audit trails. Store the TokenFingerprint of each token
+// along with its owner to join the events to users.
+func AccessAuditHook(fn func(ctx context.Context, event AccessEvent)) Hook {
+	return HookFunc(func(ctx context.Context, event RequestEvent) {
+		if event.TokenFingerprint == "" {
+			return
+		}
+		access := AccessEvent{
+			Time:             event.Start,
+			TokenFingerprint: event.TokenFingerprint,
+			ItemID:           event.ItemID,
+			Endpoint:         event.Endpoint,
+			Outcome:          "SUCCESS",
+			ErrorCode:        event.ErrorCode,
+			RequestID:        event.RequestID,
+		}
+		switch {
+		case event.Err != nil || event.StatusCode == 0:
+			access.Outcome = "TRANSPORT_ERROR"
+		case event.StatusCode != 200:
+			access.Outcome = "PLAID_ERROR"
+		}
+		fn(ctx, access)
+	})
+}
+
+// responseSummary holds the fields of a response body the client's observers look at.
+type responseSummary struct {
+	RequestID string `json:"request_id"`
+	ErrorType string `json:"error_type"`
+	ErrorCode string `json:"error_code"`
+	// ItemID is set instead of Item by e.g. /item/public_token/exchange.
+	ItemID ItemID `json:"item_id"`
+	Item   struct {
+		ItemID        ItemID `json:"item_id"`
+		InstitutionID string `json:"institution_id"`
+		Error         *struct {
+			ErrorCode string `json:"error_code"`
+		} `json:"error"`
+	} `json:"item"`
+}
+
+// inspectsRequests reports whether the client needs to know the access tokens of requests.
+func (c *Client) inspectsRequests() bool {
+	return c.debug != nil || c.cooldown != nil || len(c.hooks) > 0 || c.events != nil
+}
+
+// observe reports a request to the client's observers. raw is the response body and token
+// the tokenHash of the request's access token.
+func (c *Client) observe(ctx context.Context, request SlowRequest, raw []byte, token string) {
+	if c.slow == nil && !c.inspectsRequests() {
+		return
+	}
+	var summary responseSummary
+	if len(raw) > 0 {
+		json.Unmarshal(raw, &summary)
+	}
+	request.RequestID, request.ResponseBytes = summary.RequestID, len(raw)
+
+	c.slow.observe(request)
+	c.debug.record(request, summary, token)
+	c.cooldown.observe(token, request.StatusCode, summary, c.clock.Now(), c.emit)
+	if len(c.hooks) == 0 && c.events == nil {
+		return
+	}
+	event := RequestEvent{
+		Method:           request.Method,
+		Endpoint:         request.Endpoint,
+		Start:            request.Start,
+		Duration:         request.Duration,
+		StatusCode:       request.StatusCode,
+		RequestID:        summary.RequestID,
+		ErrorType:        summary.ErrorType,
+		ErrorCode:        summary.ErrorCode,
+		Err:              request.Err,
+		TokenFingerprint: token,
+		ItemID:           summary.Item.ItemID,
+	}
+	if event.ItemID == "" {
+		event.ItemID = summary.ItemID
+	}
+	if summary.Item.Error != nil {
+		event.ItemErrorCode = summary.Item.Error.ErrorCode
+	}
+	for _, hook := range c.hooks {
+		hook.AfterRequest(ctx, event)
+	}
+	c.emit(Event{Type: EventRequestCompleted, Endpoint: request.Endpoint, Request: &event})
+}
@@ -0,0 +1,67 @@
+package plaid
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type traceKey struct{}
+
+func TestHookContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		switch r.URL.Path {
+		case "/item/public_token/exchange":
+			w.Write([]byte(`{"access_token": "access-sandbox-1", "item_id": "item-1", "request_id": "r1"}`))
+		case "/transactions/get":
+			w.Write([]byte(`{"transactions": [], "total_transactions": 0, "request_id": "r2"}`))
+		default:
+			w.WriteHeader(400)
+			w.Write([]byte(`{"error_type": "ITEM_ERROR", "error_code": "ITEM_NOT_FOUND", "request_id": "r3"}`))
+		}
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	traces := map[string][]interface{}{}
+	hook := HookFunc(func(ctx context.Context, event RequestEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		traces[event.Endpoint] = append(traces[event.Endpoint], ctx.Value(traceKey{}))
+	})
+	c := NewClient("id", "secret", Sandbox, WithBaseURL(server.URL), WithHook(hook))
+	ctx := context.WithValue(context.Background(), traceKey{}, "trace-1")
+
+	// A Context variant, a helper making its requests through per-call deadlines, and the
+	// rollback of a failed onboarding, which outlives the caller's cancellation.
+	c.TransactionsContext(ctx, "access-sandbox-1", "2024-01-01", "2024-01-31", TransactionOptionsJson{})
+	c.With(WithDeadlines(Deadlines{PerCall: time.Minute})).TransactionsConcurrentlyContext(ctx, "access-sandbox-1",
+		"2024-01-01", "2024-01-31", ConcurrentPages{})
+	c.OnboardContext(ctx, "public-sandbox-1", nil)
+	// A variant without a context.
+	c.ItemGet("access-sandbox-1")
+
+	want := map[string][]interface{}{
+		"/transactions/get":           {"trace-1", "trace-1"},
+		"/item/public_token/exchange": {"trace-1"},
+		"/item/get":                   {"trace-1", nil},
+		"/item/remove":                {"trace-1"},
+	}
+	for endpoint, values := range want {
+		got := traces[endpoint]
+		if len(got) != len(values) {
+			t.Errorf("hook called %d times for %s, want %d", len(got), endpoint, len(values))
+			continue
+		}
+		for i := range values {
+			if got[i] != values[i] {
+				t.Errorf("hook call %d for %s saw trace %v, want %v", i, endpoint, got[i], values[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wearevest/plaidgo/plaid/identity"
+)
+
+// IdentityGet (POST /identity/get) retrieves the accounts of an item together with the
+// names, phone numbers, emails and addresses of their owners as held by the institution.
+//
+// See https://plaid.com/docs/api/products/identity/#identityget.
+func (c *Client) IdentityGet(accessToken string) (*IdentityGetResponse, error) {
+	return c.IdentityGetContext(context.Background(), accessToken)
+}
+
+// IdentityGetContext is like IdentityGet but carries a context.
+func (c *Client) IdentityGetContext(ctx context.Context, accessToken string) (*IdentityGetResponse, error) {
+	if accessToken == "" {
+		return nil, errors.New("/identity/get - access token must be specified")
+	}
+	var res IdentityGetResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/identity/get", itemJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// IdentityGetResponse is the response of /identity/get. Every account carries its Owners.
+type IdentityGetResponse struct {
+	Accounts  Accounts `json:"accounts"`
+	Item      Item     `json:"item"`
+	RequestID string   `json:"request_id"`
+}
+
+// Owner holds the identity information an institution has on file for an account holder,
+// see identity.Owner.
+type Owner = identity.Owner
+
+// OwnerPhoneNumber is a phone number of an account owner.
+type OwnerPhoneNumber = identity.OwnerPhoneNumber
+
+// OwnerEmail is an email address of an account owner.
+type OwnerEmail = identity.OwnerEmail
+
+// OwnerAddress is a postal address of an account owner.
+type OwnerAddress = identity.OwnerAddress
+
+// OwnerAddressData holds the fields of an OwnerAddress.
+type OwnerAddressData = identity.OwnerAddressData
@@ -0,0 +1,64 @@
+// Package identity holds the types of Plaid's Identity product, which retrieves the names,
+// phone numbers, emails and addresses institutions have on file for account holders. The
+// plaid package's Client makes the requests; see package core for how the products are split
+// out of plaid.
+//
+// See https://plaid.com/docs/identity/.
+package identity
+
+import (
+	"strings"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// Owner holds the identity information an institution has on file for an account holder.
+//
+// See https://plaid.com/docs/api/products/identity/#identity-get-response-accounts-owners.
+type Owner struct {
+	Names        []string           `json:"names"`
+	PhoneNumbers []OwnerPhoneNumber `json:"phone_numbers"`
+	Emails       []OwnerEmail       `json:"emails"`
+	Addresses    []OwnerAddress     `json:"addresses"`
+}
+
+// OwnerPhoneNumber is a phone number of an account owner.
+type OwnerPhoneNumber struct {
+	Data    string `json:"data"`
+	Primary bool   `json:"primary"`
+	Type    string `json:"type"` // "home", "work", "office", "mobile", "mobile1" or "other"
+}
+
+// OwnerEmail is an email address of an account owner.
+type OwnerEmail struct {
+	Data    string `json:"data"`
+	Primary bool   `json:"primary"`
+	Type    string `json:"type"` // "primary", "secondary" or "other"
+}
+
+// OwnerAddress is a postal address of an account owner.
+type OwnerAddress struct {
+	Data    OwnerAddressData `json:"data"`
+	Primary bool             `json:"primary"`
+}
+
+// OwnerAddressData holds the fields of an OwnerAddress.
+type OwnerAddressData struct {
+	Street     string          `json:"street"`
+	City       string          `json:"city"`
+	Region     string          `json:"region"`
+	PostalCode core.FlexString `json:"postal_code"`
+	Country    string          `json:"country"`
+}
+
+// String formats an address as a single line, e.g. "2992 Cameron Road, Malakoff, NY 14236, US".
+func (a OwnerAddressData) String() string {
+	var parts []string
+	region := strings.TrimSpace(a.Region + " " + string(a.PostalCode))
+	for _, part := range []string{a.Street, a.City, region, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
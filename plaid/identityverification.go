@@ -0,0 +1,199 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// IdentityVerificationStatus is the state of an identity verification session.
+type IdentityVerificationStatus string
+
+const (
+	IdentityVerificationActive        IdentityVerificationStatus = "active"
+	IdentityVerificationSuccess       IdentityVerificationStatus = "success"
+	IdentityVerificationFailed        IdentityVerificationStatus = "failed"
+	IdentityVerificationExpired       IdentityVerificationStatus = "expired"
+	IdentityVerificationCanceled      IdentityVerificationStatus = "canceled"
+	IdentityVerificationPendingReview IdentityVerificationStatus = "pending_review"
+)
+
+// Terminal reports whether the session won't change status anymore without a retry or a
+// review.
+func (s IdentityVerificationStatus) Terminal() bool {
+	switch s {
+	case IdentityVerificationSuccess, IdentityVerificationFailed, IdentityVerificationExpired,
+		IdentityVerificationCanceled:
+		return true
+	}
+	return false
+}
+
+// IdentityVerificationUser is the identity details an identity verification session checks.
+//
+// See https://plaid.com/docs/api/products/identity-verification/#identity_verification-create-request-user.
+type IdentityVerificationUser struct {
+	EmailAddress string `json:"email_address,omitempty"`
+	// PhoneNumber is in E.164 format, e.g. "+14155550123".
+	PhoneNumber string `json:"phone_number,omitempty"`
+	// DateOfBirth is a Plaid date ("2006-01-02").
+	DateOfBirth string                    `json:"date_of_birth,omitempty"`
+	Name        *IdentityVerificationName `json:"name,omitempty"`
+	Address     *UserAddress              `json:"address,omitempty"`
+	IDNumber    *IdentityVerificationID   `json:"id_number,omitempty"`
+}
+
+// IdentityVerificationName is the name of an IdentityVerificationUser.
+type IdentityVerificationName struct {
+	GivenName  string `json:"given_name"`
+	FamilyName string `json:"family_name"`
+}
+
+// IdentityVerificationID is a government id number, e.g. Type "us_ssn".
+type IdentityVerificationID struct {
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+// IdentityVerificationUser returns the user's details for an identity verification
+// session. The last word of LegalName is taken as the family name, which is wrong for
+// some names; set Name explicitly where that matters.
+func (u LinkUser) IdentityVerificationUser() IdentityVerificationUser {
+	user := IdentityVerificationUser{
+		EmailAddress: u.EmailAddress,
+		PhoneNumber:  u.PhoneNumber,
+		DateOfBirth:  u.DateOfBirth,
+		Address:      u.Address,
+	}
+	if name := strings.TrimSpace(u.LegalName); name != "" {
+		user.Name = &IdentityVerificationName{GivenName: name}
+		if i := strings.LastIndex(name, " "); i >= 0 {
+			user.Name = &IdentityVerificationName{
+				GivenName:  strings.TrimSpace(name[:i]),
+				FamilyName: name[i+1:],
+			}
+		}
+	}
+	return user
+}
+
+// IdentityVerificationCreateOptions represents the optional fields of an identity
+// verification session.
+type IdentityVerificationCreateOptions struct {
+	// IsShareable makes the session's ShareableURL usable by the user outside of Link.
+	IsShareable bool
+	// GaveConsent records that the user already consented to the checks, which lets the
+	// session run without Link when the template only has steps that don't need the
+	// user, such as the KYC check.
+	GaveConsent bool
+	// IsIdempotent returns the user's existing session for the template instead of
+	// failing if there is one.
+	IsIdempotent bool
+}
+
+// IdentityVerificationCreate (POST /identity_verification/create) creates an identity
+// verification session for the application's user with the given id, from the Identity
+// Verification template templateID.
+//
+// See https://plaid.com/docs/api/products/identity-verification/#identity_verificationcreate.
+func (c *Client) IdentityVerificationCreate(templateID, clientUserID string, user IdentityVerificationUser,
+	options *IdentityVerificationCreateOptions) (*IdentityVerification, error) {
+	return c.IdentityVerificationCreateContext(context.Background(), templateID, clientUserID, user, options)
+}
+
+// IdentityVerificationCreateContext is like IdentityVerificationCreate but carries a
+// context.
+func (c *Client) IdentityVerificationCreateContext(ctx context.Context, templateID, clientUserID string,
+	user IdentityVerificationUser, options *IdentityVerificationCreateOptions) (*IdentityVerification, error) {
+
+	if templateID == "" {
+		return nil, errors.New("/identity_verification/create - template id must be specified")
+	}
+	if clientUserID == "" {
+		return nil, errors.New("/identity_verification/create - client user id must be specified")
+	}
+	creds := c.creds.Load()
+	request := identityVerificationCreateJson{
+		ClientID:     creds.clientID,
+		Secret:       creds.secret,
+		TemplateID:   templateID,
+		ClientUserID: clientUserID,
+		User:         user,
+	}
+	if options != nil {
+		request.IsShareable = options.IsShareable
+		request.GaveConsent = options.GaveConsent
+		request.IsIdempotent = options.IsIdempotent
+	}
+	var res IdentityVerification
+	if err := c.postAndDecode(ctx, "/identity_verification/create", request, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// IdentityVerificationGet (POST /identity_verification/get) retrieves an identity
+// verification session, including its status and the outcome of each step.
+//
+// See https://plaid.com/docs/api/products/identity-verification/#identity_verificationget.
+func (c *Client) IdentityVerificationGet(identityVerificationID string) (*IdentityVerification, error) {
+	return c.IdentityVerificationGetContext(context.Background(), identityVerificationID)
+}
+
+// IdentityVerificationGetContext is like IdentityVerificationGet but carries a context.
+func (c *Client) IdentityVerificationGetContext(ctx context.Context,
+	identityVerificationID string) (*IdentityVerification, error) {
+
+	if identityVerificationID == "" {
+		return nil, errors.New("/identity_verification/get - identity verification id must be specified")
+	}
+	var res IdentityVerification
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/identity_verification/get", identityVerificationGetJson{
+		ClientID:               creds.clientID,
+		Secret:                 creds.secret,
+		IdentityVerificationID: identityVerificationID,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// IdentityVerification is an identity verification session.
+//
+// See https://plaid.com/docs/api/products/identity-verification/#identity_verification-get-response.
+type IdentityVerification struct {
+	ID           string                     `json:"id"`
+	ClientUserID string                     `json:"client_user_id"`
+	CreatedAt    string                     `json:"created_at"`   // RFC 3339 timestamp
+	CompletedAt  string                     `json:"completed_at"` // RFC 3339 timestamp, empty until completed
+	Status       IdentityVerificationStatus `json:"status"`
+	ShareableURL string                     `json:"shareable_url"`
+	Template     struct {
+		ID      string `json:"id"`
+		Version int    `json:"version"`
+	} `json:"template"`
+	User IdentityVerificationUser `json:"user"`
+	// Steps maps each step of the template, e.g. "kyc_check" or "selfie_check", to its
+	// status, e.g. "success", "failed", "waiting_for_prerequisite" or "not_applicable".
+	Steps     map[string]string `json:"steps"`
+	RequestID string            `json:"request_id"`
+}
+
+type identityVerificationCreateJson struct {
+	ClientID     string                   `json:"client_id"`
+	Secret       string                   `json:"secret"`
+	TemplateID   string                   `json:"template_id"`
+	ClientUserID string                   `json:"client_user_id"`
+	IsShareable  bool                     `json:"is_shareable"`
+	GaveConsent  bool                     `json:"gave_consent"`
+	IsIdempotent bool                     `json:"is_idempotent,omitempty"`
+	User         IdentityVerificationUser `json:"user"`
+}
+
+type identityVerificationGetJson struct {
+	ClientID               string `json:"client_id"`
+	Secret                 string `json:"secret"`
+	IdentityVerificationID string `json:"identity_verification_id"`
+}
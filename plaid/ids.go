@@ -0,0 +1,96 @@
+package plaid
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// ItemID identifies an item, see core.ItemID.
+//
+// The ID types replace the plain strings ids used to be, which breaks code that passes
+// string variables or []string values where ids are expected. Untyped constants still
+// convert implicitly; convert variables with ItemID(s) and string(id), and slices with
+// IDsFromStrings and IDStrings.
+type ItemID = core.ItemID
+
+// AccountID identifies an account, see core.AccountID.
+type AccountID = core.AccountID
+
+// TransactionID identifies a transaction, see core.TransactionID.
+type TransactionID = core.TransactionID
+
+// IDsFromStrings converts ids held as strings, e.g. by code written before the ID types,
+// for the parameters that take slices of ids:
+//
+//	res, err := client.TransactionsRecurringGet(token, plaid.IDsFromStrings[plaid.AccountID](accountIDs))
+func IDsFromStrings[ID ~string](ids []string) []ID {
+	converted := make([]ID, len(ids))
+	for i, id := range ids {
+		converted[i] = ID(id)
+	}
+	return converted
+}
+
+// IDStrings converts ids to strings, e.g. for storage or code that predates the ID types.
+func IDStrings[ID ~string](ids []ID) []string {
+	converted := make([]string, len(ids))
+	for i, id := range ids {
+		converted[i] = string(id)
+	}
+	return converted
+}
+
+// tokenPrefixes are the prefixes of the tokens Plaid issues, by request field.
+var tokenPrefixes = map[string]string{
+	"access_token": "access-",
+	"public_token": "public-",
+	"link_token":   "link-",
+}
+
+// ValidateAccessToken reports an error if token is empty or not an access token, e.g.
+// because a public token or an item id was passed instead.
+func ValidateAccessToken(token string) error {
+	return validateToken(token, "access token", "access-")
+}
+
+// ValidatePublicToken reports an error if token is empty or not a public token.
+func ValidatePublicToken(token string) error {
+	return validateToken(token, "public token", "public-")
+}
+
+// ValidateLinkToken reports an error if token is empty or not a link token.
+func ValidateLinkToken(token string) error {
+	return validateToken(token, "link token", "link-")
+}
+
+func validateToken(token, name, prefix string) error {
+	if token == "" {
+		return errors.New(name + " must not be empty")
+	}
+	if !strings.HasPrefix(token, prefix) {
+		return errors.New(name + " " + maskToken(token) + " must start with " + prefix)
+	}
+	return nil
+}
+
+// WithTokenValidation checks the access, public and link tokens of every request before it
+// is sent, failing requests whose tokens don't start with the prefix of their kind, e.g. an
+// access token passed as a public token. Tokens issued before Plaid's current token format
+// don't have prefixes; don't use it with those.
+func WithTokenValidation() Option {
+	return WithRequestMutator(func(endpoint string, body map[string]interface{}) error {
+		for field, prefix := range tokenPrefixes {
+			// Empty tokens are left for Plaid to reject, with the error it returns for them.
+			token, ok := body[field].(string)
+			if !ok || token == "" {
+				continue
+			}
+			if err := validateToken(token, strings.ReplaceAll(field, "_", " "), prefix); err != nil {
+				return errors.New(endpoint + " - " + err.Error())
+			}
+		}
+		return nil
+	})
+}
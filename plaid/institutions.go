@@ -2,7 +2,9 @@ package plaid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -39,6 +41,125 @@ type institutionJson struct {
 	PublicKey     string `json:"public_key"`
 }
 
+// InstitutionGetByID (POST /institutions/get_by_id) returns information for a single
+// institution. countryCodes lists the countries to look for the institution in, e.g.
+// []string{"US"}.
+//
+// See https://plaid.com/docs/api/institutions/#institutionsget_by_id.
+func (c *Client) InstitutionGetByID(institutionID string, countryCodes []string,
+	options *InstitutionOptions) (*InstitutionJson, error) {
+	return c.InstitutionGetByIDContext(context.Background(), institutionID, countryCodes, options)
+}
+
+// InstitutionGetByIDContext is like InstitutionGetByID but carries a context.
+func (c *Client) InstitutionGetByIDContext(ctx context.Context, institutionID string, countryCodes []string,
+	options *InstitutionOptions) (*InstitutionJson, error) {
+
+	if institutionID == "" {
+		return nil, errors.New("/institutions/get_by_id - institution id must be specified")
+	}
+	if options != nil {
+		resolved := options.Profile.institutionOptions(*options)
+		options = &resolved
+	}
+	var res InstitutionJson
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/institutions/get_by_id", institutionGetByIDJson{
+		ClientID:      creds.clientID,
+		Secret:        creds.secret,
+		InstitutionID: institutionID,
+		CountryCodes:  countryCodes,
+		Options:       options,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// InstitutionOptions represents options associated with retrieving institutions.
+//
+// See https://plaid.com/docs/api/institutions/#institutionsget_by_id.
+type InstitutionOptions struct {
+	// IncludeOptionalMetadata adds the URL, primary color and logo of the institution.
+	IncludeOptionalMetadata bool `json:"include_optional_metadata,omitempty"`
+	// IncludeStatus adds the health of the institution's products.
+	IncludeStatus bool `json:"include_status,omitempty"`
+	// IncludeAuthMetadata adds the Auth flows the institution supports.
+	IncludeAuthMetadata bool `json:"include_auth_metadata,omitempty"`
+
+	// Profile, if set, overrides the Include flags above.
+	Profile PayloadProfile `json:"-"`
+}
+
+type institutionGetByIDJson struct {
+	ClientID      string              `json:"client_id"`
+	Secret        string              `json:"secret"`
+	InstitutionID string              `json:"institution_id"`
+	CountryCodes  []string            `json:"country_codes"`
+	Options       *InstitutionOptions `json:"options,omitempty"`
+}
+
+// InstitutionsGet (POST /institutions/get) returns a page of the institutions supported by
+// Plaid, along with the total number of institutions matching the options.
+//
+// See https://plaid.com/docs/api/institutions/#institutionsget.
+func (c *Client) InstitutionsGet(count, offset int, countryCodes []string,
+	options *InstitutionsGetOptions) ([]Institution, int, error) {
+	return c.InstitutionsGetContext(context.Background(), count, offset, countryCodes, options)
+}
+
+// InstitutionsGetContext is like InstitutionsGet but carries a context.
+func (c *Client) InstitutionsGetContext(ctx context.Context, count, offset int, countryCodes []string,
+	options *InstitutionsGetOptions) ([]Institution, int, error) {
+
+	if count < 1 || count > 500 {
+		return nil, 0, errors.New("/institutions/get - count must be between 1 and 500")
+	}
+	var res institutionsGetResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/institutions/get", institutionsGetJson{
+		ClientID:     creds.clientID,
+		Secret:       creds.secret,
+		Count:        count,
+		Offset:       offset,
+		CountryCodes: countryCodes,
+		Options:      options,
+	}, &res)
+	if err != nil {
+		return nil, 0, err
+	}
+	return res.Institutions, res.Total, nil
+}
+
+// InstitutionsGetOptions represents options associated with listing institutions.
+//
+// See https://plaid.com/docs/api/institutions/#institutions-get-request-options.
+type InstitutionsGetOptions struct {
+	// Products, RoutingNumbers and OAuth filter the institutions returned.
+	Products       []string `json:"products,omitempty"`
+	RoutingNumbers []string `json:"routing_numbers,omitempty"`
+	OAuth          *bool    `json:"oauth,omitempty"`
+
+	IncludeOptionalMetadata bool `json:"include_optional_metadata,omitempty"`
+	IncludeAuthMetadata     bool `json:"include_auth_metadata,omitempty"`
+}
+
+type institutionsGetJson struct {
+	ClientID     string                  `json:"client_id"`
+	Secret       string                  `json:"secret"`
+	Count        int                     `json:"count"`
+	Offset       int                     `json:"offset"`
+	CountryCodes []string                `json:"country_codes"`
+	Options      *InstitutionsGetOptions `json:"options,omitempty"`
+}
+
+type institutionsGetResponse struct {
+	Institutions []Institution `json:"institutions"`
+	Total        int           `json:"total"`
+	RequestID    string        `json:"request_id"`
+}
+
 // GetInstitution returns information for a single institution given an ID.
 // // See: https://plaid.com/docs/api/#institutions-by-id
 // func GetInstitution(environment environmentURL, id string) (inst institution, err error) {
@@ -85,8 +206,8 @@ type institutionJson struct {
 // 	}
 
 // 	jsonText, err := json.Marshal(institutionsJson{
-// 		ClientID: c.clientID,
-// 		Secret:   c.secret,
+// 		ClientID: c.clientID(),
+// 		Secret:   c.secret(),
 // 		Products: products,
 // 		Count:    count,
 // 		Offset:   offset,
@@ -182,5 +303,51 @@ type InstitutionJson struct {
 }
 
 type Institution struct {
-	Name string `json:"name"`
+	InstitutionID  string             `json:"institution_id"`
+	Name           string             `json:"name"`
+	Products       []string           `json:"products"`
+	CountryCodes   []string           `json:"country_codes"`
+	RoutingNumbers []string           `json:"routing_numbers"`
+	OAuth          bool               `json:"oauth"`
+	URL            string             `json:"url"`
+	PrimaryColor   string             `json:"primary_color"`
+	Logo           string             `json:"logo"` // base64 encoded PNG
+	Status         *InstitutionStatus `json:"status"`
+	AuthMetadata   *AuthMetadata      `json:"auth_metadata"`
+}
+
+// AuthMetadata describes the Auth flows an institution supports.
+//
+// See https://plaid.com/docs/api/institutions/#institutions-get_by_id-response-institution-auth-metadata.
+type AuthMetadata struct {
+	SupportedMethods *struct {
+		InstantAuth            bool `json:"instant_auth"`
+		InstantMatch           bool `json:"instant_match"`
+		AutomatedMicroDeposits bool `json:"automated_micro_deposits"`
+		InstantMicroDeposits   bool `json:"instant_micro_deposits"`
+	} `json:"supported_methods"`
+}
+
+// InstitutionStatus describes the health of an institution's products.
+//
+// See https://plaid.com/docs/api/institutions/#institutions-get_by_id-response-institution-status.
+type InstitutionStatus struct {
+	ItemLogins          *ProductStatus `json:"item_logins"`
+	TransactionsUpdates *ProductStatus `json:"transactions_updates"`
+	Auth                *ProductStatus `json:"auth"`
+	Identity            *ProductStatus `json:"identity"`
+	InvestmentsUpdates  *ProductStatus `json:"investments_updates"`
+	LiabilitiesUpdates  *ProductStatus `json:"liabilities_updates"`
+}
+
+// ProductStatus is the health of one product at an institution.
+type ProductStatus struct {
+	Status           string `json:"status"` // "HEALTHY", "DEGRADED" or "DOWN"
+	LastStatusChange string `json:"last_status_change"`
+	Breakdown        struct {
+		Success          float64 `json:"success"`
+		ErrorPlaid       float64 `json:"error_plaid"`
+		ErrorInstitution float64 `json:"error_institution"`
+		RefreshInterval  string  `json:"refresh_interval"`
+	} `json:"breakdown"`
 }
@@ -0,0 +1,457 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// InstitutionStore persists a mirror of Plaid's institution catalog, e.g. for rendering an
+// institution picker without calling Plaid.
+type InstitutionStore interface {
+	// Institutions returns the stored institutions with the given ids. Ids that aren't
+	// stored are left out of the result.
+	Institutions(ctx context.Context, ids []string) (map[string]Institution, error)
+	// PutInstitutions adds or replaces institutions.
+	PutInstitutions(ctx context.Context, institutions []Institution) error
+	// InstitutionIDs returns the ids of every stored institution.
+	InstitutionIDs(ctx context.Context) ([]string, error)
+	// DeleteInstitutions removes the institutions with the given ids. Ids that aren't
+	// stored are ignored.
+	DeleteInstitutions(ctx context.Context, ids []string) error
+}
+
+// MemoryInstitutionStore is an InstitutionStore that keeps institutions in memory. It
+// doesn't survive restarts and is meant for tests.
+type MemoryInstitutionStore struct {
+	mu           sync.Mutex
+	institutions map[string]Institution
+}
+
+// NewMemoryInstitutionStore instantiates an empty MemoryInstitutionStore.
+func NewMemoryInstitutionStore() *MemoryInstitutionStore {
+	return &MemoryInstitutionStore{institutions: map[string]Institution{}}
+}
+
+// Institutions implements InstitutionStore.
+func (s *MemoryInstitutionStore) Institutions(ctx context.Context, ids []string) (map[string]Institution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := map[string]Institution{}
+	for _, id := range ids {
+		if institution, ok := s.institutions[id]; ok {
+			result[id] = institution
+		}
+	}
+	return result, nil
+}
+
+// PutInstitutions implements InstitutionStore.
+func (s *MemoryInstitutionStore) PutInstitutions(ctx context.Context, institutions []Institution) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, institution := range institutions {
+		s.institutions[institution.InstitutionID] = institution
+	}
+	return nil
+}
+
+// InstitutionIDs implements InstitutionStore.
+func (s *MemoryInstitutionStore) InstitutionIDs(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.institutions))
+	for id := range s.institutions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// DeleteInstitutions implements InstitutionStore.
+func (s *MemoryInstitutionStore) DeleteInstitutions(ctx context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		delete(s.institutions, id)
+	}
+	return nil
+}
+
+// InstitutionChangeKind is the kind of an InstitutionChange.
+type InstitutionChangeKind int
+
+const (
+	InstitutionAdded InstitutionChangeKind = iota
+	InstitutionUpdated
+	InstitutionRemoved
+)
+
+// InstitutionChange is a difference between the catalog and the store found by an
+// InstitutionSyncer.
+type InstitutionChange struct {
+	Kind InstitutionChangeKind
+	// Institution is the institution as Plaid reports it now, or as it was stored for
+	// InstitutionRemoved.
+	Institution Institution
+	// Previous is the stored institution an update replaced.
+	Previous *Institution
+	// Fields names what an update changed, e.g. "products" or "status.item_logins".
+	// Changes to the success rates of the status breakdowns are ignored.
+	Fields []string
+}
+
+// InstitutionSyncReport summarizes one pass of an InstitutionSyncer.
+type InstitutionSyncReport struct {
+	Time    time.Time
+	Total   int
+	Added   int
+	Updated int
+	Removed int
+	// Partial is set if the catalog changed while it was paged through, so that removals
+	// couldn't be detected safely. They are detected by the next pass.
+	Partial bool
+}
+
+// InstitutionSyncConfig configures an InstitutionSyncer.
+type InstitutionSyncConfig struct {
+	Store InstitutionStore
+	// CountryCodes are the countries to mirror the institutions of. Defaults to US.
+	CountryCodes []string
+	// Options filters the institutions mirrored. Defaults to including optional and Auth
+	// metadata, which the status and logos are part of.
+	Options *InstitutionsGetOptions
+	// Interval is the time between passes. Defaults to 24 hours.
+	Interval time.Duration
+	// Backoff is the delay before retrying a failed pass, doubled after each further
+	// failure up to Interval. Defaults to 1 minute.
+	Backoff time.Duration
+
+	OnChange func(change InstitutionChange)
+	OnReport func(report InstitutionSyncReport)
+	OnError  func(err error)
+}
+
+// InstitutionSyncer mirrors Plaid's institution catalog into an InstitutionStore. Each pass
+// pages through /institutions/get, writes the page's new and changed institutions to the
+// store as it goes and reports every difference to OnChange, so that a pass interrupted by
+// an error or a restart has still made progress. Institutions no longer in the catalog are
+// deleted at the end of a pass.
+//
+// Only stored institutions within the syncer's scope, those of its CountryCodes that match
+// its Options' filters, are deleted, so that syncers of different countries or products can
+// share a store.
+//
+// An InstitutionSyncer passes once when started and then every Interval. It is either
+// driven by Run or, as a Component, by Start and Close.
+type InstitutionSyncer struct {
+	client    *Client
+	config    InstitutionSyncConfig
+	lifecycle lifecycle
+}
+
+// NewInstitutionSyncer instantiates an InstitutionSyncer that makes its requests through c.
+func NewInstitutionSyncer(c *Client, config InstitutionSyncConfig) *InstitutionSyncer {
+	if len(config.CountryCodes) == 0 {
+		config.CountryCodes = []string{"US"}
+	}
+	if config.Options == nil {
+		config.Options = &InstitutionsGetOptions{IncludeOptionalMetadata: true, IncludeAuthMetadata: true}
+	}
+	if config.Interval == 0 {
+		config.Interval = 24 * time.Hour
+	}
+	if config.Backoff == 0 {
+		config.Backoff = time.Minute
+	}
+	return &InstitutionSyncer{client: c, config: config}
+}
+
+// Run syncs until ctx is done and then returns ctx.Err().
+func (s *InstitutionSyncer) Run(ctx context.Context) error {
+	s.run(ctx, nil)
+	return ctx.Err()
+}
+
+// Start syncs in the background until ctx is done or Close is called.
+func (s *InstitutionSyncer) Start(ctx context.Context) error {
+	return s.lifecycle.start(func(stop <-chan struct{}) {
+		s.run(ctx, stop)
+	})
+}
+
+// Close stops an InstitutionSyncer started with Start and waits for an in-flight page to
+// finish.
+func (s *InstitutionSyncer) Close() error {
+	return s.lifecycle.close()
+}
+
+func (s *InstitutionSyncer) run(ctx context.Context, stop <-chan struct{}) {
+	backoff := s.config.Backoff
+	for {
+		wait := s.config.Interval
+		report, err := s.sync(ctx, stop)
+		switch {
+		case err != nil:
+			if s.config.OnError != nil && ctx.Err() == nil && !stopped(stop) {
+				s.config.OnError(err)
+			}
+			wait, backoff = backoff, backoff*2
+			if backoff > s.config.Interval {
+				backoff = s.config.Interval
+			}
+		default:
+			backoff = s.config.Backoff
+			if s.config.OnReport != nil {
+				s.config.OnReport(*report)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-s.client.clock.After(wait):
+		}
+	}
+}
+
+// Sync runs one pass over the catalog.
+func (s *InstitutionSyncer) Sync(ctx context.Context) (*InstitutionSyncReport, error) {
+	return s.sync(ctx, nil)
+}
+
+func (s *InstitutionSyncer) sync(ctx context.Context, stop <-chan struct{}) (*InstitutionSyncReport, error) {
+	const pageSize = 500
+	ctx, cancel := s.client.operation(ctx)
+	defer cancel()
+	report := &InstitutionSyncReport{Time: s.client.clock.Now()}
+	seen := map[string]bool{}
+	for offset := 0; ; offset += pageSize {
+		if stopped(stop) {
+			return nil, errors.New("institution sync stopped")
+		}
+		var page []Institution
+		var total int
+		err := s.client.call(ctx, "/institutions/get", func(ctx context.Context) (err error) {
+			page, total, err = s.client.InstitutionsGetContext(ctx, pageSize, offset, s.config.CountryCodes,
+				s.config.Options)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if offset == 0 {
+			report.Total = total
+		} else if total != report.Total {
+			report.Partial = true
+		}
+		if err = s.syncPage(ctx, page, seen, report); err != nil {
+			return nil, err
+		}
+		if len(page) < pageSize || offset+len(page) >= total {
+			break
+		}
+	}
+	if len(seen) != report.Total {
+		report.Partial = true
+	}
+	if report.Partial {
+		return report, nil
+	}
+	return report, s.removeUnseen(ctx, seen, report)
+}
+
+// syncPage stores the new and changed institutions of a page.
+func (s *InstitutionSyncer) syncPage(ctx context.Context, page []Institution, seen map[string]bool,
+	report *InstitutionSyncReport) error {
+
+	ids := make([]string, 0, len(page))
+	for _, institution := range page {
+		ids = append(ids, institution.InstitutionID)
+	}
+	stored, err := s.config.Store.Institutions(ctx, ids)
+	if err != nil {
+		return err
+	}
+	var changed []Institution
+	var changes []InstitutionChange
+	for _, institution := range page {
+		if seen[institution.InstitutionID] {
+			continue
+		}
+		seen[institution.InstitutionID] = true
+		previous, ok := stored[institution.InstitutionID]
+		if !ok {
+			changed = append(changed, institution)
+			changes = append(changes, InstitutionChange{Kind: InstitutionAdded, Institution: institution})
+			report.Added++
+			continue
+		}
+		if fields := institutionChanges(previous, institution); len(fields) > 0 {
+			changed = append(changed, institution)
+			changes = append(changes, InstitutionChange{Kind: InstitutionUpdated, Institution: institution,
+				Previous: &previous, Fields: fields})
+			report.Updated++
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err = s.config.Store.PutInstitutions(ctx, changed); err != nil {
+		return err
+	}
+	if s.config.OnChange != nil {
+		for _, change := range changes {
+			s.config.OnChange(change)
+		}
+	}
+	return nil
+}
+
+// removeUnseen deletes the stored institutions within the syncer's scope that a complete
+// pass didn't see.
+func (s *InstitutionSyncer) removeUnseen(ctx context.Context, seen map[string]bool,
+	report *InstitutionSyncReport) error {
+
+	ids, err := s.config.Store.InstitutionIDs(ctx)
+	if err != nil {
+		return err
+	}
+	var unseen []string
+	for _, id := range ids {
+		if !seen[id] {
+			unseen = append(unseen, id)
+		}
+	}
+	if len(unseen) == 0 {
+		return nil
+	}
+	stored, err := s.config.Store.Institutions(ctx, unseen)
+	if err != nil {
+		return err
+	}
+	var removed []string
+	for _, id := range unseen {
+		if institution, ok := stored[id]; ok && s.inScope(institution) {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	if err = s.config.Store.DeleteInstitutions(ctx, removed); err != nil {
+		return err
+	}
+	report.Removed = len(removed)
+	if s.config.OnChange != nil {
+		for _, id := range removed {
+			s.config.OnChange(InstitutionChange{Kind: InstitutionRemoved, Institution: stored[id]})
+		}
+	}
+	return nil
+}
+
+// inScope reports whether a stored institution matched the syncer's country codes and
+// filters when it was stored, so that the syncer would have seen it if it were still in the
+// catalog.
+func (s *InstitutionSyncer) inScope(institution Institution) bool {
+	if !sharesString(institution.CountryCodes, s.config.CountryCodes) {
+		return false
+	}
+	options := s.config.Options
+	for _, product := range options.Products {
+		if !sharesString(institution.Products, []string{product}) {
+			return false
+		}
+	}
+	if len(options.RoutingNumbers) > 0 && !sharesString(institution.RoutingNumbers, options.RoutingNumbers) {
+		return false
+	}
+	return options.OAuth == nil || institution.OAuth == *options.OAuth
+}
+
+// sharesString reports whether a and b have a string in common.
+func sharesString(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// institutionChanges names the fields that differ between two versions of an institution.
+func institutionChanges(previous, current Institution) []string {
+	var fields []string
+	if previous.Name != current.Name {
+		fields = append(fields, "name")
+	}
+	if !sameStrings(previous.Products, current.Products) {
+		fields = append(fields, "products")
+	}
+	if !sameStrings(previous.CountryCodes, current.CountryCodes) {
+		fields = append(fields, "country_codes")
+	}
+	if !sameStrings(previous.RoutingNumbers, current.RoutingNumbers) {
+		fields = append(fields, "routing_numbers")
+	}
+	if previous.OAuth != current.OAuth {
+		fields = append(fields, "oauth")
+	}
+	if previous.URL != current.URL || previous.PrimaryColor != current.PrimaryColor || previous.Logo != current.Logo {
+		fields = append(fields, "branding")
+	}
+	var before, after InstitutionStatus
+	if previous.Status != nil {
+		before = *previous.Status
+	}
+	if current.Status != nil {
+		after = *current.Status
+	}
+	for _, status := range []struct {
+		field         string
+		before, after *ProductStatus
+	}{
+		{"status.item_logins", before.ItemLogins, after.ItemLogins},
+		{"status.transactions_updates", before.TransactionsUpdates, after.TransactionsUpdates},
+		{"status.auth", before.Auth, after.Auth},
+		{"status.identity", before.Identity, after.Identity},
+		{"status.investments_updates", before.InvestmentsUpdates, after.InvestmentsUpdates},
+		{"status.liabilities_updates", before.LiabilitiesUpdates, after.LiabilitiesUpdates},
+	} {
+		if productStatus(status.before) != productStatus(status.after) {
+			fields = append(fields, status.field)
+		}
+	}
+	return fields
+}
+
+func productStatus(status *ProductStatus) string {
+	if status == nil {
+		return ""
+	}
+	return status.Status
+}
+
+// sameStrings reports whether a and b hold the same strings, regardless of order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s] == 0 {
+			return false
+		}
+		counts[s]--
+	}
+	return true
+}
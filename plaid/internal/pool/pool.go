@@ -0,0 +1,34 @@
+// Package pool runs the work of the bulk helpers of plaid and its subpackages, such as
+// RemoveItems, ExchangeAll and SweepWebhooks, on a bounded number of goroutines.
+package pool
+
+import "sync"
+
+// Each calls work for every index in [0, n) using up to concurrency goroutines, and returns
+// once every call returned. A concurrency below 1 is treated as 1. Callers collect results
+// by index, so that they come out in the order of the input whatever order the calls finish
+// in.
+func Each(n, concurrency int, work func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				work(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,32 @@
+package pool
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestEach(t *testing.T) {
+	var inFlight, maxInFlight int32
+	results := make([]int, 100)
+	Each(len(results), 4, func(i int) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		results[i] = i * i
+		atomic.AddInt32(&inFlight, -1)
+	})
+	for i, result := range results {
+		if result != i*i {
+			t.Fatalf("results[%d] = %d, want %d", i, result, i*i)
+		}
+	}
+	if maxInFlight > 4 {
+		t.Fatalf("%d calls in flight, want at most 4", maxInFlight)
+	}
+
+	Each(0, 4, func(i int) { t.Fatal("called work without input") })
+}
@@ -0,0 +1,87 @@
+package plaid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// ItemGet (POST /item/get) retrieves information about the item associated with an access token.
+//
+// See https://plaid.com/docs/api/items/#itemget.
+func (c *Client) ItemGet(accessToken string) (postRes *postResponse, err error) {
+	return c.ItemGetContext(context.Background(), accessToken)
+}
+
+// ItemGetContext is like ItemGet but carries a context.
+func (c *Client) ItemGetContext(ctx context.Context, accessToken string) (postRes *postResponse, err error) {
+	creds := c.creds.Load()
+	jsonText, err := json.Marshal(itemJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/item/get", bytes.NewReader(jsonText))
+	return postRes, err
+}
+
+// ItemWebhookUpdate (POST /item/webhook/update) updates the webhook URL of an item.
+//
+// See https://plaid.com/docs/api/items/#itemwebhookupdate.
+func (c *Client) ItemWebhookUpdate(accessToken, webhook string) (postRes *postResponse, err error) {
+	return c.ItemWebhookUpdateContext(context.Background(), accessToken, webhook)
+}
+
+// ItemWebhookUpdateContext is like ItemWebhookUpdate but carries a context.
+func (c *Client) ItemWebhookUpdateContext(ctx context.Context, accessToken, webhook string) (postRes *postResponse, err error) {
+	creds := c.creds.Load()
+	jsonText, err := json.Marshal(itemWebhookUpdateJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+		Webhook:     webhook,
+	})
+	if err != nil {
+		return nil, err
+	}
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/item/webhook/update", bytes.NewReader(jsonText))
+	return postRes, err
+}
+
+// ItemRemove (POST /item/remove) removes an item. The access token is invalidated.
+//
+// See https://plaid.com/docs/api/items/#itemremove.
+func (c *Client) ItemRemove(accessToken string) (postRes *postResponse, err error) {
+	return c.ItemRemoveContext(context.Background(), accessToken)
+}
+
+// ItemRemoveContext is like ItemRemove but carries a context.
+func (c *Client) ItemRemoveContext(ctx context.Context, accessToken string) (postRes *postResponse, err error) {
+	creds := c.creds.Load()
+	jsonText, err := json.Marshal(itemJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/item/remove", bytes.NewReader(jsonText))
+	return postRes, err
+}
+
+type itemJson struct {
+	ClientID    string `json:"client_id"`
+	Secret      string `json:"secret"`
+	AccessToken string `json:"access_token"`
+}
+
+type itemWebhookUpdateJson struct {
+	ClientID    string `json:"client_id"`
+	Secret      string `json:"secret"`
+	AccessToken string `json:"access_token"`
+	Webhook     string `json:"webhook"`
+}
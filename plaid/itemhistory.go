@@ -0,0 +1,266 @@
+package plaid
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/webhooks"
+)
+
+// ItemEventKind is the kind of an ItemEvent.
+type ItemEventKind string
+
+const (
+	ItemLinked         ItemEventKind = "linked"
+	ItemWebhookUpdated ItemEventKind = "webhook_updated"
+	// ItemErrorEntered is recorded when an item enters an error state or its error
+	// changes, e.g. to ITEM_LOGIN_REQUIRED.
+	ItemErrorEntered ItemEventKind = "error_entered"
+	// ItemErrorResolved is recorded when an item in an error state works again.
+	ItemErrorResolved ItemEventKind = "error_resolved"
+	ItemRemoved       ItemEventKind = "removed"
+)
+
+// ItemEvent is an entry of an item's history.
+type ItemEvent struct {
+	Time   time.Time     `json:"time"`
+	ItemID ItemID        `json:"item_id"`
+	Kind   ItemEventKind `json:"kind"`
+	// ErrorCode is the item's error code for ItemErrorEntered, and the code of the error
+	// that was resolved for ItemErrorResolved.
+	ErrorCode string `json:"error_code,omitempty"`
+	// Source is the endpoint of the request, e.g. "/item/remove", or the webhook, e.g.
+	// "ITEM/ERROR", the event was learned from.
+	Source    string `json:"source"`
+	RequestID string `json:"request_id,omitempty"`
+	// TokenFingerprint identifies the access token of the request, see TokenFingerprint.
+	TokenFingerprint string `json:"token_fingerprint,omitempty"`
+}
+
+// ItemEventStore is an append-only store of item events.
+type ItemEventStore interface {
+	AppendItemEvent(ctx context.Context, event ItemEvent) error
+	// ItemEvents returns the events of an item in the order they were appended.
+	ItemEvents(ctx context.Context, itemID ItemID) ([]ItemEvent, error)
+}
+
+// MemoryItemEventStore is an ItemEventStore that keeps events in memory. It doesn't
+// survive restarts and is meant for tests.
+type MemoryItemEventStore struct {
+	mu     sync.Mutex
+	events map[ItemID][]ItemEvent
+}
+
+// NewMemoryItemEventStore instantiates an empty MemoryItemEventStore.
+func NewMemoryItemEventStore() *MemoryItemEventStore {
+	return &MemoryItemEventStore{events: map[ItemID][]ItemEvent{}}
+}
+
+// AppendItemEvent implements ItemEventStore.
+func (s *MemoryItemEventStore) AppendItemEvent(ctx context.Context, event ItemEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[event.ItemID] = append(s.events[event.ItemID], event)
+	return nil
+}
+
+// ItemEvents implements ItemEventStore.
+func (s *MemoryItemEventStore) ItemEvents(ctx context.Context, itemID ItemID) ([]ItemEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ItemEvent(nil), s.events[itemID]...), nil
+}
+
+// ItemHistoryConfig configures an ItemHistory.
+type ItemHistoryConfig struct {
+	Store ItemEventStore
+	// Clock timestamps the events learned from webhooks. Defaults to the system clock.
+	Clock Clock
+	// OnError, if set, receives the errors of the store. Recording never fails the request
+	// or webhook an event was learned from.
+	OnError func(err error)
+}
+
+// ItemHistory records the lifecycle of items, giving support teams a timeline per
+// connection: when an item was linked, had its webhook updated, entered and left error
+// states, and was removed.
+//
+// An ItemHistory is a Hook: add it to a client with WithHook to record the events the
+// client's requests reveal, and pass the webhooks the application receives to
+// RecordWebhook. Requests that carry neither the item nor an access token the history saw
+// with it earlier can't be attributed and are skipped; record such events with Record.
+type ItemHistory struct {
+	config ItemHistoryConfig
+
+	mu     sync.Mutex
+	items  map[string]ItemID // token fingerprint -> item id
+	errors map[ItemID]string // item id -> error code, "" if healthy
+}
+
+// NewItemHistory instantiates an ItemHistory that appends to config.Store.
+func NewItemHistory(config ItemHistoryConfig) *ItemHistory {
+	if config.Clock == nil {
+		config.Clock = systemClock{}
+	}
+	return &ItemHistory{config: config, items: map[string]ItemID{}, errors: map[ItemID]string{}}
+}
+
+// Record appends an event to the store.
+func (h *ItemHistory) Record(ctx context.Context, event ItemEvent) error {
+	if event.Time.IsZero() {
+		event.Time = h.config.Clock.Now()
+	}
+	return h.config.Store.AppendItemEvent(ctx, event)
+}
+
+// History returns the timeline of an item, oldest event first.
+func (h *ItemHistory) History(ctx context.Context, itemID ItemID) ([]ItemEvent, error) {
+	return h.config.Store.ItemEvents(ctx, itemID)
+}
+
+// AfterRequest implements Hook.
+func (h *ItemHistory) AfterRequest(ctx context.Context, event RequestEvent) {
+	if event.StatusCode == 0 {
+		return
+	}
+	itemID := h.itemID(event.TokenFingerprint, event.ItemID)
+	if itemID == "" {
+		return
+	}
+	// The request's context may be done already, e.g. if it timed out.
+	ctx = context.WithoutCancel(ctx)
+	record := ItemEvent{
+		Time:             event.Start,
+		ItemID:           itemID,
+		Source:           event.Endpoint,
+		RequestID:        event.RequestID,
+		TokenFingerprint: event.TokenFingerprint,
+	}
+	switch {
+	case event.StatusCode == 200 && event.Endpoint == "/item/public_token/exchange":
+		record.Kind = ItemLinked
+		h.append(ctx, record)
+		h.setError(itemID, "")
+	case event.StatusCode == 200 && event.Endpoint == "/item/webhook/update":
+		record.Kind = ItemWebhookUpdated
+		h.append(ctx, record)
+	case event.StatusCode == 200 && event.Endpoint == "/item/remove":
+		record.Kind = ItemRemoved
+		h.append(ctx, record)
+		h.forget(event.TokenFingerprint, itemID)
+	case event.StatusCode == 200:
+		h.transition(ctx, record, event.ItemErrorCode)
+	case event.ErrorType == "ITEM_ERROR":
+		h.transition(ctx, record, event.ErrorCode)
+	}
+}
+
+// RecordWebhook records the events a webhook reveals: ITEM webhooks announcing errors,
+// repaired logins, revoked permissions and acknowledged webhook updates. Other webhooks
+// are ignored.
+func (h *ItemHistory) RecordWebhook(ctx context.Context, webhook interface{}) {
+	w, ok := webhook.(*webhooks.ItemWebhook)
+	if !ok || w.ItemID == "" {
+		return
+	}
+	record := ItemEvent{
+		Time:   h.config.Clock.Now(),
+		ItemID: w.ItemID,
+		Source: w.WebhookType + "/" + w.WebhookCode,
+	}
+	switch w.WebhookCode {
+	case "ERROR":
+		if w.Error != nil {
+			h.transition(ctx, record, w.Error.ErrorCode)
+		}
+	case "USER_PERMISSION_REVOKED", "USER_ACCOUNT_REVOKED":
+		h.transition(ctx, record, w.WebhookCode)
+	case "LOGIN_REPAIRED":
+		h.transition(ctx, record, "")
+	case "WEBHOOK_UPDATE_ACKNOWLEDGED":
+		record.Kind = ItemWebhookUpdated
+		h.append(ctx, record)
+	}
+}
+
+// itemID returns the item of a request and remembers it for the request's access token.
+func (h *ItemHistory) itemID(token string, itemID ItemID) ItemID {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if itemID == "" {
+		return h.items[token]
+	}
+	if token != "" {
+		h.items[token] = itemID
+	}
+	return itemID
+}
+
+func (h *ItemHistory) forget(token string, itemID ItemID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.items, token)
+	delete(h.errors, itemID)
+}
+
+// transition records an error state change of the item, if errorCode changes it.
+func (h *ItemHistory) transition(ctx context.Context, record ItemEvent, errorCode string) {
+	previous, known := h.lastError(ctx, record.ItemID)
+	if known && previous == errorCode || !known && errorCode == "" {
+		return
+	}
+	if errorCode == "" {
+		record.Kind, record.ErrorCode = ItemErrorResolved, previous
+	} else {
+		record.Kind, record.ErrorCode = ItemErrorEntered, errorCode
+	}
+	h.append(ctx, record)
+	h.setError(record.ItemID, errorCode)
+}
+
+// lastError returns the item's error code as of its last recorded event, loading the
+// history of items the ItemHistory didn't see yet, e.g. after a restart.
+func (h *ItemHistory) lastError(ctx context.Context, itemID ItemID) (string, bool) {
+	h.mu.Lock()
+	errorCode, ok := h.errors[itemID]
+	h.mu.Unlock()
+	if ok {
+		return errorCode, true
+	}
+	events, err := h.config.Store.ItemEvents(ctx, itemID)
+	if err != nil {
+		h.reportError(err)
+		return "", false
+	}
+	for _, event := range events {
+		switch event.Kind {
+		case ItemErrorEntered:
+			errorCode = event.ErrorCode
+		case ItemErrorResolved, ItemLinked:
+			errorCode = ""
+		}
+	}
+	h.mu.Lock()
+	h.errors[itemID] = errorCode
+	h.mu.Unlock()
+	return errorCode, true
+}
+
+func (h *ItemHistory) setError(itemID ItemID, errorCode string) {
+	h.mu.Lock()
+	h.errors[itemID] = errorCode
+	h.mu.Unlock()
+}
+
+func (h *ItemHistory) append(ctx context.Context, record ItemEvent) {
+	if err := h.config.Store.AppendItemEvent(ctx, record); err != nil {
+		h.reportError(err)
+	}
+}
+
+func (h *ItemHistory) reportError(err error) {
+	if h.config.OnError != nil {
+		h.config.OnError(err)
+	}
+}
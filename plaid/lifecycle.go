@@ -0,0 +1,69 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Component is implemented by the long-running components of this package, such as the
+// Refresher, so that services embedding them can start and stop them uniformly.
+type Component interface {
+	// Start begins the component's background work and returns immediately. The work
+	// stops when ctx is done or Close is called. A component can only be started once.
+	Start(ctx context.Context) error
+	// Close stops the component. It stops scheduling new work, waits for requests that
+	// are in flight to finish and then returns.
+	Close() error
+}
+
+// lifecycle implements the bookkeeping of Component for a single background goroutine.
+type lifecycle struct {
+	mu     sync.Mutex
+	stop   chan struct{}
+	done   chan struct{}
+	closed bool
+}
+
+// start runs fn in a new goroutine. fn must return soon after stop is closed.
+func (l *lifecycle) start(fn func(stop <-chan struct{})) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.stop != nil {
+		return errors.New("component already started")
+	}
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	go func() {
+		defer close(l.done)
+		fn(l.stop)
+	}()
+	return nil
+}
+
+// close signals the goroutine started by start to stop and waits for it to return.
+func (l *lifecycle) close() error {
+	l.mu.Lock()
+	if l.stop == nil {
+		l.mu.Unlock()
+		return nil
+	}
+	if !l.closed {
+		close(l.stop)
+		l.closed = true
+	}
+	done := l.done
+	l.mu.Unlock()
+	<-done
+	return nil
+}
+
+// stopped reports whether stop has been closed without blocking.
+func stopped(stop <-chan struct{}) bool {
+	select {
+	case <-stop:
+		return true
+	default:
+		return false
+	}
+}
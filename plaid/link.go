@@ -0,0 +1,266 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wearevest/plaidgo/plaid/internal/pool"
+)
+
+// LinkTokenCreate (POST /link/token/create) creates a link token to initialize Link with,
+// e.g. clientName "Vest", language "en", countryCodes []string{"US"} and products
+// []string{"transactions"}.
+//
+// See https://plaid.com/docs/api/tokens/#linktokencreate.
+func (c *Client) LinkTokenCreate(clientName, language string, countryCodes []string, user LinkUser,
+	products []string, options *LinkTokenCreateOptions) (*LinkTokenCreateResponse, error) {
+	return c.LinkTokenCreateContext(context.Background(), clientName, language, countryCodes, user,
+		products, options)
+}
+
+// LinkTokenCreateContext is like LinkTokenCreate but carries a context.
+func (c *Client) LinkTokenCreateContext(ctx context.Context, clientName, language string, countryCodes []string,
+	user LinkUser, products []string, options *LinkTokenCreateOptions) (*LinkTokenCreateResponse, error) {
+
+	if err := user.Validate(); err != nil {
+		return nil, errors.New("/link/token/create - " + err.Error())
+	}
+	creds := c.creds.Load()
+	request := linkTokenCreateJson{
+		ClientID:     creds.clientID,
+		Secret:       creds.secret,
+		ClientName:   clientName,
+		Language:     language,
+		CountryCodes: countryCodes,
+		User:         user,
+		Products:     products,
+	}
+	if options != nil {
+		request.Webhook = options.Webhook
+		request.RedirectURI = options.RedirectURI
+		request.AccessToken = options.AccessToken
+		request.LinkCustomizationName = options.LinkCustomizationName
+		request.EnableMultiItemLink = options.EnableMultiItemLink
+		if options.TransferIntentID != "" {
+			request.Transfer = &linkTokenTransferJson{IntentID: options.TransferIntentID}
+		}
+		request.RequiredIfSupportedProducts = options.RequiredIfSupportedProducts
+		request.OptionalProducts = options.OptionalProducts
+		request.AdditionalConsentedProducts = options.AdditionalConsentedProducts
+	}
+	if err := validateLinkProducts(products, options); err != nil {
+		return nil, errors.New("/link/token/create - " + err.Error())
+	}
+	if request.AccessToken != "" && request.EnableMultiItemLink {
+		return nil, errors.New("/link/token/create - multi-item link can't be used in update mode")
+	}
+	var res LinkTokenCreateResponse
+	if err := c.postAndDecode(ctx, "/link/token/create", request, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// LinkTokenGet (POST /link/token/get) retrieves a link token along with the Link sessions
+// started with it. With multi-item Link, the public tokens of all items the user linked are
+// only available from the sessions, see LinkToken.PublicTokens.
+//
+// See https://plaid.com/docs/api/tokens/#linktokenget.
+func (c *Client) LinkTokenGet(linkToken string) (*LinkToken, error) {
+	return c.LinkTokenGetContext(context.Background(), linkToken)
+}
+
+// LinkTokenGetContext is like LinkTokenGet but carries a context.
+func (c *Client) LinkTokenGetContext(ctx context.Context, linkToken string) (*LinkToken, error) {
+	var res LinkToken
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/link/token/get", linkTokenGetJson{
+		ClientID:  creds.clientID,
+		Secret:    creds.secret,
+		LinkToken: linkToken,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// LinkTokenCreateOptions represents the optional fields of a link token.
+type LinkTokenCreateOptions struct {
+	Webhook     string
+	RedirectURI string
+	// AccessToken initializes Link in update mode for an existing item.
+	AccessToken           string
+	LinkCustomizationName string
+	// EnableMultiItemLink lets the user link several items in one Link session. The public
+	// tokens are then retrieved with LinkTokenGet or from the SESSION_FINISHED webhook
+	// rather than from Link's onSuccess callback.
+	EnableMultiItemLink bool
+	// TransferIntentID initializes Link for the Transfer UI, see TransferIntentCreate.
+	TransferIntentID string
+
+	// RequiredIfSupportedProducts are initialized if the institution supports them, and
+	// otherwise don't prevent the user from linking it, unlike the products passed to
+	// LinkTokenCreate. OptionalProducts are initialized if the institution supports them
+	// and are only billed once used. AdditionalConsentedProducts are consented to by the
+	// user without being initialized, so they can be added later without going through Link
+	// again. A product may only appear in one of these lists and the required products.
+	//
+	// See https://plaid.com/docs/link/initializing-products/.
+	RequiredIfSupportedProducts []string
+	OptionalProducts            []string
+	AdditionalConsentedProducts []string
+}
+
+// LinkTokenCreateResponse is the response of LinkTokenCreate.
+type LinkTokenCreateResponse struct {
+	LinkToken  string `json:"link_token"`
+	Expiration string `json:"expiration"` // RFC 3339 timestamp
+	RequestID  string `json:"request_id"`
+}
+
+// LinkToken is a link token along with its Link sessions.
+//
+// See https://plaid.com/docs/api/tokens/#link-token-get-response.
+type LinkToken struct {
+	LinkToken    string        `json:"link_token"`
+	CreatedAt    string        `json:"created_at"`
+	Expiration   string        `json:"expiration"`
+	LinkSessions []LinkSession `json:"link_sessions"`
+	RequestID    string        `json:"request_id"`
+}
+
+// LinkSession is one session of a user in Link.
+type LinkSession struct {
+	LinkSessionID string `json:"link_session_id"`
+	StartedAt     string `json:"started_at"`
+	FinishedAt    string `json:"finished_at"`
+	Results       *struct {
+		ItemAddResults []LinkItemAddResult `json:"item_add_results"`
+	} `json:"results"`
+}
+
+// LinkItemAddResult is an item the user linked during a Link session.
+type LinkItemAddResult struct {
+	PublicToken string `json:"public_token"`
+	Accounts    []struct {
+		ID   string     `json:"id"`
+		Name string     `json:"name"`
+		Mask FlexString `json:"mask"`
+		Type string     `json:"type"`
+	} `json:"accounts"`
+	Institution struct {
+		Name          string `json:"name"`
+		InstitutionID string `json:"institution_id"`
+	} `json:"institution"`
+}
+
+// PublicTokens returns the public tokens of every item linked in the token's sessions.
+func (t *LinkToken) PublicTokens() []string {
+	var tokens []string
+	for _, session := range t.LinkSessions {
+		if session.Results == nil {
+			continue
+		}
+		for _, result := range session.Results.ItemAddResults {
+			tokens = append(tokens, result.PublicToken)
+		}
+	}
+	return tokens
+}
+
+// ExchangeResult is the outcome of exchanging one public token.
+type ExchangeResult struct {
+	PublicToken string
+	AccessToken string
+	ItemID      ItemID
+	Err         error
+}
+
+// ExchangeAll exchanges public tokens for access tokens using up to concurrency requests in
+// flight, and returns one result per token in the order of publicTokens. A failed exchange
+// doesn't stop the others; use ExchangeErr to combine the failures into one error.
+func (c *Client) ExchangeAll(publicTokens []string, concurrency int) []ExchangeResult {
+	return c.ExchangeAllContext(context.Background(), publicTokens, concurrency)
+}
+
+// ExchangeAllContext is like ExchangeAll but carries a context.
+func (c *Client) ExchangeAllContext(ctx context.Context, publicTokens []string, concurrency int) []ExchangeResult {
+	results := make([]ExchangeResult, len(publicTokens))
+	pool.Each(len(publicTokens), concurrency, func(i int) {
+		result := ExchangeResult{PublicToken: publicTokens[i]}
+		res, err := c.ItemPublicTokenExchangeContext(ctx, publicTokens[i])
+		if err != nil {
+			result.Err = err
+		} else {
+			result.AccessToken = res.AccessToken
+			result.ItemID = res.ItemID
+		}
+		results[i] = result
+	})
+	return results
+}
+
+type linkTokenCreateJson struct {
+	ClientID              string                 `json:"client_id"`
+	Secret                string                 `json:"secret"`
+	ClientName            string                 `json:"client_name"`
+	Language              string                 `json:"language"`
+	CountryCodes          []string               `json:"country_codes"`
+	User                  LinkUser               `json:"user"`
+	Products              []string               `json:"products,omitempty"`
+	Webhook               string                 `json:"webhook,omitempty"`
+	RedirectURI           string                 `json:"redirect_uri,omitempty"`
+	AccessToken           string                 `json:"access_token,omitempty"`
+	LinkCustomizationName string                 `json:"link_customization_name,omitempty"`
+	EnableMultiItemLink   bool                   `json:"enable_multi_item_link,omitempty"`
+	Transfer              *linkTokenTransferJson `json:"transfer,omitempty"`
+
+	RequiredIfSupportedProducts []string `json:"required_if_supported_products,omitempty"`
+	OptionalProducts            []string `json:"optional_products,omitempty"`
+	AdditionalConsentedProducts []string `json:"additional_consented_products,omitempty"`
+}
+
+// validateLinkProducts checks that no product is listed twice across the product lists of a
+// link token, which Plaid rejects or, for some combinations, silently resolves in a way
+// that makes fewer institutions eligible.
+func validateLinkProducts(products []string, options *LinkTokenCreateOptions) error {
+	type productList struct {
+		name     string
+		products []string
+	}
+	lists := []productList{{"products", products}}
+	if options != nil {
+		lists = append(lists,
+			productList{"required_if_supported_products", options.RequiredIfSupportedProducts},
+			productList{"optional_products", options.OptionalProducts},
+			productList{"additional_consented_products", options.AdditionalConsentedProducts})
+	}
+	listed := map[string]string{} // product -> name of the list it's in
+	for _, list := range lists {
+		for _, product := range list.products {
+			if previous, ok := listed[product]; ok {
+				if previous == list.name {
+					return errors.New("product " + product + " is listed twice in " + list.name)
+				}
+				return errors.New("product " + product + " is listed in both " + previous + " and " + list.name)
+			}
+			listed[product] = list.name
+		}
+	}
+	if len(products) == 0 && options != nil &&
+		(len(options.RequiredIfSupportedProducts) > 0 || len(options.OptionalProducts) > 0) {
+		return errors.New("required_if_supported_products and optional_products need at least one product in products")
+	}
+	return nil
+}
+
+type linkTokenTransferJson struct {
+	IntentID string `json:"intent_id"`
+}
+
+type linkTokenGetJson struct {
+	ClientID  string `json:"client_id"`
+	Secret    string `json:"secret"`
+	LinkToken string `json:"link_token"`
+}
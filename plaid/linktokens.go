@@ -0,0 +1,131 @@
+package plaid
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// ExpiresAt parses the expiration of the link token.
+func (r *LinkTokenCreateResponse) ExpiresAt() (time.Time, error) {
+	return time.Parse(time.RFC3339, r.Expiration)
+}
+
+// LinkTokenConfig is the configuration a LinkTokenCache creates link tokens with, see
+// LinkTokenCreate.
+type LinkTokenConfig struct {
+	ClientName   string                  `json:"client_name"`
+	Language     string                  `json:"language"`
+	CountryCodes []string                `json:"country_codes"`
+	Products     []string                `json:"products"`
+	Options      *LinkTokenCreateOptions `json:"options"`
+}
+
+// DefaultLinkTokenRefreshMargin is how long before its expiration a LinkTokenCache replaces
+// a link token unless configured otherwise.
+const DefaultLinkTokenRefreshMargin = 10 * time.Minute
+
+// LinkTokenCache hands out link tokens per user and configuration, creating a new one once
+// the cached token is within the refresh margin of its expiration, so that long-lived
+// onboarding sessions don't fail with INVALID_LINK_TOKEN. Link tokens expire after 4 hours,
+// or 30 minutes in update mode.
+//
+// Tokens are kept in memory. Forget a user's tokens once they completed Link.
+type LinkTokenCache struct {
+	client *Client
+	margin time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*linkTokenEntry // client user id + config -> entry
+}
+
+type linkTokenEntry struct {
+	userID string
+
+	mu        sync.Mutex // held while the token is created
+	res       *LinkTokenCreateResponse
+	expiresAt time.Time
+}
+
+// NewLinkTokenCache instantiates an empty LinkTokenCache that creates link tokens through c.
+// A margin of zero uses DefaultLinkTokenRefreshMargin.
+func NewLinkTokenCache(c *Client, margin time.Duration) *LinkTokenCache {
+	if margin == 0 {
+		margin = DefaultLinkTokenRefreshMargin
+	}
+	return &LinkTokenCache{client: c, margin: margin, entries: map[string]*linkTokenEntry{}}
+}
+
+// LinkToken returns a link token for user created with config, reusing the cached one while
+// it has more than the refresh margin left. Concurrent calls for the same user and config
+// share a single /link/token/create request.
+func (lc *LinkTokenCache) LinkToken(ctx context.Context, user LinkUser, config LinkTokenConfig) (*LinkTokenCreateResponse, error) {
+	key, err := linkTokenKey(user, config)
+	if err != nil {
+		return nil, err
+	}
+	lc.mu.Lock()
+	entry, ok := lc.entries[key]
+	if !ok {
+		entry = &linkTokenEntry{userID: user.ClientUserID}
+		lc.entries[key] = entry
+	}
+	lc.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	now := lc.client.clock.Now()
+	if entry.res != nil && entry.expiresAt.Sub(now) > lc.margin {
+		return entry.res, nil
+	}
+	res, err := lc.client.LinkTokenCreateContext(ctx, config.ClientName, config.Language,
+		config.CountryCodes, user, config.Products, config.Options)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := res.ExpiresAt()
+	if err != nil {
+		// Without an expiration the token can't be reused safely.
+		expiresAt = now
+	}
+	entry.res, entry.expiresAt = res, expiresAt
+	lc.prune(now)
+	return res, nil
+}
+
+// Forget drops the cached link tokens of a user, e.g. after the user completed Link or a
+// token was rejected as INVALID_LINK_TOKEN.
+func (lc *LinkTokenCache) Forget(clientUserID string) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	for key, entry := range lc.entries {
+		if entry.userID == clientUserID {
+			delete(lc.entries, key)
+		}
+	}
+}
+
+// prune drops the entries whose token has expired.
+func (lc *LinkTokenCache) prune(now time.Time) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	for key, entry := range lc.entries {
+		// Entries being created are locked; skip them rather than wait.
+		if !entry.mu.TryLock() {
+			continue
+		}
+		if entry.res != nil && !entry.expiresAt.After(now) {
+			delete(lc.entries, key)
+		}
+		entry.mu.Unlock()
+	}
+}
+
+func linkTokenKey(user LinkUser, config LinkTokenConfig) (string, error) {
+	encoded, err := json.Marshal(struct {
+		User   LinkUser        `json:"user"`
+		Config LinkTokenConfig `json:"config"`
+	}{user, config})
+	return string(encoded), err
+}
@@ -0,0 +1,6 @@
+package plaid
+
+import "github.com/wearevest/plaidgo/plaid/transactions"
+
+// Location is where a transaction took place, see transactions.Location.
+type Location = transactions.Location
@@ -0,0 +1,90 @@
+package plaid
+
+import (
+	"sort"
+	"strings"
+)
+
+// MerchantSummary aggregates the transactions of a single merchant.
+type MerchantSummary struct {
+	// Key identifies the merchant: its entity id if Plaid knows one, otherwise its
+	// normalized name.
+	Key       string
+	Name      string
+	EntityID  string
+	Count     int
+	Total     float64
+	FirstSeen string // date of the earliest transaction, YYYY-MM-DD
+	LastSeen  string // date of the latest transaction, YYYY-MM-DD
+}
+
+// SummarizeMerchants groups transactions by merchant and returns one summary per merchant,
+// ordered by descending total.
+//
+// Transactions are grouped by merchant_entity_id, falling back to the entity id of the
+// primary merchant counterparty and finally to the normalized merchant name (or the
+// transaction name if Plaid didn't identify a merchant).
+func SummarizeMerchants(transactions []Transaction) []MerchantSummary {
+	byKey := map[string]*MerchantSummary{}
+	var keys []string
+	for _, t := range transactions {
+		key, name, entityID := merchantKey(t)
+		if key == "" {
+			continue
+		}
+		summary, ok := byKey[key]
+		if !ok {
+			summary = &MerchantSummary{Key: key, Name: name, EntityID: entityID, FirstSeen: t.Date, LastSeen: t.Date}
+			byKey[key] = summary
+			keys = append(keys, key)
+		}
+		summary.Count++
+		summary.Total += t.Amount64()
+		if t.Date < summary.FirstSeen {
+			summary.FirstSeen = t.Date
+		}
+		if t.Date > summary.LastSeen {
+			summary.LastSeen = t.Date
+		}
+	}
+
+	summaries := make([]MerchantSummary, len(keys))
+	for i, key := range keys {
+		summaries[i] = *byKey[key]
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Total > summaries[j].Total
+	})
+	return summaries
+}
+
+// merchantKey returns the grouping key, display name and entity id of a transaction's
+// merchant.
+func merchantKey(t Transaction) (key, name, entityID string) {
+	name = t.MerchantName
+	entityID = t.MerchantEntityID
+	if counterparty, ok := t.PrimaryMerchant(); ok {
+		if name == "" {
+			name = counterparty.Name
+		}
+		if entityID == "" {
+			entityID = counterparty.EntityID
+		}
+	}
+	if name == "" {
+		name = t.Name
+	}
+	if entityID != "" {
+		return entityID, name, entityID
+	}
+	return NormalizeMerchantName(name), name, ""
+}
+
+// NormalizeMerchantName lower-cases a merchant name and collapses punctuation and
+// whitespace, so that e.g. "STARBUCKS  #1234" and "Starbucks #1234" group together.
+func NormalizeMerchantName(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9' || r > 127)
+	})
+	return strings.Join(fields, " ")
+}
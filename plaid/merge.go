@@ -0,0 +1,66 @@
+package plaid
+
+import (
+	"sort"
+	"strings"
+)
+
+// MergeTransactions combines the transactions of several accounts or items into a single
+// slice in the order UIs list them in. The order is fully determined by the transactions,
+// independent of the order of the sets or of the transactions within them:
+//
+//  1. newest date first;
+//  2. on the same date, pending transactions before posted ones;
+//  3. then by account id and transaction id, both ascending;
+//  4. transactions without a transaction id, which Plaid never sends but applications may
+//     build, finally by amount, name and merchant name, all ascending.
+//
+// A transaction id present in several sets, e.g. because fetched pages overlapped, is only
+// kept once, in the version of the last set containing it. The sets are not modified.
+func MergeTransactions(sets ...[]Transaction) []Transaction {
+	index := map[TransactionID]int{}
+	var merged []Transaction
+	for _, set := range sets {
+		for _, t := range set {
+			if i, ok := index[t.TransactionID]; ok && t.TransactionID != "" {
+				merged[i] = t
+				continue
+			}
+			index[t.TransactionID] = len(merged)
+			merged = append(merged, t)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return CompareTransactions(merged[i], merged[j]) < 0
+	})
+	return merged
+}
+
+// CompareTransactions orders two transactions the way MergeTransactions does. It returns a
+// negative number if a comes first, a positive number if b comes first and zero if they
+// compare equal, which only happens for transactions with the same account and transaction
+// id, or without a transaction id and equal in all the fields compared.
+func CompareTransactions(a, b Transaction) int {
+	switch {
+	case a.Date != b.Date:
+		return strings.Compare(b.Date, a.Date)
+	case a.Pending != b.Pending:
+		if a.Pending {
+			return -1
+		}
+		return 1
+	case a.AccountID != b.AccountID:
+		return strings.Compare(string(a.AccountID), string(b.AccountID))
+	case a.TransactionID != b.TransactionID || a.TransactionID != "":
+		return strings.Compare(string(a.TransactionID), string(b.TransactionID))
+	case a.Amount != b.Amount:
+		if a.Amount < b.Amount {
+			return -1
+		}
+		return 1
+	case a.Name != b.Name:
+		return strings.Compare(a.Name, b.Name)
+	default:
+		return strings.Compare(a.MerchantName, b.MerchantName)
+	}
+}
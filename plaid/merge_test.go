@@ -0,0 +1,64 @@
+package plaid
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestMergeTransactions(t *testing.T) {
+	checking := []Transaction{
+		{TransactionID: "c2", AccountID: "checking", Date: "2024-03-02", Amount: 5},
+		{TransactionID: "c1", AccountID: "checking", Date: "2024-03-01", Amount: 10},
+		{TransactionID: "c3", AccountID: "checking", Date: "2024-03-02", Pending: true},
+	}
+	savings := []Transaction{
+		{TransactionID: "s1", AccountID: "savings", Date: "2024-03-02"},
+		// An overlapping page returned c1 again, updated.
+		{TransactionID: "c1", AccountID: "checking", Date: "2024-03-01", Amount: 11},
+	}
+	// Transactions built by the application, without ids.
+	manual := []Transaction{
+		{AccountID: "cash", Date: "2024-03-01", Amount: 3, Name: "Coffee"},
+		{AccountID: "cash", Date: "2024-03-01", Amount: 3, Name: "Bagel"},
+		{AccountID: "cash", Date: "2024-03-01", Amount: 1, Name: "Tip"},
+	}
+	want := []string{
+		"c3",       // newest, pending
+		"c2", "s1", // newest, posted, by account
+		"cash 1 Tip",   // no ids: by amount
+		"cash 3 Bagel", // then by name
+		"cash 3 Coffee",
+		"c1 11", // the last version of c1
+	}
+	describe := func(merged []Transaction) []string {
+		var got []string
+		for _, tx := range merged {
+			switch {
+			case tx.TransactionID == "":
+				got = append(got, string(tx.AccountID)+" "+fmt.Sprint(tx.Amount)+" "+tx.Name)
+			case tx.TransactionID == "c1":
+				got = append(got, "c1 "+fmt.Sprint(tx.Amount))
+			default:
+				got = append(got, string(tx.TransactionID))
+			}
+		}
+		return got
+	}
+	if got := describe(MergeTransactions(checking, savings, manual)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("MergeTransactions = %v, want %v", got, want)
+	}
+
+	// The order doesn't depend on the order of the sets or within them.
+	reversed := func(set []Transaction) []Transaction {
+		r := make([]Transaction, len(set))
+		for i, tx := range set {
+			r[len(set)-1-i] = tx
+		}
+		return r
+	}
+	got := describe(MergeTransactions(reversed(manual), reversed(checking), savings))
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("MergeTransactions of reordered sets = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package plaid
+
+import (
+	"strconv"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// decodeMFA decodes the body of a 201 response into an mfaResponse. Every field of the
+// MFA type the body announces is required; a missing or mistyped one fails with a
+// *core.DecodeError carrying its path, e.g. "mfa[1].answers[0]". Unknown MFA types decode
+// to an mfaResponse without details.
+func decodeMFA(body []byte) (*mfaResponse, error) {
+	var inter mfaIntermediate
+	if err := core.Decode(body, &inter); err != nil {
+		return nil, err
+	}
+	mfaRes := &mfaResponse{Type: inter.Type, AccessToken: inter.AccessToken}
+	switch inter.Type {
+	case "device", "list", "questions", "selections":
+		if len(inter.MFA) == 0 || string(inter.MFA) == "null" {
+			return nil, core.MissingFieldError("mfa")
+		}
+	}
+
+	switch inter.Type {
+	case "device":
+		var device struct {
+			MFA struct {
+				Message *string `json:"message"`
+			} `json:"mfa"`
+		}
+		if err := core.Decode(body, &device); err != nil {
+			return nil, err
+		}
+		if device.MFA.Message == nil {
+			return nil, core.MissingFieldError("mfa.message")
+		}
+		mfaRes.Device.Message = *device.MFA.Message
+
+	case "list":
+		var list struct {
+			MFA []struct {
+				Mask *string `json:"mask"`
+				Type *string `json:"type"`
+			} `json:"mfa"`
+		}
+		if err := core.Decode(body, &list); err != nil {
+			return nil, err
+		}
+		for i, v := range list.MFA {
+			path := "mfa[" + strconv.Itoa(i) + "]"
+			if v.Mask == nil {
+				return nil, core.MissingFieldError(path + ".mask")
+			}
+			if v.Type == nil {
+				return nil, core.MissingFieldError(path + ".type")
+			}
+			mfaRes.List = append(mfaRes.List, mfaList{Mask: *v.Mask, Type: *v.Type})
+		}
+
+	case "questions":
+		var questions struct {
+			MFA []struct {
+				Question *string `json:"question"`
+			} `json:"mfa"`
+		}
+		if err := core.Decode(body, &questions); err != nil {
+			return nil, err
+		}
+		for i, v := range questions.MFA {
+			if v.Question == nil {
+				return nil, core.MissingFieldError("mfa[" + strconv.Itoa(i) + "].question")
+			}
+			mfaRes.Questions = append(mfaRes.Questions, mfaQuestion{Question: *v.Question})
+		}
+
+	case "selections":
+		var selections struct {
+			MFA []struct {
+				Answers  []*string `json:"answers"`
+				Question *string   `json:"question"`
+			} `json:"mfa"`
+		}
+		if err := core.Decode(body, &selections); err != nil {
+			return nil, err
+		}
+		for i, v := range selections.MFA {
+			path := "mfa[" + strconv.Itoa(i) + "]"
+			if v.Answers == nil {
+				return nil, core.MissingFieldError(path + ".answers")
+			}
+			answers := make([]string, len(v.Answers))
+			for j, answer := range v.Answers {
+				if answer == nil {
+					return nil, core.MissingFieldError(path + ".answers[" + strconv.Itoa(j) + "]")
+				}
+				answers[j] = *answer
+			}
+			if v.Question == nil {
+				return nil, core.MissingFieldError(path + ".question")
+			}
+			mfaRes.Selections = append(mfaRes.Selections, mfaSelection{Answers: answers, Question: *v.Question})
+		}
+	}
+	return mfaRes, nil
+}
@@ -0,0 +1,73 @@
+package plaid
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// FuzzUnmarshalPostMFA checks that no response body makes the legacy decoder panic, that it
+// returns exactly one of a response, an MFA challenge and an error, and that bodies it
+// can't decode fail with a *core.DecodeError. The seed corpus in testdata/fuzz holds odd
+// bodies seen from Plaid.
+func FuzzUnmarshalPostMFA(f *testing.F) {
+	f.Add(200, []byte(`{"access_token": "access-sandbox-1", "accounts": [], "request_id": "a"}`))
+	f.Add(201, []byte(`{"type": "questions", "mfa": [{"question": "What is your favorite color?"}]}`))
+	f.Add(400, []byte(`{"error_type": "ITEM_ERROR", "error_code": "ITEM_LOGIN_REQUIRED"}`))
+	f.Fuzz(func(t *testing.T, statusCode int, body []byte) {
+		postRes, mfaRes, err := unmarshalPostMFA(&http.Response{StatusCode: statusCode}, body)
+		outcomes := 0
+		for _, set := range []bool{postRes != nil, mfaRes != nil, err != nil} {
+			if set {
+				outcomes++
+			}
+		}
+		if outcomes != 1 {
+			t.Fatalf("got response %v, MFA %v and error %v, want exactly one", postRes, mfaRes, err)
+		}
+		var decodeErr *core.DecodeError
+		if err != nil && (statusCode == 200 || statusCode == 201) && !errors.As(err, &decodeErr) {
+			t.Fatalf("status %d: got %T %v, want a *core.DecodeError", statusCode, err, err)
+		}
+	})
+}
+
+func TestDecodeMFA(t *testing.T) {
+	tests := []struct {
+		name, body string
+		wantPath   string // of the *core.DecodeError, if an error is expected
+		wantErr    bool
+	}{
+		{name: "device", body: `{"type": "device", "mfa": {"message": "Code sent to t..t@plaid.com"}}`},
+		{name: "list", body: `{"type": "list", "mfa": [{"mask": "t..t@plaid.com", "type": "email"}]}`},
+		{name: "selections", body: `{"type": "selections", "mfa": [{"question": "Color?", "answers": ["red", "blue"]}]}`},
+		{name: "unknown type", body: `{"type": "carrier_pigeon", "mfa": 7}`},
+		{name: "missing mfa", body: `{"type": "questions"}`, wantErr: true, wantPath: "mfa"},
+		{name: "null mfa", body: `{"type": "device", "mfa": null}`, wantErr: true, wantPath: "mfa"},
+		{name: "missing message", body: `{"type": "device", "mfa": {}}`, wantErr: true, wantPath: "mfa.message"},
+		{name: "missing mask", body: `{"type": "list", "mfa": [{"mask": "x", "type": "email"}, {"type": "phone"}]}`,
+			wantErr: true, wantPath: "mfa[1].mask"},
+		{name: "mistyped answer", body: `{"type": "selections", "mfa": [{"question": "Color?", "answers": ["red", 7]}]}`,
+			wantErr: true, wantPath: "mfa[0].answers[1]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeMFA([]byte(tt.body))
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("got error %v", err)
+				}
+				return
+			}
+			var decodeErr *core.DecodeError
+			if !errors.As(err, &decodeErr) {
+				t.Fatalf("got %v, want a *core.DecodeError", err)
+			}
+			if decodeErr.Path != tt.wantPath {
+				t.Errorf("got path %q, want %q", decodeErr.Path, tt.wantPath)
+			}
+		})
+	}
+}
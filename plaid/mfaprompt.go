@@ -0,0 +1,89 @@
+package plaid
+
+import (
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// MFAPrompt is how to present an MFA challenge to the user, with the client's own strings
+// localized and the institution's strings, such as its questions, passed through as is.
+type MFAPrompt struct {
+	// Type is the MFA type, "device", "list", "questions" or "selections".
+	Type         string
+	Title        string
+	Instructions string
+	// Detail is the institution's message for a device challenge, e.g. where the code was
+	// sent.
+	Detail string
+	// Options are the ways the user can receive a code, for a list challenge.
+	Options []MFAOption
+	// Questions are the institution's questions, for a questions or selections
+	// challenge.
+	Questions []MFAPromptQuestion
+}
+
+// MFAOption is a way to receive an MFA code.
+type MFAOption struct {
+	// Label describes the option to the user, e.g. "Text message to xxx-xxx-1234".
+	Label string
+	// Mask and Type are the option as Plaid sent it, to answer the challenge with.
+	Mask string
+	Type string
+}
+
+// MFAPromptQuestion is a question of an MFA challenge.
+type MFAPromptQuestion struct {
+	Question string
+	// Answers are the choices of a selections challenge.
+	Answers []string
+}
+
+// mfaListLabels are the English labels of the send methods of a list challenge.
+var mfaListLabels = map[string]string{
+	"phone": "Text message to {mask}",
+	"email": "Email to {mask}",
+}
+
+// Prompt returns how to present the challenge to the user, with the client's strings
+// translated by l under keys starting with "mfa.". A nil l returns English.
+func (r *mfaResponse) Prompt(l Localizer) MFAPrompt {
+	prompt := MFAPrompt{
+		Type:  r.Type,
+		Title: core.Localize(l, "mfa.title", "Verify it's you"),
+	}
+	switch r.Type {
+	case "device":
+		prompt.Instructions = core.Localize(l, "mfa.device.instructions", "Enter the security code sent to you.")
+		prompt.Detail = r.Device.Message
+	case "list":
+		prompt.Instructions = core.Localize(l, "mfa.list.instructions", "Choose where to send your security code.")
+		for _, option := range r.List {
+			key, label := "mfa.list."+option.Type, mfaListLabels[option.Type]
+			if label == "" {
+				key, label = "mfa.list.other", "Send to {mask}"
+			}
+			prompt.Options = append(prompt.Options, MFAOption{
+				Label: core.Localize(l, key, label, "mask", option.Mask),
+				Mask:  option.Mask,
+				Type:  option.Type,
+			})
+		}
+	case "questions":
+		prompt.Instructions = core.Localize(l, "mfa.questions.instructions", "Answer your bank's security questions.")
+		for _, question := range r.Questions {
+			prompt.Questions = append(prompt.Questions, MFAPromptQuestion{Question: question.Question})
+		}
+	case "selections":
+		prompt.Instructions = core.Localize(l, "mfa.selections.instructions",
+			"Choose the answer to each of your bank's security questions.")
+		for _, selection := range r.Selections {
+			prompt.Questions = append(prompt.Questions, MFAPromptQuestion{
+				Question: selection.Question,
+				Answers:  selection.Answers,
+			})
+		}
+	default:
+		prompt.Instructions = core.Localize(l, "mfa.other.instructions",
+			"Your bank needs to verify it's you. Please sign in again.")
+	}
+	return prompt
+}
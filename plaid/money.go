@@ -0,0 +1,192 @@
+package plaid
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MoneyMarshaler encodes amounts for a system downstream of the client, e.g. one that
+// stores integer cents or requires decimal strings to avoid floating point rounding.
+type MoneyMarshaler interface {
+	// MarshalMoney returns the JSON encoding of amount in currency. currency is an ISO 4217
+	// or unofficial currency code, or empty if unknown.
+	MarshalMoney(amount float64, currency string) ([]byte, error)
+}
+
+// MoneyMarshalerFunc adapts a function to a MoneyMarshaler.
+type MoneyMarshalerFunc func(amount float64, currency string) ([]byte, error)
+
+// MarshalMoney implements MoneyMarshaler.
+func (f MoneyMarshalerFunc) MarshalMoney(amount float64, currency string) ([]byte, error) {
+	return f(amount, currency)
+}
+
+var (
+	// MoneyAsFloat encodes amounts as JSON numbers, e.g. 12.34, like Plaid does. It is the
+	// default.
+	MoneyAsFloat MoneyMarshaler = MoneyMarshalerFunc(func(amount float64, currency string) ([]byte, error) {
+		return json.Marshal(amount)
+	})
+	// MoneyAsString encodes amounts as strings with the number of decimals of their
+	// currency, e.g. "12.34" or "1234" for yen.
+	MoneyAsString MoneyMarshaler = MoneyMarshalerFunc(func(amount float64, currency string) ([]byte, error) {
+		decimals := CurrencyDecimals(currency)
+		units := minorUnits(amount, decimals)
+		return json.Marshal(strconv.FormatFloat(float64(units)/math.Pow10(decimals), 'f', decimals, 64))
+	})
+	// MoneyAsCents encodes amounts as integers in the minor unit of their currency, e.g.
+	// 1234 for 12.34 dollars or 1234 for 1234 yen.
+	MoneyAsCents MoneyMarshaler = MoneyMarshalerFunc(func(amount float64, currency string) ([]byte, error) {
+		return strconv.AppendInt(nil, minorUnits(amount, CurrencyDecimals(currency)), 10), nil
+	})
+)
+
+// minorUnits rounds amount to the nearest minor unit, half away from zero, so that
+// MoneyAsString and MoneyAsCents agree.
+func minorUnits(amount float64, decimals int) int64 {
+	return int64(math.Round(amount * math.Pow10(decimals)))
+}
+
+// currencyDecimals holds the currencies whose minor unit isn't a hundredth.
+var currencyDecimals = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyDecimals returns the number of decimals of a currency's minor unit: 2 for most
+// currencies and for unknown or empty currency codes.
+func CurrencyDecimals(currency string) int {
+	if decimals, ok := currencyDecimals[strings.ToUpper(currency)]; ok {
+		return decimals
+	}
+	return 2
+}
+
+// WithMoneyMarshaler sets how Client.EncodeJSON encodes amounts. It defaults to
+// MoneyAsFloat. Pass the client's MoneyMarshaler to the fdx package as well to encode its
+// structures the same way.
+func WithMoneyMarshaler(money MoneyMarshaler) Option {
+	return func(c *Client) {
+		c.money = money
+	}
+}
+
+// MoneyMarshaler returns the client's MoneyMarshaler, see WithMoneyMarshaler.
+func (c *Client) MoneyMarshaler() MoneyMarshaler {
+	if c.money == nil {
+		return MoneyAsFloat
+	}
+	return c.money
+}
+
+// MoneyFields names the JSON fields that MarshalMoneyJSON encodes with a MoneyMarshaler.
+type MoneyFields struct {
+	// Amounts are the names of fields holding amounts. Only number values are encoded;
+	// amounts Plaid sends as strings are left alone.
+	Amounts []string
+	// Currencies are the names of fields holding the currency of the amounts of the same
+	// object, in order of preference. A name may refer to a field of an object field, e.g.
+	// "currency.currencyCode". Objects without a currency use the one of the object they
+	// are part of.
+	Currencies []string
+}
+
+// PlaidMoneyFields are the money fields of Plaid's responses: transaction, transfer and
+// balance amounts, with the currency codes next to them.
+var PlaidMoneyFields = MoneyFields{
+	Amounts:    []string{"amount", "available", "current", "limit"},
+	Currencies: []string{"iso_currency_code", "unofficial_currency_code"},
+}
+
+// EncodeJSON encodes v, usually a response or a value taken from one, as JSON with its
+// amounts encoded by the client's MoneyMarshaler, for handing responses on to downstream
+// systems. Object keys are sorted in the result.
+func (c *Client) EncodeJSON(v interface{}) ([]byte, error) {
+	return MarshalMoneyJSON(v, c.MoneyMarshaler(), PlaidMoneyFields)
+}
+
+// MarshalMoneyJSON encodes v as JSON with the fields named by fields encoded by money.
+// Object keys are sorted in the result.
+func MarshalMoneyJSON(v interface{}, money MoneyMarshaler, fields MoneyFields) ([]byte, error) {
+	jsonText, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonText))
+	decoder.UseNumber()
+	var value interface{}
+	if err = decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	amounts := make(map[string]bool, len(fields.Amounts))
+	for _, name := range fields.Amounts {
+		amounts[name] = true
+	}
+	value, err = marshalMoney(value, "", money, amounts, fields.Currencies)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// marshalMoney replaces the amounts in value by their encoding, for amounts in currency
+// unless value names a currency of its own.
+func marshalMoney(value interface{}, currency string, money MoneyMarshaler, amounts map[string]bool,
+	currencies []string) (interface{}, error) {
+
+	switch value := value.(type) {
+	case []interface{}:
+		for i, element := range value {
+			var err error
+			if value[i], err = marshalMoney(element, currency, money, amounts, currencies); err != nil {
+				return nil, err
+			}
+		}
+	case map[string]interface{}:
+		if own := objectCurrency(value, currencies); own != "" {
+			currency = own
+		}
+		for key, field := range value {
+			if number, ok := field.(json.Number); ok && amounts[key] {
+				amount, err := number.Float64()
+				if err != nil {
+					return nil, err
+				}
+				encoded, err := money.MarshalMoney(amount, currency)
+				if err != nil {
+					return nil, err
+				}
+				value[key] = json.RawMessage(encoded)
+				continue
+			}
+			var err error
+			if value[key], err = marshalMoney(field, currency, money, amounts, currencies); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return value, nil
+}
+
+// objectCurrency returns the first non-empty currency of object, or an empty string.
+func objectCurrency(object map[string]interface{}, currencies []string) string {
+	for _, path := range currencies {
+		var field interface{} = object
+		for _, name := range strings.Split(path, ".") {
+			parent, ok := field.(map[string]interface{})
+			if !ok {
+				field = nil
+				break
+			}
+			field = parent[name]
+		}
+		if code, ok := field.(string); ok && code != "" {
+			return code
+		}
+	}
+	return ""
+}
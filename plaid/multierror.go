@@ -0,0 +1,160 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wearevest/plaidgo/plaid/internal/pool"
+)
+
+// MultiError is returned by bulk helpers when some of the items they process fail. It
+// keeps the error of every failed item; errors.Is and errors.As match any of them.
+type MultiError struct {
+	// Total is the number of items processed, including those that succeeded.
+	Total  int
+	Errors []ItemError
+}
+
+// ItemError is the error of one item of a bulk operation.
+type ItemError struct {
+	// Key identifies the item, e.g. by item id. Access tokens are masked.
+	Key string
+	Err error
+}
+
+func (e *ItemError) Error() string {
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// multiErrorSummaryLimit is the number of item errors spelled out by MultiError.Error.
+const multiErrorSummaryLimit = 3
+
+func (e *MultiError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d of %d failed: ", len(e.Errors), e.Total)
+	for i, itemErr := range e.Errors {
+		if i == multiErrorSummaryLimit {
+			fmt.Fprintf(&b, "; and %d more", len(e.Errors)-i)
+			break
+		}
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(itemErr.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the item errors.
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i := range e.Errors {
+		errs[i] = &e.Errors[i]
+	}
+	return errs
+}
+
+// Is reports whether any item error matches target.
+func (e *MultiError) Is(target error) bool {
+	for _, itemErr := range e.Errors {
+		if errors.Is(itemErr.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first item error that matches target.
+func (e *MultiError) As(target interface{}) bool {
+	for _, itemErr := range e.Errors {
+		if errors.As(itemErr.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// multiErrorOf returns a *MultiError for the failed items, or nil if there are none.
+func multiErrorOf(total int, errs []ItemError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &MultiError{Total: total, Errors: errs}
+}
+
+// maskToken shortens a token for use in errors, keeping its environment prefix and its
+// last four characters, e.g. "access-sandbox-...f3a9".
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return "..."
+	}
+	prefix := ""
+	// Tokens look like access-sandbox-<uuid>; keep everything before the uuid.
+	if parts := strings.SplitN(token, "-", 3); len(parts) == 3 {
+		prefix = parts[0] + "-" + parts[1] + "-"
+	}
+	return prefix + "..." + token[len(token)-4:]
+}
+
+// ExchangeErr returns a *MultiError for the failed exchanges of ExchangeAll, or nil if all
+// succeeded.
+func ExchangeErr(results []ExchangeResult) error {
+	var errs []ItemError
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, ItemError{Key: maskToken(result.PublicToken), Err: result.Err})
+		}
+	}
+	return multiErrorOf(len(results), errs)
+}
+
+// SweepErr returns a *MultiError for the failed items of SweepWebhooks, or nil if all
+// succeeded.
+func SweepErr(results []WebhookSweepResult) error {
+	var errs []ItemError
+	for _, result := range results {
+		if result.Err == nil {
+			continue
+		}
+		key := string(result.ItemID)
+		if key == "" {
+			key = maskToken(result.AccessToken)
+		}
+		errs = append(errs, ItemError{Key: key, Err: result.Err})
+	}
+	return multiErrorOf(len(results), errs)
+}
+
+// RemoveItems removes the items of accessTokens using up to concurrency requests in flight.
+// Failures don't stop the removal of the other items; they are returned as a *MultiError, in
+// the order of accessTokens.
+func (c *Client) RemoveItems(accessTokens []string, concurrency int) error {
+	return c.RemoveItemsContext(context.Background(), accessTokens, concurrency)
+}
+
+// RemoveItemsContext is like RemoveItems but carries a context.
+func (c *Client) RemoveItemsContext(ctx context.Context, accessTokens []string, concurrency int) error {
+	errs := make([]error, len(accessTokens))
+	pool.Each(len(accessTokens), concurrency, func(i int) {
+		_, errs[i] = c.ItemRemoveContext(ctx, accessTokens[i])
+	})
+	return tokenErrors(accessTokens, errs)
+}
+
+// tokenErrors returns a *MultiError for the access tokens whose error is not nil, in the
+// order of accessTokens, or nil if there are none.
+func tokenErrors(accessTokens []string, errs []error) error {
+	var itemErrs []ItemError
+	for i, err := range errs {
+		if err != nil {
+			itemErrs = append(itemErrs, ItemError{Key: maskToken(accessTokens[i]), Err: err})
+		}
+	}
+	return multiErrorOf(len(accessTokens), itemErrs)
+}
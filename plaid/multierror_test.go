@@ -0,0 +1,43 @@
+package plaid
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRemoveItemsErrorOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			AccessToken string `json:"access_token"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		// Later tokens fail first.
+		n, _ := strconv.Atoi(req.AccessToken[strings.LastIndex(req.AccessToken, "-")+1:])
+		time.Sleep(time.Duration(10-n) * 5 * time.Millisecond)
+		w.WriteHeader(400)
+		w.Write([]byte(`{"error_type": "ITEM_ERROR", "error_code": "ITEM_NOT_FOUND"}`))
+	}))
+	defer server.Close()
+	c := NewClient("id", "secret", Sandbox, WithBaseURL(server.URL))
+
+	var tokens []string
+	for i := 0; i < 10; i++ {
+		tokens = append(tokens, "access-sandbox-000"+strconv.Itoa(i))
+	}
+	err := c.RemoveItems(tokens, 10)
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) || len(multiErr.Errors) != len(tokens) {
+		t.Fatalf("RemoveItems = %v, want every item to fail", err)
+	}
+	for i, itemErr := range multiErr.Errors {
+		if want := maskToken(tokens[i]); itemErr.Key != want {
+			t.Fatalf("Errors[%d] is for %s, want %s: errors must follow the order of the tokens", i, itemErr.Key, want)
+		}
+	}
+}
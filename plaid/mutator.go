@@ -0,0 +1,54 @@
+package plaid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// RequestMutator modifies the JSON body of a request to endpoint before it is sent, e.g. to
+// add beta fields Plaid enabled for the account that the request structs don't model yet:
+//
+//	plaid.WithRequestMutator(func(endpoint string, body map[string]interface{}) error {
+//		if endpoint == "/link/token/create" {
+//			body["beta_feature"] = map[string]interface{}{"enabled": true}
+//		}
+//		return nil
+//	})
+//
+// Numbers in body are json.Number values. An error aborts the request.
+type RequestMutator func(endpoint string, body map[string]interface{}) error
+
+// WithRequestMutator adds a mutator that the client applies to every request body. Several
+// mutators are applied in the order they were added.
+func WithRequestMutator(mutator RequestMutator) Option {
+	return func(c *Client) {
+		// Copy rather than append in place, so that clients derived with With don't share
+		// mutators added later.
+		mutators := make([]RequestMutator, len(c.mutators), len(c.mutators)+1)
+		copy(mutators, c.mutators)
+		c.mutators = append(mutators, mutator)
+	}
+}
+
+// mutate applies the client's mutators to a JSON request body.
+func (c *Client) mutate(endpoint string, jsonText []byte) ([]byte, error) {
+	if len(c.mutators) == 0 {
+		return jsonText, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(jsonText))
+	decoder.UseNumber()
+	var body map[string]interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, errors.New(endpoint + " - request body is not a JSON object")
+	}
+	for _, mutator := range c.mutators {
+		if err := mutator(endpoint, body); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(body)
+}
@@ -0,0 +1,101 @@
+package plaid
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RollbackTimeout bounds removing the item again after onboarding failed.
+const RollbackTimeout = 30 * time.Second
+
+// OnboardOptions represents options associated with onboarding an item.
+type OnboardOptions struct {
+	// Webhook, if set, is registered on the item once the token has been exchanged.
+	Webhook string
+}
+
+// OnboardResult holds everything gathered while onboarding an item.
+type OnboardResult struct {
+	AccessToken string
+	ItemID      ItemID
+	Item        Item
+	Accounts    []Account
+}
+
+// RollbackError is returned by Onboard when a step after the token exchange failed and
+// the item could not be removed afterwards. The item identified by AccessToken is still
+// live and should be removed by the caller.
+type RollbackError struct {
+	AccessToken string
+	Err         error // error of the failed onboarding step
+	RollbackErr error // error returned while removing the item
+}
+
+func (e *RollbackError) Error() string {
+	return fmt.Sprintf("onboarding failed: %v; removing item also failed: %v", e.Err, e.RollbackErr)
+}
+
+func (e *RollbackError) Unwrap() error {
+	return e.Err
+}
+
+// Onboard exchanges a public token for an access token, fetches the resulting item and its
+// accounts, and optionally sets the item's webhook.
+//
+// If any step after the exchange fails the item is removed again, so callers never end up
+// with a half onboarded item, and the error of the failed step is returned. If removing the
+// item fails as well, or takes longer than RollbackTimeout, a *RollbackError is returned.
+func (c *Client) Onboard(publicToken string, options *OnboardOptions) (*OnboardResult, error) {
+	return c.OnboardContext(context.Background(), publicToken, options)
+}
+
+// OnboardContext is like Onboard but carries a context.
+func (c *Client) OnboardContext(ctx context.Context, publicToken string,
+	options *OnboardOptions) (*OnboardResult, error) {
+
+	exchangeRes, err := c.ItemPublicTokenExchangeContext(ctx, publicToken)
+	if err != nil {
+		return nil, err
+	}
+	result := &OnboardResult{AccessToken: exchangeRes.AccessToken, ItemID: exchangeRes.ItemID}
+
+	if err = c.onboard(ctx, result, options); err != nil {
+		return nil, c.rollback(ctx, result.AccessToken, err)
+	}
+	return result, nil
+}
+
+// rollback removes the item of accessToken after the onboarding step that failed with err,
+// and returns the error to report. The caller's context may be what failed, so the removal
+// keeps only its values and is bounded by RollbackTimeout instead.
+func (c *Client) rollback(ctx context.Context, accessToken string, err error) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), RollbackTimeout)
+	defer cancel()
+	if _, rollbackErr := c.ItemRemoveContext(ctx, accessToken); rollbackErr != nil {
+		return &RollbackError{AccessToken: accessToken, Err: err, RollbackErr: rollbackErr}
+	}
+	return err
+}
+
+// onboard runs the steps of Onboard that follow the token exchange.
+func (c *Client) onboard(ctx context.Context, result *OnboardResult, options *OnboardOptions) error {
+	if options != nil && options.Webhook != "" {
+		if _, err := c.ItemWebhookUpdateContext(ctx, result.AccessToken, options.Webhook); err != nil {
+			return err
+		}
+	}
+
+	itemRes, err := c.ItemGetContext(ctx, result.AccessToken)
+	if err != nil {
+		return err
+	}
+	result.Item = itemRes.Item
+
+	accountsRes, err := c.AccountsContext(ctx, result.AccessToken)
+	if err != nil {
+		return err
+	}
+	result.Accounts = accountsRes.Accounts
+	return nil
+}
@@ -0,0 +1,45 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOnboardRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	removed := make(chan context.Context, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices the client going away once the body is read.
+		io.Copy(io.Discard, r.Body)
+		switch r.URL.Path {
+		case "/item/public_token/exchange":
+			w.Write([]byte(`{"access_token": "access-sandbox-1", "item_id": "item-1", "request_id": "r1"}`))
+		case "/item/get":
+			// The caller gives up while the item is fetched.
+			cancel()
+			<-r.Context().Done()
+		case "/item/remove":
+			removed <- r.Context()
+			w.Write([]byte(`{"request_id": "r2"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	c := NewClient("id", "secret", Sandbox, WithBaseURL(server.URL))
+
+	_, err := c.OnboardContext(ctx, "public-sandbox-1", nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Onboard = %v, want the error of the canceled step", err)
+	}
+	select {
+	case <-removed:
+	case <-time.After(time.Second):
+		t.Fatal("the item wasn't removed after the caller's context was canceled")
+	}
+}
@@ -0,0 +1,23 @@
+// Package openapi holds request and response structs generated from Plaid's published
+// OpenAPI definition, so that fields and endpoints Plaid adds are available without waiting
+// for the hand-written plaid package to model them.
+//
+// The plaid package remains the ergonomic API: its methods validate arguments, page, retry
+// and decode into the structs programs already use. The generated structs complement it.
+// Client.Call sends any generated request with the client's credentials, throttling and
+// hooks:
+//
+//	var res openapi.TransactionsRecurringGetResponse
+//	err := client.CallContext(ctx, "/transactions/recurring/get",
+//		openapi.TransactionsRecurringGetRequest{AccessToken: openapi.AccessToken(token)}, &res)
+//
+// The generated code and the definition it is generated from, plaid-openapi.json, are
+// committed, and a test fails if regenerating changes the code. The committed definition
+// is pinned to the API version the plaid package targets and holds the schemas of the
+// endpoints generated so far. To add endpoints, copy their schemas from the definition at
+// https://github.com/plaid/plaid-openapi, converted to JSON, e.g. with
+// "yq -o=json 2020-09-14.yml", into plaid-openapi.json and run go generate. Like the plaid
+// package, this package never imports plaid.
+package openapi
+
+//go:generate go run gen.go -spec plaid-openapi.json -o types_gen.go
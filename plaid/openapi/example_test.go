@@ -0,0 +1,22 @@
+package openapi_test
+
+import (
+	"fmt"
+
+	"github.com/wearevest/plaidgo/plaid"
+	"github.com/wearevest/plaidgo/plaid/openapi"
+)
+
+func Example() {
+	client := plaid.NewClient("client_id", "secret", plaid.Sandbox)
+	var res openapi.TransactionsRecurringGetResponse
+	err := client.Call("/transactions/recurring/get",
+		openapi.TransactionsRecurringGetRequest{AccessToken: "access-sandbox-token"}, &res)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, stream := range res.OutflowStreams {
+		fmt.Println(stream.Description, stream.Frequency, stream.AverageAmount.Amount)
+	}
+}
@@ -0,0 +1,291 @@
+//go:build ignore
+
+// gen generates Go structs from the component schemas of an OpenAPI 3 definition in JSON.
+//
+// Usage:
+//
+//	go run gen.go -spec plaid-openapi.json -o types_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type spec struct {
+	Info struct {
+		Version string `json:"version"`
+	} `json:"info"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Nullable             bool               `json:"nullable"`
+	Deprecated           bool               `json:"deprecated"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	Enum                 []interface{}      `json:"enum"`
+	AllOf                []*schema          `json:"allOf"`
+	OneOf                []*schema          `json:"oneOf"`
+	AnyOf                []*schema          `json:"anyOf"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+}
+
+type generator struct {
+	schemas map[string]*schema
+	out     bytes.Buffer
+}
+
+func main() {
+	specPath := flag.String("spec", "plaid-openapi.json", "OpenAPI definition in JSON")
+	outPath := flag.String("o", "types_gen.go", "output file")
+	pkg := flag.String("package", "openapi", "package name")
+	flag.Parse()
+
+	raw, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatalf("%v; see the package documentation for how to obtain the definition", err)
+	}
+	var s spec
+	if err = json.Unmarshal(raw, &s); err != nil {
+		log.Fatalf("%s: %v", *specPath, err)
+	}
+	g := &generator{schemas: s.Components.Schemas}
+	fmt.Fprintf(&g.out, "// Code generated by gen.go from %s (API version %s); DO NOT EDIT.\n\n",
+		filepath.Base(*specPath), s.Info.Version)
+	fmt.Fprintf(&g.out, "package %s\n\nimport \"encoding/json\"\n\nvar _ json.RawMessage\n", *pkg)
+
+	names := make([]string, 0, len(g.schemas))
+	for name := range g.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.declare(name, g.schemas[name])
+	}
+
+	source, err := format.Source(g.out.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if err = os.WriteFile(*outPath, source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// declare emits the declaration of a named schema.
+func (g *generator) declare(name string, s *schema) {
+	typeName := exported(name)
+	g.out.WriteString("\n")
+	g.comment(typeName, s.Description, s.Deprecated)
+	switch {
+	case len(s.Enum) > 0 && s.Type == "string":
+		fmt.Fprintf(&g.out, "type %s string\n\nconst (\n", typeName)
+		seen := map[string]bool{}
+		for _, value := range s.Enum {
+			v, ok := value.(string)
+			if constant := typeName + exported(v); ok && !seen[constant] {
+				seen[constant] = true
+				fmt.Fprintf(&g.out, "\t%s %s = %q\n", constant, typeName, v)
+			}
+		}
+		g.out.WriteString(")\n")
+	case len(s.Properties) > 0 || len(s.AllOf) > 0 && g.isObject(s):
+		fmt.Fprintf(&g.out, "type %s struct {\n", typeName)
+		g.fields(s)
+		g.out.WriteString("}\n")
+	default:
+		goType := g.goType(s, true)
+		if goType == "json.RawMessage" {
+			// A defined type would lose the methods that keep the JSON raw.
+			fmt.Fprintf(&g.out, "type %s = %s\n", typeName, goType)
+		} else {
+			fmt.Fprintf(&g.out, "type %s %s\n", typeName, goType)
+		}
+	}
+}
+
+// isObject reports whether an allOf schema combines objects.
+func (g *generator) isObject(s *schema) bool {
+	for _, part := range s.AllOf {
+		if part = g.resolve(part); part.Type == "object" || len(part.Properties) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fields emits the struct fields of an object schema, flattening allOf.
+func (g *generator) fields(s *schema) {
+	properties := map[string]*schema{}
+	required := map[string]bool{}
+	var collect func(s *schema)
+	collect = func(s *schema) {
+		s = g.resolve(s)
+		for _, part := range s.AllOf {
+			collect(part)
+		}
+		for name, property := range s.Properties {
+			properties[name] = property
+		}
+		for _, name := range s.Required {
+			required[name] = true
+		}
+	}
+	collect(s)
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := properties[name]
+		fieldName := exported(name)
+		g.comment(fieldName, property.Description, property.Deprecated)
+		tag := name
+		if !required[name] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&g.out, "\t%s %s `json:%q`\n", fieldName, g.goType(property, required[name]), tag)
+	}
+}
+
+// resolve follows a $ref to the schema it refers to.
+func (g *generator) resolve(s *schema) *schema {
+	for s.Ref != "" {
+		target, ok := g.schemas[refName(s.Ref)]
+		if !ok {
+			return &schema{}
+		}
+		s = target
+	}
+	return s
+}
+
+// goType returns the Go type of a schema. Optional and nullable structs and nullable scalars
+// are pointers.
+func (g *generator) goType(s *schema, required bool) string {
+	if s.Ref != "" {
+		name := exported(refName(s.Ref))
+		target := g.resolve(s)
+		if (target.Type == "object" || len(target.Properties) > 0) && (!required || s.Nullable) {
+			return "*" + name
+		}
+		return name
+	}
+	if len(s.AllOf) == 1 {
+		return g.goType(s.AllOf[0], required && !s.Nullable)
+	}
+	if len(s.OneOf) > 0 || len(s.AnyOf) > 0 || len(s.AllOf) > 1 {
+		return "json.RawMessage"
+	}
+	var scalar string
+	switch s.Type {
+	case "string":
+		scalar = "string"
+	case "integer":
+		scalar = "int64"
+		if s.Format == "int32" {
+			scalar = "int32"
+		}
+	case "number":
+		scalar = "float64"
+	case "boolean":
+		scalar = "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]json.RawMessage"
+		}
+		return "[]" + strings.TrimPrefix(g.goType(s.Items, true), "*")
+	case "object":
+		if len(s.Properties) > 0 {
+			// Inline objects are rare in Plaid's definition; keep them raw rather than
+			// inventing names for them.
+			return "json.RawMessage"
+		}
+		var additional schema
+		if json.Unmarshal(s.AdditionalProperties, &additional) == nil && (additional.Type != "" || additional.Ref != "") {
+			return "map[string]" + g.goType(&additional, true)
+		}
+		return "map[string]interface{}"
+	default:
+		return "json.RawMessage"
+	}
+	if s.Nullable {
+		return "*" + scalar
+	}
+	return scalar
+}
+
+// comment emits the first sentence of a description as a doc comment.
+func (g *generator) comment(name, description string, deprecated bool) {
+	description = strings.Join(strings.Fields(description), " ")
+	if i := strings.Index(description, ". "); i >= 0 {
+		description = description[:i+1]
+	}
+	switch {
+	case strings.HasPrefix(description, name+" "):
+		fmt.Fprintf(&g.out, "// %s\n", description)
+	case description != "":
+		fmt.Fprintf(&g.out, "// %s: %s\n", name, description)
+	}
+	if deprecated {
+		if description != "" {
+			g.out.WriteString("//\n")
+		}
+		g.out.WriteString("// Deprecated: deprecated in Plaid's API definition.\n")
+	}
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// initialisms are spelled in upper case in Go identifiers, following the plaid package.
+var initialisms = map[string]string{
+	"id": "ID", "ids": "IDs", "url": "URL", "uri": "URI", "api": "API", "ach": "ACH",
+	"iso": "ISO", "ip": "IP", "ssn": "SSN", "dob": "DOB", "json": "JSON", "pdf": "PDF",
+}
+
+// exported converts a schema or property name such as "account_id" or "transactionsSync"
+// into an exported Go identifier such as "AccountID" or "TransactionsSync".
+func exported(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if upper, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(upper)
+			continue
+		}
+		if strings.ToUpper(word) == word {
+			// Enum values are often in upper case, e.g. "SYNC_UPDATES_AVAILABLE".
+			word = strings.ToLower(word)
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	identifier := b.String()
+	if identifier == "" || unicode.IsDigit([]rune(identifier)[0]) {
+		identifier = "X" + identifier
+	}
+	return identifier
+}
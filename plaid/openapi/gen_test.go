@@ -0,0 +1,33 @@
+package openapi
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerated checks that types_gen.go is what gen.go generates from the committed
+// definition, so that neither is changed without the other.
+func TestGenerated(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not available")
+	}
+	out := filepath.Join(t.TempDir(), "types_gen.go")
+	cmd := exec.Command("go", "run", "gen.go", "-spec", "plaid-openapi.json", "-o", out)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("gen.go failed: %v\n%s", err, output)
+	}
+	want, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("types_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("types_gen.go is out of date; run go generate")
+	}
+}
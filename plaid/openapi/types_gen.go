@@ -0,0 +1,194 @@
+// Code generated by gen.go from plaid-openapi.json (API version 2020-09-14); DO NOT EDIT.
+
+package openapi
+
+import "encoding/json"
+
+var _ json.RawMessage
+
+// AccessToken: The access token associated with the Item data is being requested for.
+type AccessToken string
+
+// Item: Metadata about the Item.
+type Item struct {
+	// AvailableProducts: A list of products available for the Item that have not yet been accessed.
+	AvailableProducts []Products `json:"available_products"`
+	// BilledProducts: A list of products that have been billed for the Item.
+	BilledProducts []Products `json:"billed_products"`
+	// ConsentExpirationTime: The RFC 3339 timestamp after which the consent provided by the end user will expire.
+	ConsentExpirationTime *string         `json:"consent_expiration_time"`
+	Error                 json.RawMessage `json:"error"`
+	// InstitutionID: The Plaid Institution ID associated with the Item.
+	InstitutionID *string `json:"institution_id,omitempty"`
+	// ItemID: The Plaid Item ID.
+	ItemID string `json:"item_id"`
+	// UpdateType: Indicates whether an Item requires user interaction to be updated.
+	UpdateType string `json:"update_type"`
+	// Webhook: The URL registered to receive webhooks for the Item.
+	Webhook *string `json:"webhook"`
+}
+
+// ItemGetRequest defines the request schema for `/item/get`
+type ItemGetRequest struct {
+	AccessToken AccessToken `json:"access_token"`
+	// ClientID: Your Plaid API `client_id`.
+	ClientID string `json:"client_id,omitempty"`
+	// Secret: Your Plaid API `secret`.
+	Secret string `json:"secret,omitempty"`
+}
+
+// ItemGetResponse defines the response schema for `/item/get` and `/item/webhook/update`
+type ItemGetResponse struct {
+	Item      Item                `json:"item"`
+	RequestID RequestID           `json:"request_id"`
+	Status    *ItemStatusNullable `json:"status,omitempty"`
+}
+
+// ItemStatusNullable: Information about the last successful and failed transactions update for the Item.
+type ItemStatusNullable struct {
+	// Transactions: Information about the last successful and failed transactions update for the Item.
+	Transactions json.RawMessage `json:"transactions,omitempty"`
+}
+
+// PersonalFinanceCategory: Information describing the intent of the transaction.
+type PersonalFinanceCategory struct {
+	// ConfidenceLevel: A description of how confident we are that the provided categories accurately describe the transaction intent.
+	ConfidenceLevel *string `json:"confidence_level,omitempty"`
+	// Detailed: A granular category conveying the transaction's intent.
+	Detailed string `json:"detailed"`
+	// Primary: A high level category that communicates the broad category of the transaction.
+	Primary string `json:"primary"`
+}
+
+// PlaidError: We use standard HTTP response codes for success and failure notifications, and our errors are further classified by `error_type`.
+type PlaidError struct {
+	// DisplayMessage: A user-friendly representation of the error code.
+	DisplayMessage *string `json:"display_message"`
+	// ErrorCode: The particular error code.
+	ErrorCode string `json:"error_code"`
+	// ErrorMessage: A developer-friendly representation of the error code.
+	ErrorMessage string `json:"error_message"`
+	// ErrorType: A broad categorization of the error.
+	ErrorType string `json:"error_type"`
+	// RequestID: A unique ID identifying the request, to be used for troubleshooting purposes.
+	RequestID string `json:"request_id,omitempty"`
+	// Status: The HTTP status code associated with the error.
+	Status *float64 `json:"status,omitempty"`
+}
+
+// Products: A list of products that an institution can support.
+type Products string
+
+const (
+	ProductsAssets                Products = "assets"
+	ProductsAuth                  Products = "auth"
+	ProductsBalance               Products = "balance"
+	ProductsIdentity              Products = "identity"
+	ProductsInvestments           Products = "investments"
+	ProductsLiabilities           Products = "liabilities"
+	ProductsPaymentInitiation     Products = "payment_initiation"
+	ProductsIdentityVerification  Products = "identity_verification"
+	ProductsTransactions          Products = "transactions"
+	ProductsCreditDetails         Products = "credit_details"
+	ProductsIncome                Products = "income"
+	ProductsIncomeVerification    Products = "income_verification"
+	ProductsDepositSwitch         Products = "deposit_switch"
+	ProductsStandingOrders        Products = "standing_orders"
+	ProductsTransfer              Products = "transfer"
+	ProductsEmployment            Products = "employment"
+	ProductsRecurringTransactions Products = "recurring_transactions"
+	ProductsSignal                Products = "signal"
+)
+
+// RecurringTransactionFrequency: Describes the frequency of the transaction stream.
+type RecurringTransactionFrequency string
+
+const (
+	RecurringTransactionFrequencyUnknown     RecurringTransactionFrequency = "UNKNOWN"
+	RecurringTransactionFrequencyWeekly      RecurringTransactionFrequency = "WEEKLY"
+	RecurringTransactionFrequencyBiweekly    RecurringTransactionFrequency = "BIWEEKLY"
+	RecurringTransactionFrequencySemiMonthly RecurringTransactionFrequency = "SEMI_MONTHLY"
+	RecurringTransactionFrequencyMonthly     RecurringTransactionFrequency = "MONTHLY"
+	RecurringTransactionFrequencyAnnually    RecurringTransactionFrequency = "ANNUALLY"
+)
+
+// RequestID: A unique identifier for the request, which can be used for troubleshooting.
+type RequestID string
+
+// TransactionStream: A grouping of related transactions
+type TransactionStream struct {
+	// AccountID: The ID of the account to which the stream belongs
+	AccountID     string                  `json:"account_id"`
+	AverageAmount TransactionStreamAmount `json:"average_amount"`
+	// Category: A hierarchical array of the categories to which this transaction belongs.
+	Category []string `json:"category"`
+	// CategoryID: The ID of the category to which this transaction belongs.
+	CategoryID string `json:"category_id"`
+	// Description: A description of the transaction stream.
+	Description string `json:"description"`
+	// FirstDate: The posted date of the earliest transaction in the stream.
+	FirstDate string                        `json:"first_date"`
+	Frequency RecurringTransactionFrequency `json:"frequency"`
+	// IsActive: Indicates whether the transaction stream is still live.
+	IsActive   bool                    `json:"is_active"`
+	LastAmount TransactionStreamAmount `json:"last_amount"`
+	// LastDate: The posted date of the latest transaction in the stream.
+	LastDate string `json:"last_date"`
+	// MerchantName: The merchant associated with the transaction stream.
+	MerchantName            *string                  `json:"merchant_name"`
+	PersonalFinanceCategory *PersonalFinanceCategory `json:"personal_finance_category,omitempty"`
+	Status                  TransactionStreamStatus  `json:"status"`
+	// StreamID: A unique id for the stream
+	StreamID string `json:"stream_id"`
+	// TransactionIDs: An array of Plaid transaction IDs belonging to the stream, sorted by posted date.
+	TransactionIDs []string `json:"transaction_ids"`
+}
+
+// TransactionStreamAmount: Object with data pertaining to an amount on the transaction stream.
+type TransactionStreamAmount struct {
+	// Amount: Represents the numerical value of an amount.
+	Amount float64 `json:"amount,omitempty"`
+	// ISOCurrencyCode: The ISO-4217 currency code of the amount.
+	ISOCurrencyCode *string `json:"iso_currency_code,omitempty"`
+	// UnofficialCurrencyCode: The unofficial currency code of the amount.
+	UnofficialCurrencyCode *string `json:"unofficial_currency_code,omitempty"`
+}
+
+// TransactionStreamStatus: The current status of the transaction stream.
+type TransactionStreamStatus string
+
+const (
+	TransactionStreamStatusUnknown        TransactionStreamStatus = "UNKNOWN"
+	TransactionStreamStatusMature         TransactionStreamStatus = "MATURE"
+	TransactionStreamStatusEarlyDetection TransactionStreamStatus = "EARLY_DETECTION"
+	TransactionStreamStatusTombstoned     TransactionStreamStatus = "TOMBSTONED"
+)
+
+// TransactionsRecurringGetRequest defines the request schema for `/transactions/recurring/get`
+type TransactionsRecurringGetRequest struct {
+	AccessToken AccessToken `json:"access_token"`
+	// AccountIDs: A list of `account_ids` to retrieve for the Item.
+	AccountIDs []string `json:"account_ids,omitempty"`
+	// ClientID: Your Plaid API `client_id`.
+	ClientID string                                  `json:"client_id,omitempty"`
+	Options  *TransactionsRecurringGetRequestOptions `json:"options,omitempty"`
+	// Secret: Your Plaid API `secret`.
+	Secret string `json:"secret,omitempty"`
+}
+
+// TransactionsRecurringGetRequestOptions: An optional object to be used with the request.
+type TransactionsRecurringGetRequestOptions struct {
+	// IncludePersonalFinanceCategory: Include the `personal_finance_category` object for each transaction stream in the response.
+	IncludePersonalFinanceCategory bool `json:"include_personal_finance_category,omitempty"`
+}
+
+// TransactionsRecurringGetResponse defines the response schema for `/transactions/recurring/get`
+type TransactionsRecurringGetResponse struct {
+	// InflowStreams: An array of depository transaction streams.
+	InflowStreams []TransactionStream `json:"inflow_streams"`
+	// OutflowStreams: An array of expense transaction streams.
+	OutflowStreams []TransactionStream `json:"outflow_streams"`
+	RequestID      RequestID           `json:"request_id"`
+	// UpdatedDatetime: Timestamp in ISO 8601 format (`YYYY-MM-DDTHH:mm:ssZ`) indicating the last time transaction streams for the given account were updated on
+	UpdatedDatetime string `json:"updated_datetime"`
+}
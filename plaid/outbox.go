@@ -0,0 +1,307 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/webhooks"
+)
+
+// OutboxEntry is a transactions sync of an item scheduled by a webhook.
+type OutboxEntry struct {
+	ItemID ItemID `json:"item_id"`
+	// Reason is the type and code of the webhook that scheduled the sync, e.g.
+	// "TRANSACTIONS/SYNC_UPDATES_AVAILABLE".
+	Reason string `json:"reason"`
+	// ScheduledAt is the time of the latest webhook that scheduled the sync.
+	ScheduledAt time.Time `json:"scheduled_at"`
+	// Attempts is the number of failed syncs so far, NextAttempt the time the entry is due
+	// and LastError the error of the last failed sync.
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// OutboxStore persists scheduled syncs, so that a sync scheduled by a webhook isn't lost if
+// the process crashes before it ran. Webhooks are only acked once Enqueue returned;
+// implementations backed by the application's database can write the entry in the same
+// transaction as the other effects of a webhook.
+type OutboxStore interface {
+	// Enqueue schedules a sync of entry.ItemID. An item has at most one entry: if it already
+	// has one, its ScheduledAt and Reason are updated and it is made due immediately, since a
+	// single sync catches up on all changes.
+	Enqueue(ctx context.Context, entry OutboxEntry) error
+	// Due returns up to limit entries whose NextAttempt is not after now, oldest first.
+	Due(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error)
+	// Complete removes entry after its sync succeeded, unless it was enqueued again since
+	// it was returned by Due, i.e. its stored ScheduledAt differs from entry's.
+	Complete(ctx context.Context, entry OutboxEntry) error
+	// Reschedule stores the Attempts, NextAttempt and LastError of entry after a failed sync.
+	Reschedule(ctx context.Context, entry OutboxEntry) error
+}
+
+// MemoryOutboxStore is an OutboxStore that keeps entries in memory. It doesn't survive
+// restarts and is meant for tests.
+type MemoryOutboxStore struct {
+	mu      sync.Mutex
+	entries map[ItemID]OutboxEntry // item id -> entry
+}
+
+// NewMemoryOutboxStore instantiates an empty MemoryOutboxStore.
+func NewMemoryOutboxStore() *MemoryOutboxStore {
+	return &MemoryOutboxStore{entries: map[ItemID]OutboxEntry{}}
+}
+
+// Enqueue implements OutboxStore.
+func (s *MemoryOutboxStore) Enqueue(ctx context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.entries[entry.ItemID]; ok {
+		entry.Attempts = existing.Attempts
+		entry.LastError = existing.LastError
+	}
+	entry.NextAttempt = entry.ScheduledAt
+	s.entries[entry.ItemID] = entry
+	return nil
+}
+
+// Due implements OutboxStore.
+func (s *MemoryOutboxStore) Due(ctx context.Context, now time.Time, limit int) ([]OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var due []OutboxEntry
+	for _, entry := range s.entries {
+		if !entry.NextAttempt.After(now) {
+			due = append(due, entry)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttempt.Before(due[j].NextAttempt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// Complete implements OutboxStore.
+func (s *MemoryOutboxStore) Complete(ctx context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.entries[entry.ItemID]; ok && existing.ScheduledAt.Equal(entry.ScheduledAt) {
+		delete(s.entries, entry.ItemID)
+	}
+	return nil
+}
+
+// Reschedule implements OutboxStore.
+func (s *MemoryOutboxStore) Reschedule(ctx context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.entries[entry.ItemID]
+	if !ok {
+		return nil
+	}
+	existing.Attempts = entry.Attempts
+	existing.LastError = entry.LastError
+	if existing.ScheduledAt.Equal(entry.ScheduledAt) {
+		existing.NextAttempt = entry.NextAttempt
+	}
+	s.entries[entry.ItemID] = existing
+	return nil
+}
+
+// OutboxDispatcherConfig configures an OutboxDispatcher.
+type OutboxDispatcherConfig struct {
+	Store OutboxStore
+	// AccessToken returns the access token of an item.
+	AccessToken func(ctx context.Context, itemID ItemID) (string, error)
+	// SyncStore receives the synced transactions through SyncToStore.
+	SyncStore TransactionSyncStore
+	// Sync, if set, replaces the sync into SyncStore.
+	Sync func(ctx context.Context, accessToken string, entry OutboxEntry) error
+
+	// Interval is the time between polls of the store. Defaults to 5 seconds.
+	Interval time.Duration
+	// BatchSize is the number of entries handled per poll. Defaults to 50.
+	BatchSize int
+	// Backoff is the delay before retrying a failed sync, doubled after each further
+	// failure up to an hour. Defaults to 30 seconds.
+	Backoff time.Duration
+	// MaxAttempts is the number of failed syncs after which an entry is removed and passed
+	// to OnDeadLetter. Defaults to 10.
+	MaxAttempts int
+
+	OnDeadLetter func(entry OutboxEntry)
+	OnError      func(entry OutboxEntry, err error)
+}
+
+// OutboxDispatcher runs the syncs scheduled in an OutboxStore, so that "webhook received,
+// sync scheduled" survives process crashes: HandleWebhooks makes a webhooks.Router record a
+// sync for every transactions webhook, and the dispatcher runs them, retrying failed syncs
+// with backoff. An entry is only removed once its sync succeeded, so a sync may run again
+// after a crash; syncs through SyncToStore are idempotent.
+//
+// An OutboxDispatcher is either driven by Run or, as a Component, by Start and Close.
+type OutboxDispatcher struct {
+	client    *Client
+	config    OutboxDispatcherConfig
+	lifecycle lifecycle
+}
+
+// NewOutboxDispatcher instantiates an OutboxDispatcher that makes its requests through c.
+func NewOutboxDispatcher(c *Client, config OutboxDispatcherConfig) *OutboxDispatcher {
+	if config.Interval == 0 {
+		config.Interval = 5 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	if config.Backoff == 0 {
+		config.Backoff = 30 * time.Second
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 10
+	}
+	return &OutboxDispatcher{client: c, config: config}
+}
+
+// HandleWebhooks registers a handler on router that schedules a sync for every
+// TRANSACTIONS webhook announcing new, changed or removed transactions.
+func (d *OutboxDispatcher) HandleWebhooks(router *webhooks.Router) {
+	router.OnTransactions(func(ctx context.Context, webhook *TransactionsWebhook) error {
+		switch webhook.WebhookCode {
+		case "SYNC_UPDATES_AVAILABLE", "INITIAL_UPDATE", "HISTORICAL_UPDATE", "DEFAULT_UPDATE",
+			"TRANSACTIONS_REMOVED":
+		default:
+			return nil
+		}
+		return d.config.Store.Enqueue(ctx, OutboxEntry{
+			ItemID:      webhook.ItemID,
+			Reason:      webhook.WebhookType + "/" + webhook.WebhookCode,
+			ScheduledAt: d.client.clock.Now(),
+		})
+	})
+}
+
+// Run dispatches until ctx is done and then returns ctx.Err().
+func (d *OutboxDispatcher) Run(ctx context.Context) error {
+	d.run(ctx, nil)
+	return ctx.Err()
+}
+
+// Start dispatches in the background until ctx is done or Close is called.
+func (d *OutboxDispatcher) Start(ctx context.Context) error {
+	return d.lifecycle.start(func(stop <-chan struct{}) {
+		d.run(ctx, stop)
+	})
+}
+
+// Close stops an OutboxDispatcher started with Start and waits for an in-flight sync to
+// finish.
+func (d *OutboxDispatcher) Close() error {
+	return d.lifecycle.close()
+}
+
+func (d *OutboxDispatcher) run(ctx context.Context, stop <-chan struct{}) {
+	for {
+		n, err := d.dispatchDue(ctx, stop)
+		if err != nil && d.config.OnError != nil {
+			d.config.OnError(OutboxEntry{}, err)
+		}
+		if n == d.config.BatchSize {
+			// There may be more entries due.
+			if ctx.Err() != nil || stopped(stop) {
+				return
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-d.client.clock.After(d.config.Interval):
+		}
+	}
+}
+
+// DispatchDue runs the syncs of up to BatchSize due entries and returns the number of
+// entries handled. Failures of single syncs are reported to OnError; the error is that of
+// the store.
+func (d *OutboxDispatcher) DispatchDue(ctx context.Context) (int, error) {
+	return d.dispatchDue(ctx, nil)
+}
+
+func (d *OutboxDispatcher) dispatchDue(ctx context.Context, stop <-chan struct{}) (int, error) {
+	due, err := d.config.Store.Due(ctx, d.client.clock.Now(), d.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for i, entry := range due {
+		if ctx.Err() != nil || stopped(stop) {
+			return i, ctx.Err()
+		}
+		if err = d.dispatch(ctx, entry); err != nil {
+			return i, err
+		}
+	}
+	return len(due), nil
+}
+
+// dispatch runs the sync of one entry and completes or reschedules it.
+func (d *OutboxDispatcher) dispatch(ctx context.Context, entry OutboxEntry) error {
+	syncErr := d.sync(ctx, entry)
+	if syncErr == nil {
+		return d.config.Store.Complete(ctx, entry)
+	}
+	if d.config.OnError != nil {
+		d.config.OnError(entry, syncErr)
+	}
+	entry.Attempts++
+	entry.LastError = syncErr.Error()
+	if entry.Attempts >= d.config.MaxAttempts {
+		// Reschedule first so that the attempts are kept if the entry was enqueued again and
+		// therefore isn't removed.
+		if err := d.config.Store.Reschedule(ctx, entry); err != nil {
+			return err
+		}
+		if err := d.config.Store.Complete(ctx, entry); err != nil {
+			return err
+		}
+		if d.config.OnDeadLetter != nil {
+			d.config.OnDeadLetter(entry)
+		}
+		return nil
+	}
+	backoff := d.config.Backoff
+	for i := 1; i < entry.Attempts && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	if backoff > time.Hour {
+		backoff = time.Hour
+	}
+	entry.NextAttempt = d.client.clock.Now().Add(backoff)
+	return d.config.Store.Reschedule(ctx, entry)
+}
+
+func (d *OutboxDispatcher) sync(ctx context.Context, entry OutboxEntry) error {
+	if d.config.AccessToken == nil {
+		return errors.New("outbox dispatcher has no AccessToken function")
+	}
+	accessToken, err := d.config.AccessToken(ctx, entry.ItemID)
+	if err != nil {
+		return err
+	}
+	if d.config.Sync != nil {
+		return d.config.Sync(ctx, accessToken, entry)
+	}
+	if d.config.SyncStore == nil {
+		return errors.New("outbox dispatcher has neither a Sync function nor a SyncStore")
+	}
+	_, err = d.client.SyncToStoreContext(ctx, accessToken, d.config.SyncStore)
+	return err
+}
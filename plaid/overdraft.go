@@ -0,0 +1,147 @@
+package plaid
+
+import (
+	"strings"
+	"time"
+)
+
+// OverdraftRiskOptions represents options associated with analyzing overdraft risk.
+type OverdraftRiskOptions struct {
+	// LookbackDays is the number of days analyzed, ending with EndDate. Defaults to 90.
+	LookbackDays int
+	// EndDate is the day the account's current balance applies to. Defaults to today in
+	// Location.
+	EndDate string
+	// Location is the time zone today is determined in when EndDate is empty, e.g. the time
+	// zone of the account's owner. Defaults to UTC.
+	Location *time.Location
+	// LowBalanceThreshold is the balance below which a day counts as a low balance day.
+	LowBalanceThreshold float64
+}
+
+// OverdraftRisk summarizes the indicators of insufficient funds risk of a depository
+// account over a lookback window.
+type OverdraftRisk struct {
+	AccountID AccountID
+	StartDate string
+	EndDate   string
+
+	// NSFFees and OverdraftFees count the fees charged for insufficient funds and for
+	// overdrafts, and FeeTotal is their sum.
+	NSFFees       int
+	OverdraftFees int
+	FeeTotal      float64
+
+	// NegativeBalanceDays and LowBalanceDays count the days that ended with a negative
+	// balance or one below the LowBalanceThreshold.
+	NegativeBalanceDays int
+	LowBalanceDays      int
+	MinimumBalance      float64
+	MinimumBalanceDate  string
+}
+
+// AnalyzeOverdraftRisk computes NSF and overdraft fee counts and balance indicators of an
+// account from its transactions, as a local complement to Signal for teams that can't use
+// it. Daily balances are reconstructed with BalanceHistory, pending transactions included.
+//
+// Fees are recognized by their personal finance category, falling back to the legacy
+// category and finally to the transaction name, e.g. "NSF FEE" or "OVERDRAFT CHARGE".
+func AnalyzeOverdraftRisk(account Account, transactions []Transaction,
+	options OverdraftRiskOptions) (*OverdraftRisk, error) {
+
+	if options.LookbackDays <= 0 {
+		options.LookbackDays = 90
+	}
+	if options.EndDate == "" {
+		options.EndDate = DateIn(time.Now(), dateLocation(options.Location))
+	}
+	end, err := time.Parse("2006-01-02", options.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	risk := &OverdraftRisk{
+		AccountID: account.AccountID,
+		StartDate: end.AddDate(0, 0, 1-options.LookbackDays).Format("2006-01-02"),
+		EndDate:   options.EndDate,
+	}
+
+	for _, t := range transactions {
+		if t.AccountID != account.AccountID || t.Date < risk.StartDate || t.Date > risk.EndDate {
+			continue
+		}
+		switch overdraftFeeKind(t) {
+		case nsfFee:
+			risk.NSFFees++
+			risk.FeeTotal += t.Amount64()
+		case overdraftFee:
+			risk.OverdraftFees++
+			risk.FeeTotal += t.Amount64()
+		}
+	}
+
+	history, err := BalanceHistory(account, transactions, BalanceHistoryOptions{
+		StartDate:      risk.StartDate,
+		EndDate:        risk.EndDate,
+		IncludePending: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i, day := range history {
+		if i == 0 || day.Balance < risk.MinimumBalance {
+			risk.MinimumBalance, risk.MinimumBalanceDate = day.Balance, day.Date
+		}
+		if day.Balance < 0 {
+			risk.NegativeBalanceDays++
+		}
+		if day.Balance < options.LowBalanceThreshold {
+			risk.LowBalanceDays++
+		}
+	}
+	return risk, nil
+}
+
+type feeKind int
+
+const (
+	notAFee feeKind = iota
+	nsfFee
+	overdraftFee
+)
+
+// overdraftFeeKind recognizes insufficient funds and overdraft fees.
+func overdraftFeeKind(t Transaction) feeKind {
+	if t.Amount <= 0 {
+		return notAFee
+	}
+	if category := t.PersonalFinanceCategory; category != nil && category.Detailed != "" {
+		switch category.Detailed {
+		case "BANK_FEES_INSUFFICIENT_FUNDS":
+			return nsfFee
+		case "BANK_FEES_OVERDRAFT_FEES":
+			return overdraftFee
+		}
+		return notAFee
+	}
+	if len(t.Category) >= 2 && t.Category[0] == "Bank Fees" {
+		switch t.Category[1] {
+		case "Insufficient Funds":
+			return nsfFee
+		case "Overdraft":
+			return overdraftFee
+		}
+		return notAFee
+	}
+	// Match whole words, "NSF" is also part of e.g. "TRANSFER".
+	name := " " + strings.Join(strings.FieldsFunc(strings.ToUpper(t.Name), func(r rune) bool {
+		return !('A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}), " ") + " "
+	switch {
+	case strings.Contains(name, " NSF ") || strings.Contains(name, " INSUFFICIENT FUNDS ") ||
+		strings.Contains(name, " RETURNED ITEM "):
+		return nsfFee
+	case strings.Contains(name, " OVERDRAFT ") || strings.Contains(name, " OD FEE "):
+		return overdraftFee
+	}
+	return notAFee
+}
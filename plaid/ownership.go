@@ -0,0 +1,271 @@
+package plaid
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OwnerField is the kind of identity data an OwnerChange is about.
+type OwnerField string
+
+const (
+	OwnerFieldName        OwnerField = "name"
+	OwnerFieldPhoneNumber OwnerField = "phone_number"
+	OwnerFieldEmail       OwnerField = "email"
+	OwnerFieldAddress     OwnerField = "address"
+)
+
+// OwnerChange describes how one kind of identity data of an account's owners changed.
+// Values are compared across all owners of the account, so an email moving from one owner
+// to another is not a change. Addresses are formatted as one line by OwnerAddressData.String.
+type OwnerChange struct {
+	AccountID AccountID
+	Field     OwnerField
+	Added     []string
+	Removed   []string
+}
+
+// DiffOwners compares two retrievals of an item's identity and returns the changes to the
+// names, phone numbers, emails and addresses of the owners of accounts present in both.
+// Values are compared case insensitively and ignoring surrounding whitespace, since
+// institutions are not consistent about either.
+func DiffOwners(previous, current IdentityGetResponse) []OwnerChange {
+	before := map[AccountID]Account{}
+	for _, account := range previous.Accounts {
+		before[account.AccountID] = account
+	}
+	var changes []OwnerChange
+	for _, account := range current.Accounts {
+		previousAccount, ok := before[account.AccountID]
+		if !ok {
+			continue
+		}
+		for _, field := range []OwnerField{OwnerFieldName, OwnerFieldPhoneNumber, OwnerFieldEmail, OwnerFieldAddress} {
+			added, removed := diffOwnerValues(ownerValues(previousAccount.Owners, field),
+				ownerValues(account.Owners, field))
+			if len(added) > 0 || len(removed) > 0 {
+				changes = append(changes, OwnerChange{AccountID: account.AccountID, Field: field,
+					Added: added, Removed: removed})
+			}
+		}
+	}
+	return changes
+}
+
+// ownerValues returns the values of a field of all owners, keyed by their normalized form.
+func ownerValues(owners []Owner, field OwnerField) map[string]string {
+	values := map[string]string{}
+	add := func(value string) {
+		if key := strings.ToLower(strings.TrimSpace(value)); key != "" {
+			values[key] = value
+		}
+	}
+	for _, owner := range owners {
+		switch field {
+		case OwnerFieldName:
+			for _, name := range owner.Names {
+				add(name)
+			}
+		case OwnerFieldPhoneNumber:
+			for _, phone := range owner.PhoneNumbers {
+				add(phone.Data)
+			}
+		case OwnerFieldEmail:
+			for _, email := range owner.Emails {
+				add(email.Data)
+			}
+		case OwnerFieldAddress:
+			for _, address := range owner.Addresses {
+				add(address.Data.String())
+			}
+		}
+	}
+	return values
+}
+
+func diffOwnerValues(previous, current map[string]string) (added, removed []string) {
+	for key, value := range current {
+		if _, ok := previous[key]; !ok {
+			added = append(added, value)
+		}
+	}
+	for key, value := range previous {
+		if _, ok := current[key]; !ok {
+			removed = append(removed, value)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// IdentityStore persists the last identity retrieved for each access token, so that
+// ownership changes are detected across restarts.
+type IdentityStore interface {
+	// LoadIdentity returns the identity of an access token, or nil if there is none.
+	LoadIdentity(ctx context.Context, accessToken string) (*IdentityGetResponse, error)
+	SaveIdentity(ctx context.Context, accessToken string, identity IdentityGetResponse) error
+}
+
+// OwnershipMonitorConfig configures an OwnershipMonitor.
+type OwnershipMonitorConfig struct {
+	// Tokens supplies the access tokens of the items to monitor.
+	Tokens TokenStore
+	// Store persists the identities changes are detected against. If nil they are kept in
+	// memory, and the first scan after a restart only records identities.
+	Store IdentityStore
+	// Interval is the time between scans. Defaults to 24 hours.
+	Interval time.Duration
+
+	// OnChange is called for every item whose owners changed since the previous scan.
+	OnChange func(event OwnershipChangeEvent)
+	OnError  func(accessToken string, err error)
+}
+
+// OwnershipChangeEvent reports the owner changes of an item detected by an
+// OwnershipMonitor.
+type OwnershipChangeEvent struct {
+	AccessToken string
+	ItemID      ItemID
+	Time        time.Time
+	Changes     []OwnerChange
+}
+
+// OwnershipMonitor periodically fetches a set of items from /identity/get and reports
+// changes to the names, phone numbers, emails and addresses of their account owners, e.g.
+// to flag possible account takeovers for fraud review. Changes are delivered through the
+// callbacks of its OwnershipMonitorConfig.
+//
+// An OwnershipMonitor scans once when started and then every Interval. It is either driven
+// by Run or, as a Component, by Start and Close.
+type OwnershipMonitor struct {
+	client    *Client
+	config    OwnershipMonitorConfig
+	lifecycle lifecycle
+
+	mu         sync.Mutex
+	identities map[string]IdentityGetResponse // used without a Store
+}
+
+// NewOwnershipMonitor instantiates an OwnershipMonitor that makes its requests through c.
+func NewOwnershipMonitor(c *Client, config OwnershipMonitorConfig) *OwnershipMonitor {
+	if config.Interval == 0 {
+		config.Interval = 24 * time.Hour
+	}
+	return &OwnershipMonitor{client: c, config: config, identities: map[string]IdentityGetResponse{}}
+}
+
+// Run scans until ctx is done and then returns ctx.Err().
+func (m *OwnershipMonitor) Run(ctx context.Context) error {
+	m.run(ctx, nil)
+	return ctx.Err()
+}
+
+// Start scans in the background until ctx is done or Close is called.
+func (m *OwnershipMonitor) Start(ctx context.Context) error {
+	return m.lifecycle.start(func(stop <-chan struct{}) {
+		m.run(ctx, stop)
+	})
+}
+
+// Close stops an OwnershipMonitor started with Start and waits for an in-flight scan to
+// finish.
+func (m *OwnershipMonitor) Close() error {
+	return m.lifecycle.close()
+}
+
+func (m *OwnershipMonitor) run(ctx context.Context, stop <-chan struct{}) {
+	for {
+		m.scan(ctx, stop)
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-m.client.clock.After(m.config.Interval):
+		}
+	}
+}
+
+// scan checks every item once.
+func (m *OwnershipMonitor) scan(ctx context.Context, stop <-chan struct{}) {
+	tokens, err := m.config.Tokens.AccessTokens(ctx)
+	if err != nil {
+		m.reportError("", err)
+		return
+	}
+	for _, token := range tokens {
+		if ctx.Err() != nil || stopped(stop) {
+			return
+		}
+		event, err := m.Check(ctx, token)
+		if err != nil {
+			m.reportError(token, err)
+			continue
+		}
+		if event != nil && m.config.OnChange != nil {
+			m.config.OnChange(*event)
+		}
+	}
+}
+
+// Check fetches an item's identity, records it and returns the changes since the identity
+// recorded previously, or nil if there are none or no identity was recorded yet.
+func (m *OwnershipMonitor) Check(ctx context.Context, accessToken string) (*OwnershipChangeEvent, error) {
+	current, err := m.client.IdentityGetContext(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := m.load(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if err = m.save(ctx, accessToken, *current); err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return nil, nil
+	}
+	changes := DiffOwners(*previous, *current)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return &OwnershipChangeEvent{
+		AccessToken: accessToken,
+		ItemID:      current.Item.ItemId,
+		Time:        m.client.clock.Now(),
+		Changes:     changes,
+	}, nil
+}
+
+func (m *OwnershipMonitor) load(ctx context.Context, accessToken string) (*IdentityGetResponse, error) {
+	if m.config.Store != nil {
+		return m.config.Store.LoadIdentity(ctx, accessToken)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	identity, ok := m.identities[accessToken]
+	if !ok {
+		return nil, nil
+	}
+	return &identity, nil
+}
+
+func (m *OwnershipMonitor) save(ctx context.Context, accessToken string, identity IdentityGetResponse) error {
+	if m.config.Store != nil {
+		return m.config.Store.SaveIdentity(ctx, accessToken, identity)
+	}
+	m.mu.Lock()
+	m.identities[accessToken] = identity
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *OwnershipMonitor) reportError(accessToken string, err error) {
+	if m.config.OnError != nil {
+		m.config.OnError(accessToken, err)
+	}
+}
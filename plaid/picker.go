@@ -0,0 +1,151 @@
+package plaid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// PickerInstitution is an institution as listed in a PickerBundle.
+type PickerInstitution struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Logo is a base64 encoded PNG, empty if Plaid has none or logos were omitted.
+	Logo     string   `json:"logo,omitempty"`
+	OAuth    bool     `json:"oauth,omitempty"`
+	Products []string `json:"products"`
+}
+
+// PickerBundle is a compact list of the top institutions per country, meant to be embedded
+// in mobile and web institution pickers. Building it twice from the same catalog yields the
+// same JSON, so that it can be cached and served from a CDN under its Version.
+type PickerBundle struct {
+	// Version is a hash of the bundle's content.
+	Version string `json:"version"`
+	// Countries maps country codes to their institutions, best ranked first.
+	Countries map[string][]PickerInstitution `json:"countries"`
+}
+
+// PickerBundleOptions configures BuildPickerBundle.
+type PickerBundleOptions struct {
+	// CountryCodes are the countries to list. Defaults to every country of the stored
+	// institutions.
+	CountryCodes []string
+	// Top is the number of institutions listed per country. Defaults to 50.
+	Top int
+	// Products, if set, only lists institutions supporting all of them.
+	Products []string
+	// Rank scores institutions, e.g. by the number of items the application's users
+	// linked at them; higher scores are listed first. Ties, and every institution without
+	// a Rank, are ordered by name and then id.
+	Rank func(institution Institution) float64
+	// OmitLogos leaves the logos out, which makes the bundle much smaller.
+	OmitLogos bool
+}
+
+// BuildPickerBundle builds a PickerBundle from the institutions in store, typically kept
+// up to date by an InstitutionSyncer whose OnReport rebuilds the bundle.
+func BuildPickerBundle(ctx context.Context, store InstitutionStore, options PickerBundleOptions) (*PickerBundle, error) {
+	if options.Top <= 0 {
+		options.Top = 50
+	}
+	ids, err := store.InstitutionIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := store.Institutions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	countries := map[string]bool{}
+	for _, code := range options.CountryCodes {
+		countries[code] = true
+	}
+	byCountry := map[string][]Institution{}
+	for _, id := range ids {
+		institution, ok := stored[id]
+		if !ok || !supportsAll(institution, options.Products) {
+			continue
+		}
+		for _, code := range institution.CountryCodes {
+			if len(options.CountryCodes) == 0 || countries[code] {
+				byCountry[code] = append(byCountry[code], institution)
+			}
+		}
+	}
+
+	bundle := &PickerBundle{Countries: map[string][]PickerInstitution{}}
+	for _, code := range options.CountryCodes {
+		bundle.Countries[code] = []PickerInstitution{}
+	}
+	for code, institutions := range byCountry {
+		scores := map[string]float64{}
+		if options.Rank != nil {
+			for _, institution := range institutions {
+				scores[institution.InstitutionID] = options.Rank(institution)
+			}
+		}
+		sort.Slice(institutions, func(i, j int) bool {
+			a, b := institutions[i], institutions[j]
+			if scores[a.InstitutionID] != scores[b.InstitutionID] {
+				return scores[a.InstitutionID] > scores[b.InstitutionID]
+			}
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.InstitutionID < b.InstitutionID
+		})
+		if len(institutions) > options.Top {
+			institutions = institutions[:options.Top]
+		}
+		listed := make([]PickerInstitution, len(institutions))
+		for i, institution := range institutions {
+			listed[i] = pickerInstitution(institution, options.OmitLogos)
+		}
+		bundle.Countries[code] = listed
+	}
+
+	// encoding/json sorts map keys, so equal bundles encode, and hash, equally.
+	content, err := json.Marshal(bundle.Countries)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+	bundle.Version = hex.EncodeToString(sum[:8])
+	return bundle, nil
+}
+
+func pickerInstitution(institution Institution, omitLogo bool) PickerInstitution {
+	products := append([]string{}, institution.Products...)
+	sort.Strings(products)
+	listed := PickerInstitution{
+		ID:       institution.InstitutionID,
+		Name:     institution.Name,
+		OAuth:    institution.OAuth,
+		Products: products,
+	}
+	if !omitLogo {
+		listed.Logo = institution.Logo
+	}
+	return listed
+}
+
+// supportsAll reports whether the institution supports every one of products.
+func supportsAll(institution Institution, products []string) bool {
+	for _, product := range products {
+		found := false
+		for _, supported := range institution.Products {
+			if supported == product {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
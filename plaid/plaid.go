@@ -1,24 +1,54 @@
 // Package plaid implements a Go client for the Plaid API (https://plaid.com/docs)
+//
+// Every method of Client that makes requests, including the helpers built on several
+// requests, comes in two variants: X, which runs with context.Background(), and XContext,
+// which takes a context as its first argument.
 package plaid
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+	"github.com/wearevest/plaidgo/plaid/transactions"
+	"github.com/wearevest/plaidgo/plaid/transport"
 )
 
 // NewClient instantiates a Client associated with a client id, secret and environment.
 // See https://plaid.com/docs/api/#gaining-access.
-func NewClient(clientID, secret string, environment environmentURL) *Client {
-	return &Client{clientID, secret, environment, &http.Client{}}
+func NewClient(clientID, secret string, environment environmentURL, options ...Option) *Client {
+	return NewCustomClient(clientID, secret, environment, &http.Client{}, options...)
 }
 
 // Same as above but with additional parameter to pass http.Client. This is required
 // if you want to run the code on Google AppEngine which prohibits use of http.DefaultClient
-func NewCustomClient(clientID, secret string, environment environmentURL, httpClient *http.Client) *Client {
-	return &Client{clientID, secret, environment, httpClient}
+func NewCustomClient(clientID, secret string, environment environmentURL, httpClient *http.Client,
+	options ...Option) *Client {
+
+	c := &Client{
+		creds:       newCredentials(credentials{clientID: clientID, secret: secret}),
+		environment: environment,
+		httpClient:  httpClient,
+		clock:       systemClock{},
+		logger:      log.Default(),
+
+		institutions: newLRUCache[Institution]("institutions", DefaultInstitutionCacheSize, institutionCacheTTL),
+		categories:   newLRUCache[[]Category]("categories", DefaultCategoryCacheSize, categoryCacheTTL),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
 }
 
 // Note: Client is only exported for method documentation purposes.
@@ -26,10 +56,56 @@ func NewCustomClient(clientID, secret string, environment environmentURL, httpCl
 //
 // See https://github.com/golang/go/issues/7823.
 type Client struct {
-	clientID    string
-	secret      string
+	creds       *atomic.Pointer[credentials]
 	environment environmentURL
 	httpClient  *http.Client
+
+	clock     Clock
+	location  *time.Location
+	throttle  *throttle
+	drift     *DriftRecorder
+	slow      *slowRequestHook
+	debug     *debugBuffer
+	mutators  []RequestMutator
+	cooldown  *institutionCooldown
+	hooks     []Hook
+	events    *EventStream
+	flights   *flightGroup
+	responses *responseCache
+
+	institutions      *lruCache[Institution]
+	categories        *lruCache[[]Category]
+	cooldownCacheSize int
+
+	apiVersion       string
+	skipAvailability bool
+	logger           Logger
+	signConvention   SignConvention
+	money            MoneyMarshaler
+	deadlines        Deadlines
+}
+
+// Option configures optional behaviour of a Client. Options are passed to NewClient.
+type Option func(*Client)
+
+// With returns a copy of the client with options applied on top of its configuration, so
+// that a subsystem can tune e.g. throttling without affecting other users of the client.
+// The copy shares the client's http.Client, and with it connections, as well as any state
+// such as throttling and drift recording that options don't replace.
+func (c *Client) With(options ...Option) *Client {
+	clone := *c
+	for _, option := range options {
+		option(&clone)
+	}
+	return &clone
+}
+
+// WithBaseURL sends requests to the given URL instead of the client's environment, e.g. to
+// a proxy or a fake server.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.environment = environmentURL(strings.TrimRight(baseURL, "/"))
+	}
 }
 
 type environmentURL string
@@ -39,57 +115,80 @@ var Production environmentURL = "https://production.plaid.com"
 var Development environmentURL = "https://development.plaid.com"
 
 type Account struct {
-	Transactions []Transaction `json:"transactions" bson:"transactions"`
-	Type         string        `json:"type"`
-	Mask         string        `json:"mask"`
-	Name         string        `json:"name"`
-	AccountID    string        `json:"account_id"`
-	Balances     struct {
-		Limit     float64 `json:"limit"`
-		Available float64 `json:"available"`
-		Current   float64 `json:"current"`
-	} `json:"balances"`
-	Subtype      string `json:"subtype"`
-	OfficialName string `json:"official_name"`
+	Transactions       []Transaction      `json:"transactions" bson:"transactions"`
+	Type               string             `json:"type"`
+	Mask               FlexString         `json:"mask"`
+	Name               string             `json:"name"`
+	AccountID          AccountID          `json:"account_id"`
+	Balances           Balances           `json:"balances"`
+	Subtype            string             `json:"subtype"`
+	OfficialName       string             `json:"official_name"`
+	VerificationStatus VerificationStatus `json:"verification_status"`
+
+	// Owners is only returned by /identity/get.
+	Owners []Owner `json:"owners,omitempty"`
+}
+
+// Balances are the balances of an Account.
+type Balances struct {
+	Limit float64 `json:"limit"`
+	// Available is 0 if Plaid doesn't know the available balance, as for many credit and
+	// investment accounts; HasAvailable tells that apart from an available balance of 0.
+	Available float64 `json:"available"`
+	Current   float64 `json:"current"`
+
+	IsoCurrencyCode        string `json:"iso_currency_code"`
+	UnofficialCurrencyCode string `json:"unofficial_currency_code"`
+
+	noAvailable bool // Plaid sent a null available balance
+}
+
+// balancesJson has the fields of Balances without its methods.
+type balancesJson Balances
+
+// HasAvailable reports whether Plaid returned an available balance.
+func (b Balances) HasAvailable() bool {
+	return !b.noAvailable
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Balances) UnmarshalJSON(data []byte) error {
+	decoded := struct {
+		*balancesJson
+		Available *float64 `json:"available"`
+	}{balancesJson: (*balancesJson)(b)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	b.Available, b.noAvailable = 0, decoded.Available == nil
+	if decoded.Available != nil {
+		b.Available = *decoded.Available
+	}
+	return nil
 }
 
-type Transaction struct {
-	PendingTransactionID string   `json:"pending_transaction_id"`
-	Name                 string   `json:"name"`
-	AccountOwner         string   `json:"account_owner"`
-	Category             []string `json:"category"`
-	TransactionType      string   `json:"transaction_type"`
-	AccountID            string   `json:"account_id"`
-	Amount               float32  `json:"amount"`
-	Date                 string   `json:"date"`
-	TransactionID        string   `json:"transaction_id"`
-	Location             struct {
-		Zip         string  `json:"zip"`
-		State       string  `json:"state"`
-		StoreNumber string  `json:"store_number"`
-		Lon         float64 `json:"lon"`
-		City        string  `json:"city"`
-		Lat         float64 `json:"lat"`
-		Address     string  `json:"address"`
-	} `json:"location"`
-	CategoryID  string `json:"category_id"`
-	Pending     bool   `json:"pending"`
-	PaymentMeta struct {
-		Reason           string `json:"reason"`
-		Payee            string `json:"payee"`
-		PpdID            string `json:"ppd_id"`
-		Payer            string `json:"payer"`
-		ByOrderOf        string `json:"by_order_of"`
-		ReferenceNumber  string `json:"reference_number"`
-		PaymentProcessor string `json:"payment_processor"`
-		PaymentMethod    string `json:"payment_method"`
-	} `json:"payment_meta"`
+// MarshalJSON implements json.Marshaler. An unknown available balance is encoded as null.
+func (b Balances) MarshalJSON() ([]byte, error) {
+	var available *float64
+	if !b.noAvailable {
+		available = &b.Available
+	}
+	return json.Marshal(struct {
+		Limit                  float64  `json:"limit"`
+		Available              *float64 `json:"available"`
+		Current                float64  `json:"current"`
+		IsoCurrencyCode        string   `json:"iso_currency_code"`
+		UnofficialCurrencyCode string   `json:"unofficial_currency_code"`
+	}{b.Limit, available, b.Current, b.IsoCurrencyCode, b.UnofficialCurrencyCode})
 }
 
+// Transaction is a transaction of an account, see transactions.Transaction.
+type Transaction = transactions.Transaction
+
 type mfaIntermediate struct {
-	AccessToken string      `json:"access_token"`
-	MFA         interface{} `json:"mfa"`
-	Type        string      `json:"type"`
+	AccessToken string          `json:"access_token"`
+	MFA         json.RawMessage `json:"mfa"`
+	Type        string          `json:"type"`
 }
 type mfaDevice struct {
 	Message string
@@ -122,17 +221,25 @@ type postResponse struct {
 	// Normal response fields
 	AccessToken       string        `json:"access_token"`
 	AccountId         string        `json:"account_id"`
-	Accounts          []Account     `json:"accounts"`
+	Accounts          Accounts      `json:"accounts"`
 	BankAccountToken  string        `json:"stripe_bank_account_token"`
 	MFA               string        `json:"mfa"`
 	Transactions      []Transaction `json:"transactions"`
 	TotalTransactions int           `json:"total_transactions"`
 	Item              Item          `json:"item"`
+	ItemID            ItemID        `json:"item_id"`
+	RequestID         string        `json:"request_id"`
+	WebhookFired      bool          `json:"webhook_fired"`
 }
 type Item struct {
-	InstitutionId string `json:"institution_id"`
-	ItemId        string `json:"item_id"`
-	Webhook       string `json:"webhook"`
+	InstitutionId string      `json:"institution_id"`
+	ItemId        ItemID      `json:"item_id"`
+	Webhook       string      `json:"webhook"`
+	Error         *plaidError `json:"error"`
+
+	// ConsentExpirationTime is the RFC 3339 time the item's access consent expires, or
+	// empty if it doesn't expire.
+	ConsentExpirationTime string `json:"consent_expiration_time"`
 }
 
 type deleteResponse struct {
@@ -153,213 +260,205 @@ func getAndUnmarshal(environment environmentURL, endpoint string, structure inte
 
 	// Successful response
 	if res.StatusCode == 200 {
-		if err = json.Unmarshal(raw, structure); err != nil {
+		if err = core.Decode(raw, structure); err != nil {
 			return err
 		}
 		return nil
 	}
 	// Attempt to unmarshal into Plaid error format
-	var plaidErr plaidError
-	if err = json.Unmarshal(raw, &plaidErr); err != nil {
-		return err
-	}
-	plaidErr.StatusCode = res.StatusCode
-	return plaidErr
+	return transport.DecodeError(res.StatusCode, raw)
 }
 
-func (c *Client) postAndUnmarshal(endpoint string,
+func (c *Client) postAndUnmarshalContext(ctx context.Context, endpoint string,
 	body io.Reader) (*postResponse, *mfaResponse, error) {
-	// Read response body
-	req, err := http.NewRequest("POST", string(c.environment)+endpoint, body)
-	if err != nil {
-		return nil, nil, err
-	}
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("User-Agent", "plaid-go")
-	res, err := c.httpClient.Do(req)
+
+	res, raw, err := c.do(ctx, "POST", endpoint, body)
 	if err != nil {
 		return nil, nil, err
 	}
-	raw, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, nil, err
+	if res.StatusCode == 200 {
+		c.drift.record(endpoint, raw, &postResponse{})
 	}
-	res.Body.Close()
-
 	return unmarshalPostMFA(res, raw)
 }
 
-func (c *Client) patchAndUnmarshal(endpoint string,
+func (c *Client) patchAndUnmarshal(ctx context.Context, endpoint string,
 	body io.Reader) (*postResponse, *mfaResponse, error) {
 
-	req, err := http.NewRequest("PATCH", string(c.environment)+endpoint, body)
+	res, raw, err := c.do(ctx, "PATCH", endpoint, body)
 	if err != nil {
 		return nil, nil, err
 	}
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("User-Agent", "plaid-go")
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, nil, err
-	}
-	raw, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return nil, nil, err
-	}
-	res.Body.Close()
-
 	return unmarshalPostMFA(res, raw)
 }
 
-func (c *Client) deleteAndUnmarshal(endpoint string,
+func (c *Client) deleteAndUnmarshal(ctx context.Context, endpoint string,
 	body io.Reader) (*deleteResponse, error) {
 
-	req, err := http.NewRequest("DELETE", string(c.environment)+endpoint, body)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("User-Agent", "plaid-go")
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	raw, err := ioutil.ReadAll(res.Body)
+	res, raw, err := c.do(ctx, "DELETE", endpoint, body)
 	if err != nil {
 		return nil, err
 	}
-	res.Body.Close()
 
 	// Successful response
 	var deleteRes deleteResponse
 	if res.StatusCode == 200 {
-		if err = json.Unmarshal(raw, &deleteRes); err != nil {
+		if err = core.Decode(raw, &deleteRes); err != nil {
 			return nil, err
 		}
 		return &deleteRes, nil
 	}
 	// Attempt to unmarshal into Plaid error format
-	var plaidErr plaidError
-	if err = json.Unmarshal(raw, &plaidErr); err != nil {
-		return nil, err
+	return nil, transport.DecodeError(res.StatusCode, raw)
+}
+
+// postAndDecode posts request as JSON to the given endpoint and decodes a successful
+// response into response. It is used by endpoints whose responses don't fit postResponse.
+func (c *Client) postAndDecode(ctx context.Context, endpoint string, request,
+	response interface{}) error {
+
+	jsonText, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	res, raw, err := c.do(ctx, "POST", endpoint, bytes.NewReader(jsonText))
+	if err != nil {
+		return err
+	}
+	if res.StatusCode == 200 {
+		c.drift.record(endpoint, raw, response)
+		return core.Decode(raw, response)
+	}
+	// Attempt to unmarshal into Plaid error format
+	return transport.DecodeError(res.StatusCode, raw)
+}
+
+// do sends a JSON request to the given endpoint and returns the response together with
+// its fully read body.
+func (c *Client) do(ctx context.Context, method, endpoint string,
+	body io.Reader) (*http.Response, []byte, error) {
+
+	if err := c.checkAvailability(endpoint); err != nil {
+		return nil, nil, err
+	}
+	var jsonText []byte
+	var token string
+	if body != nil {
+		var err error
+		if jsonText, err = ioutil.ReadAll(body); err != nil {
+			return nil, nil, err
+		}
+		if jsonText, err = c.mutate(endpoint, jsonText); err != nil {
+			return nil, nil, err
+		}
+		if c.inspectsRequests() {
+			token = requestTokenHash(jsonText)
+		}
+	}
+	if err := c.cooldown.check(token, c.clock.Now(), c.emit); err != nil {
+		return nil, nil, err
+	}
+	send := func(ctx context.Context, header http.Header) (*http.Response, []byte, error) {
+		if !c.flights.dedupes(endpoint) {
+			return c.sendWithSecondary(ctx, method, endpoint, jsonText, token, header)
+		}
+		key := c.flightKey(method, endpoint, jsonText) + " " + header.Get("If-None-Match") + " " +
+			header.Get("If-Modified-Since")
+		return c.flights.do(ctx, key, endpoint, c.emit, func(ctx context.Context) (*http.Response, []byte, error) {
+			return c.sendWithSecondary(ctx, method, endpoint, jsonText, token, header)
+		})
+	}
+	if c.responses.caches(endpoint) {
+		return c.cached(ctx, method, endpoint, jsonText, send)
 	}
-	plaidErr.StatusCode = res.StatusCode
-	return nil, plaidErr
+	return send(ctx, nil)
 }
 
-// Unmarshals response into postResponse, mfaResponse, or plaidError
+// sendWithSecondary sends a request and repeats it with the secondary secret if Plaid
+// rejected the primary one.
+func (c *Client) sendWithSecondary(ctx context.Context, method, endpoint string, jsonText []byte,
+	token string, header http.Header) (*http.Response, []byte, error) {
+
+	res, raw, err := c.send(ctx, method, endpoint, jsonText, token, header)
+	if err != nil || !rejectedKeys(res.StatusCode, raw) {
+		return res, raw, err
+	}
+
+	// Retry with the secondary secret, if there is one the request didn't use yet.
+	secondary := c.creds.Load().secondarySecret
+	if secondary == "" {
+		return res, raw, nil
+	}
+	retry, ok, err := replaceSecret(jsonText, secondary)
+	if err != nil || !ok {
+		return res, raw, err
+	}
+	c.emit(Event{Type: EventRetry, Endpoint: endpoint, Reason: "INVALID_API_KEYS"})
+	return c.send(ctx, method, endpoint, retry, token, header)
+}
+
+// send sends a single request with the given body, which may be nil, and the given
+// additional headers, which may be nil.
+func (c *Client) send(ctx context.Context, method, endpoint string, jsonText []byte,
+	token string, header http.Header) (*http.Response, []byte, error) {
+
+	req, err := transport.NewRequest(ctx, method, string(c.environment)+endpoint, jsonText, c.apiVersion,
+		header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request := SlowRequest{Method: method, Endpoint: endpoint, Start: c.clock.Now(), RequestBytes: len(jsonText)}
+
+	group := c.throttle.group(endpoint)
+	if err = group.wait(ctx, c.clock); err != nil {
+		return nil, nil, err
+	}
+	sent := c.clock.Now()
+	request.ThrottleWait = sent.Sub(request.Start)
+	res, raw, err := transport.Do(c.httpClient, req)
+	if res == nil {
+		group.record(true, endpoint, c.emit)
+		request.Duration, request.Err = c.clock.Now().Sub(sent), err
+		c.observe(ctx, request, nil, token)
+		return nil, nil, err
+	}
+	group.record(res.StatusCode == 429 || res.StatusCode >= 500, endpoint, c.emit)
+	if res.StatusCode == 429 {
+		c.emit(Event{Type: EventRateLimited, Endpoint: endpoint})
+	}
+	request.Duration, request.StatusCode, request.Err = c.clock.Now().Sub(sent), res.StatusCode, err
+	c.observe(ctx, request, raw, token)
+	if err != nil {
+		return nil, nil, err
+	}
+	recordRaw(ctx, method, endpoint, res.StatusCode, sent.Add(request.Duration), raw)
+	return res, raw, nil
+}
+
+// Unmarshals response into postResponse, mfaResponse, or plaidError. Bodies that don't
+// decode fail with a *core.DecodeError locating the offending value.
 func unmarshalPostMFA(res *http.Response, body []byte) (*postResponse, *mfaResponse, error) {
-	// Different marshaling cases
-	var mfaInter mfaIntermediate
-	var postRes postResponse
-	var err error
 	switch {
 	// Successful response
 	case res.StatusCode == 200:
-		if err = json.Unmarshal(body, &postRes); err != nil {
-
+		var postRes postResponse
+		if err := core.Decode(body, &postRes); err != nil {
 			return nil, nil, err
 		}
 		return &postRes, nil, nil
 
 	// MFA case
 	case res.StatusCode == 201:
-		if err = json.Unmarshal(body, &mfaInter); err != nil {
+		mfaRes, err := decodeMFA(body)
+		if err != nil {
 			return nil, nil, err
 		}
-		mfaRes := mfaResponse{Type: mfaInter.Type, AccessToken: mfaInter.AccessToken}
-		switch mfaInter.Type {
-		case "device":
-			temp, ok := mfaInter.MFA.(interface{})
-			if !ok {
-				return nil, nil, errors.New("Could not decode device mfa")
-			}
-			deviceStruct, ok := temp.(map[string]interface{})
-			if !ok {
-				return nil, nil, errors.New("Could not decode device mfa")
-			}
-			deviceText, ok := deviceStruct["message"].(string)
-			if !ok {
-				return nil, nil, errors.New("Could not decode device mfa")
-			}
-			mfaRes.Device.Message = deviceText
-
-		case "list":
-			temp, ok := mfaInter.MFA.([]interface{})
-			if !ok {
-				return nil, nil, errors.New("Could not decode list mfa")
-			}
-			for _, v := range temp {
-				listArray, ok := v.(map[string]interface{})
-				if !ok {
-					return nil, nil, errors.New("Could not decode list mfa")
-				}
-				maskText, ok := listArray["mask"].(string)
-				if !ok {
-					return nil, nil, errors.New("Could not decode list mfa")
-				}
-				typeText, ok := listArray["type"].(string)
-				if !ok {
-					return nil, nil, errors.New("Could not decode list mfa")
-				}
-				mfaRes.List = append(mfaRes.List, mfaList{Mask: maskText, Type: typeText})
-			}
-
-		case "questions":
-			questions, ok := mfaInter.MFA.([]interface{})
-			if !ok {
-				return nil, nil, errors.New("Could not decode questions mfa")
-			}
-			for _, v := range questions {
-				q, ok := v.(map[string]interface{})
-				if !ok {
-					return nil, nil, errors.New("Could not decode questions mfa")
-				}
-				questionText, ok := q["question"].(string)
-				if !ok {
-					return nil, nil, errors.New("Could not decode questions mfa question")
-				}
-				mfaRes.Questions = append(mfaRes.Questions, mfaQuestion{Question: questionText})
-			}
-
-		case "selections":
-			selections, ok := mfaInter.MFA.([]interface{})
-			if !ok {
-				return nil, nil, errors.New("Could not decode selections mfa")
-			}
-			for _, v := range selections {
-				s, ok := v.(map[string]interface{})
-				if !ok {
-					return nil, nil, errors.New("Could not decode selections mfa")
-				}
-				tempAnswers, ok := s["answers"].([]interface{})
-				if !ok {
-					return nil, nil, errors.New("Could not decode selections answers")
-				}
-				answers := make([]string, len(tempAnswers))
-				for i, a := range tempAnswers {
-					answers[i], ok = a.(string)
-				}
-				if !ok {
-					return nil, nil, errors.New("Could not decode selections answers")
-				}
-				question, ok := s["question"].(string)
-				if !ok {
-					return nil, nil, errors.New("Could not decode selections questions")
-				}
-				mfaRes.Selections = append(mfaRes.Selections, mfaSelection{Answers: answers, Question: question})
-			}
-		}
-		return nil, &mfaRes, nil
+		return nil, mfaRes, nil
 
 	// Error case, attempt to unmarshal into Plaid error format
 	case res.StatusCode >= 400:
-		var plaidErr plaidError
-		if err = json.Unmarshal(body, &plaidErr); err != nil {
-			return nil, nil, err
-		}
-		plaidErr.StatusCode = res.StatusCode
-		return nil, nil, plaidErr
+		return nil, nil, transport.DecodeError(res.StatusCode, body)
 	}
-	return nil, nil, errors.New("Unknown Plaid Error - Status:" + string(res.StatusCode))
+	return nil, nil, errors.New("Unknown Plaid Error - Status:" + strconv.Itoa(res.StatusCode))
 }
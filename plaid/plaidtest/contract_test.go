@@ -0,0 +1,273 @@
+//go:build sandbox
+// +build sandbox
+
+package plaidtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid"
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// This file is only built with the sandbox build tag, e.g.
+//
+//	PLAID_CLIENT_ID=... PLAID_SECRET=... PLAID_ENV=sandbox go test -tags sandbox ./plaid/plaidtest
+//
+// It exercises the client against the real Plaid sandbox to catch differences between
+// the package's request and response structs and what Plaid actually accepts and sends.
+// Response fields the structs don't capture yet are reported with t.Log.
+
+// sandboxClient returns a client for the Plaid sandbox configured through the environment
+// as described by plaid.NewClientFromEnv, and skips the test if no credentials are set.
+func sandboxClient(t *testing.T, options ...plaid.Option) *plaid.Client {
+	t.Helper()
+	if os.Getenv("PLAID_CLIENT_ID") == "" || os.Getenv("PLAID_SECRET") == "" {
+		t.Skip("PLAID_CLIENT_ID and PLAID_SECRET are not set")
+	}
+	if os.Getenv("PLAID_ENV") != "sandbox" {
+		t.Fatal("contract tests must run with PLAID_ENV=sandbox")
+	}
+	c, err := plaid.NewClientFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.With(options...)
+}
+
+// TestSandboxContract creates a sandbox item and runs it through the client's surface,
+// checking that every response decodes and carries the fields the package relies on. The
+// item is removed at the end.
+func TestSandboxContract(t *testing.T) {
+	drift := plaid.NewDriftRecorder()
+	c := sandboxClient(t, plaid.WithDriftRecorder(drift))
+	ctx := context.Background()
+	defer func() {
+		for _, field := range drift.Report() {
+			t.Logf("%s: field %s not captured by the response structs", field.Endpoint, field.Path)
+		}
+	}()
+
+	tokenRes, err := c.SandboxPublicTokenCreateContext(ctx, "ins_109508",
+		[]string{"transactions", "auth", "identity", "assets"})
+	if err != nil {
+		t.Fatalf("/sandbox/public_token/create: %v", err)
+	}
+	if tokenRes.PublicToken == "" {
+		t.Fatal("/sandbox/public_token/create: missing public_token")
+	}
+	exchangeRes, err := c.ItemPublicTokenExchangeContext(ctx, tokenRes.PublicToken)
+	if err != nil {
+		t.Fatalf("/item/public_token/exchange: %v", err)
+	}
+	if exchangeRes.AccessToken == "" || exchangeRes.ItemID == "" {
+		t.Fatal("/item/public_token/exchange: missing access_token or item_id")
+	}
+	accessToken := exchangeRes.AccessToken
+	defer func() {
+		if _, err := c.ItemRemoveContext(ctx, accessToken); err != nil {
+			t.Errorf("/item/remove: %v", err)
+		}
+	}()
+
+	var accounts []plaid.Account
+	run := func(endpoint string, check func() error) {
+		t.Run(endpoint, func(t *testing.T) {
+			if err := check(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	run("/item/get", func() error {
+		res, err := c.ItemGetContext(ctx, accessToken)
+		if err == nil && res.Item.ItemId != exchangeRes.ItemID {
+			err = fmt.Errorf("item_id %q doesn't match exchanged item %q", res.Item.ItemId, exchangeRes.ItemID)
+		}
+		return err
+	})
+	run("/accounts/get", func() error {
+		res, err := c.AccountsContext(ctx, accessToken)
+		if err != nil {
+			return err
+		}
+		accounts = res.Accounts
+		return checkAccounts(res.Accounts)
+	})
+	run("/accounts/balance/get", func() error {
+		res, err := c.BalanceContext(ctx, accessToken)
+		if err != nil {
+			return err
+		}
+		return checkAccounts(res.Accounts)
+	})
+	run("/auth/get", func() error {
+		res, err := c.AuthGetContext(ctx, accessToken)
+		if err != nil {
+			return err
+		}
+		return checkAccounts(res.Accounts)
+	})
+	run("/identity/get", func() error {
+		res, err := c.IdentityGetContext(ctx, accessToken)
+		if err != nil {
+			return err
+		}
+		for _, account := range res.Accounts {
+			if len(account.Owners) == 0 {
+				return fmt.Errorf("account %s has no owners", account.AccountID)
+			}
+		}
+		return checkAccounts(res.Accounts)
+	})
+	run("/item/webhook/update", func() error {
+		_, err := c.ItemWebhookUpdateContext(ctx, accessToken, "https://www.example.com/webhook")
+		return err
+	})
+	run("/sandbox/item/fire_webhook", func() error {
+		res, err := c.SandboxItemFireWebhookContext(ctx, accessToken, "DEFAULT_UPDATE")
+		if err == nil && !res.WebhookFired {
+			err = errors.New("webhook_fired is false")
+		}
+		return err
+	})
+	run("/item/application/list", func() error {
+		_, err := c.ItemApplicationListContext(ctx, accessToken)
+		return err
+	})
+	run("/transactions/get", func() error {
+		return checkTransactions(ctx, c, accessToken)
+	})
+	run("/transactions/sync", func() error {
+		res, err := c.TransactionsSyncAllContext(ctx, accessToken, "")
+		if err != nil {
+			return err
+		}
+		if res.NextCursor == "" {
+			return errors.New("missing next_cursor")
+		}
+		return checkTransactionFields(res.Added)
+	})
+	run("/transactions/recurring/get", func() error {
+		_, err := c.TransactionsRecurringGetContext(ctx, accessToken, nil)
+		if isErrorCode(err, "PRODUCT_NOT_READY") || isErrorCode(err, "PRODUCTS_NOT_SUPPORTED") {
+			return nil
+		}
+		return err
+	})
+	run("/processor/token/create", func() error {
+		if len(accounts) == 0 {
+			return errors.New("no accounts to create a processor token for")
+		}
+		token, err := c.ProcessorTokenCreateContext(ctx, accessToken, accounts[0].AccountID, plaid.ProcessorDwolla)
+		if err == nil && token == "" {
+			err = errors.New("missing processor_token")
+		}
+		return err
+	})
+	run("/asset_report/create", func() error {
+		res, err := c.AssetReportCreateContext(ctx, []string{accessToken}, 30, nil)
+		if err == nil && (res.AssetReportToken == "" || res.AssetReportID == "") {
+			err = errors.New("missing asset_report_token or asset_report_id")
+		}
+		return err
+	})
+	run("/categories/get", func() error {
+		categories, err := c.CategoriesGetContext(ctx)
+		if err == nil && (len(categories) == 0 || categories[0].CategoryID == "") {
+			err = errors.New("no categories or category without category_id")
+		}
+		return err
+	})
+	run("/institutions/get", func() error {
+		institutions, total, err := c.InstitutionsGetContext(ctx, 10, 0, []string{"US"}, nil)
+		if err == nil && (len(institutions) == 0 || total < len(institutions)) {
+			err = fmt.Errorf("got %d institutions of a total of %d", len(institutions), total)
+		}
+		return err
+	})
+	run("/institutions/get_by_id", func() error {
+		res, err := c.InstitutionGetByIDContext(ctx, "ins_109508", []string{"US"},
+			&plaid.InstitutionOptions{IncludeOptionalMetadata: true, IncludeStatus: true})
+		if err == nil && res.Institution.InstitutionID != "ins_109508" {
+			err = fmt.Errorf("got institution %q", res.Institution.InstitutionID)
+		}
+		return err
+	})
+	run("/link/token/create", func() error {
+		res, err := c.LinkTokenCreateContext(ctx, "Contract", "en", []string{"US"},
+			plaid.LinkUser{ClientUserID: "contract-user"}, []string{"transactions"}, nil)
+		if err != nil {
+			return err
+		}
+		token, err := c.LinkTokenGetContext(ctx, res.LinkToken)
+		if err == nil && token.LinkToken != res.LinkToken {
+			err = fmt.Errorf("/link/token/get returned %q for %q", token.LinkToken, res.LinkToken)
+		}
+		return err
+	})
+	run("/sandbox/transfer/test_clock/create", func() error {
+		clock, err := c.SandboxTransferTestClockCreateContext(ctx, time.Now())
+		if err != nil {
+			if isErrorCode(err, "INVALID_PRODUCT") {
+				return nil // Transfer isn't enabled for every sandbox account.
+			}
+			return err
+		}
+		_, err = c.SandboxTransferTestClockGetContext(ctx, clock.TestClockID)
+		return err
+	})
+}
+
+func checkAccounts(accounts []plaid.Account) error {
+	if len(accounts) == 0 {
+		return errors.New("no accounts returned")
+	}
+	for _, account := range accounts {
+		if account.AccountID == "" || account.Type == "" {
+			return fmt.Errorf("account %+v is missing account_id or type", account)
+		}
+	}
+	return nil
+}
+
+func checkTransactionFields(transactions []plaid.Transaction) error {
+	for _, t := range transactions {
+		if t.TransactionID == "" || t.AccountID == "" || t.Date == "" {
+			return fmt.Errorf("transaction %+v is missing transaction_id, account_id or date", t)
+		}
+	}
+	return nil
+}
+
+// checkTransactions fetches transactions, waiting for the initial pull of a fresh item.
+func checkTransactions(ctx context.Context, c *plaid.Client, accessToken string) error {
+	end := time.Now()
+	start := end.AddDate(0, -1, 0)
+	for attempt := 0; ; attempt++ {
+		res, err := c.TransactionsContext(ctx, accessToken, start.Format("2006-01-02"),
+			end.Format("2006-01-02"), plaid.TransactionOptionsJson{Count: 100})
+		if err != nil {
+			if attempt < 10 && isErrorCode(err, "PRODUCT_NOT_READY") {
+				time.Sleep(3 * time.Second)
+				continue
+			}
+			return err
+		}
+		if res.TotalTransactions < len(res.Transactions) {
+			return fmt.Errorf("total_transactions %d is less than the %d returned", res.TotalTransactions, len(res.Transactions))
+		}
+		return checkTransactionFields(res.Transactions)
+	}
+}
+
+// isErrorCode reports whether err is a Plaid error with the given error code.
+func isErrorCode(err error, code string) bool {
+	var plaidErr core.Error
+	return errors.As(err, &plaidErr) && plaidErr.ErrorCode == code
+}
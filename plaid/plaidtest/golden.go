@@ -0,0 +1,193 @@
+package plaidtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+// GoldenCase is a call of one endpoint whose request and decoded response are checked
+// against golden files, see Golden.
+type GoldenCase struct {
+	// Name names the case's files in the golden directory: Name.request.json is the request
+	// body the call must send, Name.response.json the recorded response the server replies
+	// with and Name.decoded.json the call's result encoded as JSON.
+	Name string
+	// Method defaults to POST.
+	Method   string
+	Endpoint string
+	// Call makes exactly one request with c and returns its decoded result.
+	Call func(ctx context.Context, c *plaid.Client) (interface{}, error)
+}
+
+// GoldenResult is the outcome of checking one GoldenCase.
+type GoldenResult struct {
+	Case     string
+	Endpoint string
+	Err      error
+}
+
+// GoldenMismatchError is returned when a request or decoded response differs from its
+// golden file.
+type GoldenMismatchError struct {
+	File string
+	// Line is the first line that differs, counting from 1.
+	Line      int
+	Want, Got string
+}
+
+func (e *GoldenMismatchError) Error() string {
+	return fmt.Sprintf("%s differs at line %d: want %q, got %q", e.File, e.Line, e.Want, e.Got)
+}
+
+// Golden checks the wire format of the client against golden files in Dir, so that a
+// refactoring of the request layer, such as an omitempty added or removed or options
+// encoded differently, fails a check instead of silently changing what is sent to Plaid.
+// Responses are checked as well, by decoding recorded responses and comparing the results.
+//
+// Every case runs against a server of its own that records the request and replies with
+// the case's recorded response, or with an empty response if none was recorded. Typical use
+// in a test, as in this package's own wire format test covering every endpoint:
+//
+//	var update = flag.Bool("update", false, "rewrite the golden files")
+//
+//	func TestWireFormat(t *testing.T) {
+//		golden := plaidtest.Golden{Dir: "testdata/golden", Update: *update}
+//		for _, result := range golden.Run(context.Background(), cases) {
+//			if result.Err != nil {
+//				t.Errorf("%s: %v", result.Case, result.Err)
+//			}
+//		}
+//	}
+type Golden struct {
+	Dir string
+	// Update writes what the client sends and decodes now to the request and decoded golden
+	// files instead of comparing against them. Review the diff before committing it.
+	Update bool
+	// Options configure the client the cases run with. Availability checks are disabled,
+	// since the cases check the wire format rather than the environment, and so are the
+	// warnings about the deprecated methods the cases call.
+	Options []plaid.Option
+}
+
+// goldenResponse is the response of cases without a recorded one.
+const goldenResponse = `{"request_id": "golden"}`
+
+// Run checks the cases and returns one result per case.
+func (g Golden) Run(ctx context.Context, cases []GoldenCase) []GoldenResult {
+	results := make([]GoldenResult, len(cases))
+	for i, gc := range cases {
+		results[i] = GoldenResult{Case: gc.Name, Endpoint: gc.Endpoint, Err: g.check(ctx, gc)}
+	}
+	return results
+}
+
+// goldenRequest is a request received while running a case.
+type goldenRequest struct {
+	method, endpoint string
+	body             []byte
+}
+
+func (g Golden) check(ctx context.Context, gc GoldenCase) error {
+	response, err := os.ReadFile(g.path(gc, "response"))
+	if errors.Is(err, fs.ErrNotExist) {
+		response = []byte(goldenResponse)
+	} else if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var received []goldenRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received = append(received, goldenRequest{method: r.Method, endpoint: r.URL.Path, body: body})
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	options := append([]plaid.Option{plaid.WithoutAvailabilityCheck(), plaid.WithLogger(nil)}, g.Options...)
+	options = append(options, plaid.WithBaseURL(server.URL))
+	result, err := gc.Call(ctx, plaid.NewClient("test_id", "test_secret", plaid.Sandbox, options...))
+	if err != nil {
+		return fmt.Errorf("call failed: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 {
+		return fmt.Errorf("call made %d requests, want 1", len(received))
+	}
+	method := gc.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	if sent := received[0]; sent.method != method || sent.endpoint != gc.Endpoint {
+		return fmt.Errorf("call sent %s %s, want %s %s", sent.method, sent.endpoint, method, gc.Endpoint)
+	}
+	var request bytes.Buffer
+	if err = json.Indent(&request, received[0].body, "", "  "); err != nil {
+		return fmt.Errorf("request body isn't JSON: %w", err)
+	}
+	request.WriteByte('\n')
+	decoded, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't encode the result: %w", err)
+	}
+
+	if err = g.compare(g.path(gc, "request"), request.Bytes()); err != nil {
+		return err
+	}
+	return g.compare(g.path(gc, "decoded"), append(decoded, '\n'))
+}
+
+func (g Golden) path(gc GoldenCase, kind string) string {
+	return filepath.Join(g.Dir, gc.Name+"."+kind+".json")
+}
+
+// compare compares got with the golden file at path, or writes it there in update mode.
+func (g Golden) compare(path string, got []byte) error {
+	if g.Update {
+		if err := os.MkdirAll(g.Dir, 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, got, 0o644)
+	}
+	want, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s doesn't exist; run with Update to create it", path)
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(want, got) {
+		return nil
+	}
+	wantLines, gotLines := strings.Split(string(want), "\n"), strings.Split(string(got), "\n")
+	for i := 0; ; i++ {
+		var wantLine, gotLine string
+		if i < len(wantLines) {
+			wantLine = wantLines[i]
+		}
+		if i < len(gotLines) {
+			gotLine = gotLines[i]
+		}
+		if wantLine != gotLine || i >= len(wantLines) || i >= len(gotLines) {
+			return &GoldenMismatchError{File: path, Line: i + 1, Want: wantLine, Got: gotLine}
+		}
+	}
+}
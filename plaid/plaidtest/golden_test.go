@@ -0,0 +1,428 @@
+package plaidtest
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// TestWireFormat checks the request and decoded response of every endpoint against the
+// golden files. Run it with -update after an intended change of the wire format, and review
+// the diff of testdata/golden.
+func TestWireFormat(t *testing.T) {
+	golden := Golden{Dir: "testdata/golden", Update: *update}
+	for _, result := range golden.Run(context.Background(), goldenCases) {
+		if result.Err != nil {
+			t.Errorf("%s: %v", result.Case, result.Err)
+		}
+	}
+}
+
+// The fixed arguments of goldenCases.
+const (
+	goldenAccessToken = "access-sandbox-golden"
+	goldenAccountID   = plaid.AccountID("golden-account")
+	goldenReportToken = "assets-sandbox-golden"
+	goldenAuditCopy   = "a-sandbox-golden"
+)
+
+var goldenTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// goldenCases covers every endpoint the client sends requests to, with fixed arguments. An
+// endpoint whose request has options has a case without them and an "_options" case with
+// every option set, so that both the omitted and the encoded options are checked. Legacy
+// endpoints, which may answer with MFA, decode into a "response" and an "mfa" member.
+var goldenCases = []GoldenCase{
+	// Items
+	{Name: "item_get", Endpoint: "/item/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ItemGetContext(ctx, goldenAccessToken)
+	}},
+	{Name: "item_remove", Endpoint: "/item/remove", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ItemRemoveContext(ctx, goldenAccessToken)
+	}},
+	{Name: "item_webhook_update", Endpoint: "/item/webhook/update", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ItemWebhookUpdateContext(ctx, goldenAccessToken, "https://example.com/webhook")
+	}},
+	{Name: "item_public_token_exchange", Endpoint: "/item/public_token/exchange", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ItemPublicTokenExchangeContext(ctx, "public-sandbox-golden")
+	}},
+	{Name: "exchange_token_account", Endpoint: "/exchange_token", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ExchangeTokenAccountContext(ctx, "public-sandbox-golden", goldenAccountID)
+	}},
+	{Name: "item_application_list", Endpoint: "/item/application/list", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ItemApplicationListContext(ctx, goldenAccessToken)
+	}},
+	{Name: "item_application_scopes_update", Endpoint: "/item/application/scopes/update", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		yes := true
+		scopes := plaid.ApplicationScopes{
+			ProductAccess: &plaid.ProductAccess{Transactions: &yes},
+			Accounts:      []plaid.AccountAccess{{UniqueID: string(goldenAccountID), Authorized: true}},
+			NewAccounts:   &yes,
+		}
+		return nil, c.ItemApplicationScopesUpdateContext(ctx, goldenAccessToken, "golden-application", scopes,
+			plaid.ScopesContextPortal, "golden-state")
+	}},
+
+	// Link
+	{Name: "link_token_create", Endpoint: "/link/token/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.LinkTokenCreateContext(ctx, "Golden", "en", []string{"US"}, plaid.LinkUser{ClientUserID: "golden-user"},
+			[]string{"transactions"}, nil)
+	}},
+	{Name: "link_token_create_options", Endpoint: "/link/token/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		verified := goldenTime
+		user := plaid.LinkUser{
+			ClientUserID:             "golden-user",
+			LegalName:                "Leslie Knope",
+			PhoneNumber:              "+14155550123",
+			PhoneNumberVerifiedTime:  &verified,
+			EmailAddress:             "leslie@example.com",
+			EmailAddressVerifiedTime: &verified,
+			DateOfBirth:              "1975-01-18",
+			Address:                  &plaid.UserAddress{Street: "123 Main St.", City: "Pawnee", Region: "IN", PostalCode: "46001", Country: "US"},
+		}
+		return c.LinkTokenCreateContext(ctx, "Golden", "en", []string{"US"}, user, []string{"transactions"},
+			&plaid.LinkTokenCreateOptions{
+				Webhook:                     "https://example.com/webhook",
+				RedirectURI:                 "https://example.com/oauth",
+				LinkCustomizationName:       "golden",
+				EnableMultiItemLink:         true,
+				TransferIntentID:            "golden-intent",
+				RequiredIfSupportedProducts: []string{"identity"},
+				OptionalProducts:            []string{"auth"},
+				AdditionalConsentedProducts: []string{"liabilities"},
+			})
+	}},
+	{Name: "link_token_create_update", Endpoint: "/link/token/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.LinkTokenCreateContext(ctx, "Golden", "en", []string{"US"}, plaid.LinkUser{ClientUserID: "golden-user"},
+			nil, &plaid.LinkTokenCreateOptions{AccessToken: goldenAccessToken})
+	}},
+	{Name: "link_token_get", Endpoint: "/link/token/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.LinkTokenGetContext(ctx, "link-sandbox-golden")
+	}},
+
+	// Accounts, balances, auth and identity
+	{Name: "accounts_get", Endpoint: "/accounts/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AccountsContext(ctx, goldenAccessToken)
+	}},
+	{Name: "accounts_balance_get", Endpoint: "/accounts/balance/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.BalanceContext(ctx, goldenAccessToken)
+	}},
+	{Name: "auth_get", Endpoint: "/auth/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AuthGetContext(ctx, goldenAccessToken)
+	}},
+	{Name: "identity_get", Endpoint: "/identity/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.IdentityGetContext(ctx, goldenAccessToken)
+	}},
+
+	// Transactions
+	{Name: "transactions_get", Endpoint: "/transactions/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransactionsContext(ctx, goldenAccessToken, "2024-01-01", "2024-01-31", plaid.TransactionOptionsJson{})
+	}},
+	{Name: "transactions_get_options", Endpoint: "/transactions/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransactionsContext(ctx, goldenAccessToken, "2024-01-01", "2024-01-31", plaid.TransactionOptionsJson{
+			Count:                          100,
+			Offset:                         200,
+			IncludeOriginalDescription:     true,
+			IncludePersonalFinanceCategory: true,
+		})
+	}},
+	{Name: "transactions_sync", Endpoint: "/transactions/sync", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransactionsSyncContext(ctx, goldenAccessToken, "", 0)
+	}},
+	{Name: "transactions_sync_options", Endpoint: "/transactions/sync", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransactionsSyncContext(ctx, goldenAccessToken, "golden-cursor", 500)
+	}},
+	{Name: "transactions_recurring_get", Endpoint: "/transactions/recurring/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransactionsRecurringGetContext(ctx, goldenAccessToken, nil)
+	}},
+	{Name: "transactions_recurring_get_options", Endpoint: "/transactions/recurring/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransactionsRecurringGetContext(ctx, goldenAccessToken, []plaid.AccountID{goldenAccountID})
+	}},
+	{Name: "categories_get", Endpoint: "/categories/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.CategoriesGetContext(ctx)
+	}},
+
+	// Institutions
+	{Name: "institutions_get", Endpoint: "/institutions/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		institutions, total, err := c.InstitutionsGetContext(ctx, 10, 0, []string{"US"}, nil)
+		return map[string]interface{}{"institutions": institutions, "total": total}, err
+	}},
+	{Name: "institutions_get_options", Endpoint: "/institutions/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		oauth := true
+		institutions, total, err := c.InstitutionsGetContext(ctx, 10, 20, []string{"US", "CA"}, &plaid.InstitutionsGetOptions{
+			Products:                []string{"transactions"},
+			RoutingNumbers:          []string{"011000138"},
+			OAuth:                   &oauth,
+			IncludeOptionalMetadata: true,
+			IncludeAuthMetadata:     true,
+		})
+		return map[string]interface{}{"institutions": institutions, "total": total}, err
+	}},
+	{Name: "institutions_get_by_id", Endpoint: "/institutions/get_by_id", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.InstitutionGetByIDContext(ctx, "ins_109508", []string{"US"}, nil)
+	}},
+	{Name: "institutions_get_by_id_options", Endpoint: "/institutions/get_by_id", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.InstitutionGetByIDContext(ctx, "ins_109508", []string{"US"}, &plaid.InstitutionOptions{
+			IncludeOptionalMetadata: true,
+			IncludeStatus:           true,
+			IncludeAuthMetadata:     true,
+		})
+	}},
+
+	// Assets
+	{Name: "asset_report_create", Endpoint: "/asset_report/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AssetReportCreateContext(ctx, []string{goldenAccessToken}, 60, nil)
+	}},
+	{Name: "asset_report_create_options", Endpoint: "/asset_report/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AssetReportCreateContext(ctx, []string{goldenAccessToken}, 60, &plaid.AssetReportCreateOptions{
+			ClientReportID:    "golden-report",
+			Webhook:           "https://example.com/webhook",
+			IncludeFastReport: true,
+			User: &plaid.AssetReportUser{
+				ClientUserID: "golden-user",
+				FirstName:    "Leslie",
+				MiddleName:   "Barbara",
+				LastName:     "Knope",
+				SSN:          "123-45-6789",
+				PhoneNumber:  "+14155550123",
+				Email:        "leslie@example.com",
+			},
+		})
+	}},
+	{Name: "asset_report_get", Endpoint: "/asset_report/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AssetReportGetContext(ctx, goldenReportToken, nil)
+	}},
+	{Name: "asset_report_get_options", Endpoint: "/asset_report/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AssetReportGetContext(ctx, goldenReportToken, &plaid.AssetReportGetOptions{IncludeInsights: true, FastReport: true})
+	}},
+	{Name: "asset_report_pdf_get", Endpoint: "/asset_report/pdf/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AssetReportPDFGetContext(ctx, goldenReportToken)
+	}},
+	{Name: "asset_report_audit_copy_create", Endpoint: "/asset_report/audit_copy/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AssetReportAuditCopyCreateContext(ctx, goldenReportToken, "fannie_mae")
+	}},
+	{Name: "asset_report_audit_copy_get", Endpoint: "/asset_report/audit_copy/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AssetReportAuditCopyGetContext(ctx, goldenAuditCopy)
+	}},
+	{Name: "asset_report_audit_copy_remove", Endpoint: "/asset_report/audit_copy/remove", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AssetReportAuditCopyRemoveContext(ctx, goldenAuditCopy)
+	}},
+
+	// Processors
+	{Name: "processor_token_create", Endpoint: "/processor/token/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ProcessorTokenCreateContext(ctx, goldenAccessToken, goldenAccountID, plaid.ProcessorDwolla)
+	}},
+	{Name: "processor_stripe_bank_account_token_create", Endpoint: "/processor/stripe/bank_account_token/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.StripeBankAccountTokenCreateContext(ctx, goldenAccessToken, goldenAccountID)
+	}},
+
+	// Signal
+	{Name: "signal_evaluate", Endpoint: "/signal/evaluate", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.SignalEvaluateContext(ctx, goldenAccessToken, goldenAccountID, "golden-transaction", 12.34, nil)
+	}},
+	{Name: "signal_evaluate_options", Endpoint: "/signal/evaluate", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		yes, no := true, false
+		return c.SignalEvaluateContext(ctx, goldenAccessToken, goldenAccountID, "golden-transaction", 12.34,
+			&plaid.SignalEvaluateOptions{
+				UserPresent:          &yes,
+				ClientUserID:         "golden-user",
+				IsRecurring:          &no,
+				DefaultPaymentMethod: "STANDARD_ACH",
+				User:                 &plaid.SignalUser{PhoneNumber: "+14155550123", EmailAddress: "leslie@example.com"},
+				Device:               &plaid.SignalDevice{IPAddress: "192.0.2.1", UserAgent: "golden"},
+				RulesetKey:           "golden-ruleset",
+			})
+	}},
+	{Name: "processor_signal_evaluate", Endpoint: "/processor/signal/evaluate", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ProcessorSignalEvaluateContext(ctx, "processor-sandbox-golden", "golden-transaction", 12.34, nil)
+	}},
+	{Name: "signal_prepare", Endpoint: "/signal/prepare", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return nil, c.SignalPrepareContext(ctx, goldenAccessToken)
+	}},
+
+	// Transfer
+	{Name: "transfer_intent_create", Endpoint: "/transfer/intent/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransferIntentCreateContext(ctx, plaid.TransferIntentPayment, 12.34, "Golden",
+			plaid.TransferUser{LegalName: "Leslie Knope"}, nil)
+	}},
+	{Name: "transfer_intent_create_options", Endpoint: "/transfer/intent/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransferIntentCreateContext(ctx, plaid.TransferIntentDisbursement, 12.34, "Golden",
+			plaid.TransferUser{LegalName: "Leslie Knope", PhoneNumber: "+14155550123", EmailAddress: "leslie@example.com"},
+			&plaid.TransferIntentCreateOptions{
+				AccountID:            goldenAccountID,
+				ACHClass:             "ppd",
+				OriginationAccountID: "golden-origination",
+				Metadata:             map[string]string{"order": "golden"},
+				IsoCurrencyCode:      "USD",
+				RequireGuarantee:     true,
+			})
+	}},
+	{Name: "transfer_intent_get", Endpoint: "/transfer/intent/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.TransferIntentGetContext(ctx, "golden-intent")
+	}},
+
+	// Identity verification and users
+	{Name: "user_create", Endpoint: "/user/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.UserCreateContext(ctx, "golden-user")
+	}},
+	{Name: "identity_verification_create", Endpoint: "/identity_verification/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.IdentityVerificationCreateContext(ctx, "idvtmp_golden", "golden-user", plaid.IdentityVerificationUser{}, nil)
+	}},
+	{Name: "identity_verification_create_options", Endpoint: "/identity_verification/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		user := plaid.IdentityVerificationUser{
+			EmailAddress: "leslie@example.com",
+			PhoneNumber:  "+14155550123",
+			DateOfBirth:  "1975-01-18",
+			Name:         &plaid.IdentityVerificationName{GivenName: "Leslie", FamilyName: "Knope"},
+			Address:      &plaid.UserAddress{Street: "123 Main St.", City: "Pawnee", Region: "IN", PostalCode: "46001", Country: "US"},
+			IDNumber:     &plaid.IdentityVerificationID{Value: "123456789", Type: "us_ssn"},
+		}
+		return c.IdentityVerificationCreateContext(ctx, "idvtmp_golden", "golden-user", user,
+			&plaid.IdentityVerificationCreateOptions{IsShareable: true, GaveConsent: true, IsIdempotent: true})
+	}},
+	{Name: "identity_verification_get", Endpoint: "/identity_verification/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.IdentityVerificationGetContext(ctx, "idv_golden")
+	}},
+
+	// Webhooks
+	{Name: "webhook_verification_key_get", Endpoint: "/webhook_verification_key/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.WebhookVerificationKeyGetContext(ctx, "golden-key")
+	}},
+
+	// Sandbox
+	{Name: "sandbox_public_token_create", Endpoint: "/sandbox/public_token/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.SandboxPublicTokenCreateContext(ctx, "ins_109508", []string{"transactions"})
+	}},
+	{Name: "sandbox_public_token_create_options", Endpoint: "/sandbox/public_token/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.SandboxPublicTokenCreateWithOptionsContext(ctx, "ins_109508", []string{"income_verification"},
+			&plaid.SandboxPublicTokenCreateOptions{
+				OverrideUsername:        plaid.SandboxBankIncomeUsername,
+				OverridePassword:        plaid.SandboxBankIncomePassword,
+				Webhook:                 "https://example.com/webhook",
+				UserToken:               "user-sandbox-golden",
+				IncomeSourceTypes:       []string{"bank"},
+				BankIncomeDaysRequested: 90,
+			})
+	}},
+	{Name: "sandbox_item_fire_webhook", Endpoint: "/sandbox/item/fire_webhook", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.SandboxItemFireWebhookContext(ctx, goldenAccessToken, "DEFAULT_UPDATE")
+	}},
+	{Name: "sandbox_income_fire_webhook", Endpoint: "/sandbox/income/fire_webhook", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return nil, c.SandboxIncomeFireWebhookContext(ctx, "golden-user-id", "golden-item", "https://example.com/webhook",
+			plaid.IncomeVerificationProcessingComplete)
+	}},
+	{Name: "sandbox_transfer_simulate", Endpoint: "/sandbox/transfer/simulate", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return nil, c.SandboxTransferSimulateContext(ctx, "golden-transfer", plaid.TransferEventPosted, nil)
+	}},
+	{Name: "sandbox_transfer_simulate_options", Endpoint: "/sandbox/transfer/simulate", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return nil, c.SandboxTransferSimulateContext(ctx, "golden-transfer", plaid.TransferEventReturned,
+			&plaid.SandboxTransferSimulateOptions{
+				FailureReason: &plaid.TransferFailureReason{ACHReturnCode: "R01", Description: "Insufficient funds"},
+				TestClockID:   "golden-clock",
+				Webhook:       "https://example.com/webhook",
+			})
+	}},
+	{Name: "sandbox_transfer_sweep_simulate", Endpoint: "/sandbox/transfer/sweep/simulate", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.SandboxTransferSweepSimulateContext(ctx, "golden-clock", "https://example.com/webhook")
+	}},
+	{Name: "sandbox_transfer_fire_webhook", Endpoint: "/sandbox/transfer/fire_webhook", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return nil, c.SandboxTransferFireWebhookContext(ctx, "https://example.com/webhook")
+	}},
+	{Name: "sandbox_transfer_test_clock_create", Endpoint: "/sandbox/transfer/test_clock/create", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.SandboxTransferTestClockCreateContext(ctx, goldenTime)
+	}},
+	{Name: "sandbox_transfer_test_clock_advance", Endpoint: "/sandbox/transfer/test_clock/advance", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return nil, c.SandboxTransferTestClockAdvanceContext(ctx, "golden-clock", goldenTime.Add(24*time.Hour))
+	}},
+	{Name: "sandbox_transfer_test_clock_get", Endpoint: "/sandbox/transfer/test_clock/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.SandboxTransferTestClockGetContext(ctx, "golden-clock")
+	}},
+	{Name: "sandbox_transfer_test_clock_list", Endpoint: "/sandbox/transfer/test_clock/list", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.SandboxTransferTestClockListContext(ctx, goldenTime, goldenTime.Add(24*time.Hour), 25, 0)
+	}},
+
+	// Legacy endpoints
+	{Name: "connect", Endpoint: "/connect", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.ConnectAddUserContext(ctx, "plaid_test", "plaid_good", "", "citi", nil))
+	}},
+	{Name: "connect_options", Endpoint: "/connect", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.ConnectAddUserContext(ctx, "plaid_test", "plaid_good", "1234", "usaa", &plaid.ConnectOptions{
+			Webhook:   "https://example.com/webhook",
+			Pending:   true,
+			LoginOnly: true,
+			List:      true,
+			StartDate: "2024-01-01",
+			EndDate:   "2024-01-31",
+		}))
+	}},
+	{Name: "connect_step_send_method", Endpoint: "/connect/step", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.ConnectStepSendMethodContext(ctx, goldenAccessToken, "type", "email"))
+	}},
+	{Name: "connect_step", Endpoint: "/connect/step", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.ConnectStepContext(ctx, goldenAccessToken, "tomato"))
+	}},
+	{Name: "connect_get", Endpoint: "/connect/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.ConnectGetContext(ctx, goldenAccessToken, nil))
+	}},
+	{Name: "connect_get_options", Endpoint: "/connect/get", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.ConnectGetContext(ctx, goldenAccessToken, &plaid.ConnectGetOptions{
+			Pending: true,
+			Account: string(goldenAccountID),
+			GTE:     "2024-01-01",
+			LTE:     "2024-01-31",
+		}))
+	}},
+	{Name: "connect_update", Method: http.MethodPatch, Endpoint: "/connect", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.ConnectUpdateContext(ctx, "plaid_test", "plaid_good", "", goldenAccessToken))
+	}},
+	{Name: "connect_update_step", Method: http.MethodPatch, Endpoint: "/connect/step", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.ConnectUpdateStepContext(ctx, "plaid_test", "plaid_good", "", "tomato", goldenAccessToken))
+	}},
+	{Name: "connect_delete", Method: http.MethodDelete, Endpoint: "/connect", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.ConnectDeleteContext(ctx, goldenAccessToken)
+	}},
+	{Name: "auth", Endpoint: "/auth", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.AuthAddUserContext(ctx, "plaid_test", "plaid_good", "", "citi", nil))
+	}},
+	{Name: "auth_options", Endpoint: "/auth", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.AuthAddUserContext(ctx, "plaid_test", "plaid_good", "1234", "usaa", &plaid.AuthOptions{List: true}))
+	}},
+	{Name: "auth_step_send_method", Endpoint: "/auth/step", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.AuthStepSendMethodContext(ctx, goldenAccessToken, "type", "email"))
+	}},
+	{Name: "auth_step", Endpoint: "/auth/step", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.AuthStepContext(ctx, goldenAccessToken, "tomato"))
+	}},
+	{Name: "auth_update", Method: http.MethodPatch, Endpoint: "/auth", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.AuthUpdateContext(ctx, "plaid_test", "plaid_good", "", goldenAccessToken))
+	}},
+	{Name: "auth_update_step", Method: http.MethodPatch, Endpoint: "/auth/step", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.AuthUpdateStepContext(ctx, "plaid_test", "plaid_good", "", "tomato", goldenAccessToken))
+	}},
+	{Name: "auth_delete", Method: http.MethodDelete, Endpoint: "/auth", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return c.AuthDeleteContext(ctx, goldenAccessToken)
+	}},
+	{Name: "upgrade", Endpoint: "/upgrade", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.UpgradeContext(ctx, goldenAccessToken, "connect", nil))
+	}},
+	{Name: "upgrade_options", Endpoint: "/upgrade", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.UpgradeContext(ctx, goldenAccessToken, "connect", &plaid.UpgradeOptions{Webhook: "https://example.com/webhook"}))
+	}},
+	{Name: "upgrade_step_send_method", Endpoint: "/upgrade/step", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.UpgradeStepSendMethodContext(ctx, goldenAccessToken, "type", "email"))
+	}},
+	{Name: "upgrade_step", Endpoint: "/upgrade/step", Call: func(ctx context.Context, c *plaid.Client) (interface{}, error) {
+		return withMFA(c.UpgradeStepContext(ctx, goldenAccessToken, "tomato"))
+	}},
+}
+
+// withMFA combines the results of a legacy endpoint that may answer with MFA.
+func withMFA(res, mfa interface{}, err error) (interface{}, error) {
+	return map[string]interface{}{"response": res, "mfa": mfa}, err
+}
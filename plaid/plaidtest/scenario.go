@@ -0,0 +1,233 @@
+package plaidtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid"
+	"github.com/wearevest/plaidgo/plaid/webhooks"
+)
+
+// Scenario describes an item and a timeline of changes to it that a Server plays back,
+// e.g. transactions arriving over time with the matching webhooks. Scenarios can be
+// written as Go values or loaded from JSON with LoadScenario:
+//
+//	{
+//	  "item": {"access_token": "access-sandbox-1", "item": {"item_id": "item-1", "webhook": "http://localhost:8080/webhook"}},
+//	  "timeline": [
+//	    {"after": "1s", "add_transactions": [{"transaction_id": "t1", "account_id": "a1", "amount": 4.5, "date": "2021-01-02"}]},
+//	    {"webhook": {"webhook_type": "TRANSACTIONS", "webhook_code": "DEFAULT_UPDATE"}},
+//	    {"after": "1h", "webhook": {"webhook_type": "ITEM", "webhook_code": "PENDING_EXPIRATION"}}
+//	  ]
+//	}
+type Scenario struct {
+	Item     Item    `json:"item"`
+	Timeline []Event `json:"timeline"`
+}
+
+// Event is a step of a scenario's timeline. Its changes are applied in the order of the
+// fields, and the webhook, if any, is sent last.
+type Event struct {
+	// After is the delay since the previous event.
+	After Duration `json:"after"`
+
+	AddTransactions    []plaid.Transaction   `json:"add_transactions"`
+	RemoveTransactions []plaid.TransactionID `json:"remove_transactions"`
+	// SetBalances sets the current balance of accounts by account id.
+	SetBalances map[plaid.AccountID]float64 `json:"set_balances"`
+
+	// Webhook is sent to the item's webhook URL. It may be any webhook webhooks.Parse
+	// decodes, e.g. a *plaid.TransactionsWebhook, *webhooks.ItemWebhook,
+	// *webhooks.AuthVerificationWebhook or *webhooks.LinkSessionFinishedWebhook, and is
+	// written in JSON as the webhook's body. An empty item_id is filled in, and for
+	// TRANSACTIONS webhooks so are new_transactions and removed_transactions.
+	Webhook interface{} `json:"webhook"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the webhook with webhooks.Parse.
+func (e *Event) UnmarshalJSON(b []byte) error {
+	type event Event
+	decoded := struct {
+		*event
+		Webhook json.RawMessage `json:"webhook"`
+	}{event: (*event)(e)}
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+	e.Webhook = nil
+	if len(decoded.Webhook) == 0 || string(decoded.Webhook) == "null" {
+		return nil
+	}
+	webhook, err := webhooks.Parse(decoded.Webhook)
+	if err != nil {
+		return fmt.Errorf("webhook: %v", err)
+	}
+	e.Webhook = webhook
+	return nil
+}
+
+// Duration is a time.Duration written as a string such as "1.5s" in JSON.
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadScenario decodes a scenario from JSON.
+func LoadScenario(r io.Reader) (*Scenario, error) {
+	var scenario Scenario
+	if err := json.NewDecoder(r).Decode(&scenario); err != nil {
+		return nil, err
+	}
+	if scenario.Item.AccessToken == "" {
+		return nil, errors.New("scenario item must have an access token")
+	}
+	return &scenario, nil
+}
+
+// Play adds the scenario's item to the server and plays back its timeline, waiting on
+// clock between events. Pass a FakeClock to control the pace from a test, or nil to play
+// back in real time. Play returns once the timeline is complete, a webhook couldn't be
+// delivered or ctx is done.
+func (s *Server) Play(ctx context.Context, scenario Scenario, clock plaid.Clock) error {
+	s.AddItem(scenario.Item)
+	for _, event := range scenario.Timeline {
+		if event.After > 0 {
+			var after <-chan time.Time
+			if clock != nil {
+				after = clock.After(time.Duration(event.After))
+			} else {
+				after = time.After(time.Duration(event.After))
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-after:
+			}
+		}
+		webhookURL, webhook := s.apply(scenario.Item.AccessToken, event)
+		if webhook == nil || webhookURL == "" {
+			continue
+		}
+		body, err := webhookBody(webhook, scenario.Item.Item.ItemId, event)
+		if err != nil {
+			return err
+		}
+		if err := sendWebhook(ctx, webhookURL, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply applies the changes of an event to an item and returns the item's webhook URL
+// along with the webhook to send.
+func (s *Server) apply(accessToken string, event Event) (string, interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	item, ok := s.items[accessToken]
+	if !ok {
+		return "", nil
+	}
+
+	item.Transactions = append(item.Transactions, event.AddTransactions...)
+	if len(event.RemoveTransactions) > 0 {
+		removed := map[plaid.TransactionID]bool{}
+		for _, id := range event.RemoveTransactions {
+			removed[id] = true
+		}
+		kept := item.Transactions[:0]
+		for _, t := range item.Transactions {
+			if !removed[t.TransactionID] {
+				kept = append(kept, t)
+			}
+		}
+		item.Transactions = kept
+	}
+	for i, account := range item.Accounts {
+		if balance, ok := event.SetBalances[account.AccountID]; ok {
+			item.Accounts[i].Balances.Current = balance
+		}
+	}
+
+	return item.Item.Webhook, event.Webhook
+}
+
+// webhookBody encodes a webhook of event, filling in the item id and the changes of
+// TRANSACTIONS webhooks, and checks that webhooks.Parse decodes it.
+func webhookBody(webhook interface{}, itemID plaid.ItemID, event Event) ([]byte, error) {
+	var raw []byte
+	switch w := webhook.(type) {
+	case *webhooks.GenericWebhook:
+		raw = w.Raw
+	case json.RawMessage:
+		raw = w
+	default:
+		var err error
+		if raw, err = json.Marshal(webhook); err != nil {
+			return nil, err
+		}
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("webhook: %v", err)
+	}
+	if id, _ := fields["item_id"].(string); id == "" {
+		fields["item_id"] = itemID
+	}
+	if fields["webhook_type"] == "TRANSACTIONS" {
+		if count, _ := fields["new_transactions"].(float64); count == 0 {
+			fields["new_transactions"] = len(event.AddTransactions)
+		}
+		if fields["removed_transactions"] == nil {
+			fields["removed_transactions"] = event.RemoveTransactions
+		}
+	}
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := webhooks.Parse(body); err != nil {
+		return nil, fmt.Errorf("webhook: %v", err)
+	}
+	return body, nil
+}
+
+func sendWebhook(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode >= 300 {
+		return errors.New("webhook receiver responded with " + res.Status)
+	}
+	return nil
+}
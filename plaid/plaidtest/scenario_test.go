@@ -0,0 +1,75 @@
+package plaidtest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wearevest/plaidgo/plaid/webhooks"
+)
+
+func TestPlayWebhooks(t *testing.T) {
+	received := make(chan interface{}, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		webhook, err := webhooks.ParseStrict(body)
+		if err != nil {
+			t.Errorf("ParseStrict(%s) = %v", body, err)
+		}
+		received <- webhook
+	}))
+	defer receiver.Close()
+
+	scenario, err := LoadScenario(strings.NewReader(`{
+		"item": {"access_token": "access-sandbox-1", "item": {"item_id": "item-1", "webhook": "` + receiver.URL + `"}},
+		"timeline": [
+			{"add_transactions": [{"transaction_id": "t1", "account_id": "a1", "amount": 4.5, "date": "2021-01-02"}],
+			 "webhook": {"webhook_type": "TRANSACTIONS", "webhook_code": "DEFAULT_UPDATE"}},
+			{"webhook": {"webhook_type": "ITEM", "webhook_code": "PENDING_EXPIRATION", "consent_expiration_time": "2021-02-01T00:00:00Z"}},
+			{"webhook": {"webhook_type": "AUTH", "webhook_code": "AUTOMATICALLY_VERIFIED", "account_id": "a1"}},
+			{"webhook": {"webhook_type": "LINK", "webhook_code": "SESSION_FINISHED", "status": "SUCCESS", "link_session_id": "s1"}}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := NewServer()
+	defer server.Close()
+	if err := server.Play(context.Background(), *scenario, nil); err != nil {
+		t.Fatal(err)
+	}
+	close(received)
+
+	var got []interface{}
+	for webhook := range received {
+		got = append(got, webhook)
+	}
+	if len(got) != 4 {
+		t.Fatalf("received %d webhooks, want 4", len(got))
+	}
+	if w, ok := got[0].(*webhooks.TransactionsWebhook); !ok || w.ItemID != "item-1" || w.NewTransactions != 1 {
+		t.Errorf("received %#v, want a TRANSACTIONS webhook of item-1 with 1 new transaction", got[0])
+	}
+	if w, ok := got[1].(*webhooks.ItemWebhook); !ok || w.ItemID != "item-1" {
+		t.Errorf("received %#v, want an ITEM webhook of item-1", got[1])
+	}
+	if w, ok := got[2].(*webhooks.AuthVerificationWebhook); !ok || w.AccountID != "a1" {
+		t.Errorf("received %#v, want an AUTH webhook of a1", got[2])
+	}
+	if _, ok := got[3].(*webhooks.LinkSessionFinishedWebhook); !ok {
+		t.Errorf("received %#v, want a SESSION_FINISHED webhook", got[3])
+	}
+}
+
+func TestLoadScenarioMalformedWebhook(t *testing.T) {
+	_, err := LoadScenario(strings.NewReader(`{
+		"item": {"access_token": "access-sandbox-1"},
+		"timeline": [{"webhook": {"webhook_code": "DEFAULT_UPDATE"}}]
+	}`))
+	if err == nil {
+		t.Fatal("LoadScenario accepted a webhook without a type")
+	}
+}
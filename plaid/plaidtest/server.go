@@ -0,0 +1,334 @@
+package plaidtest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+// Item is the state the fake server holds for one item.
+type Item struct {
+	AccessToken string `json:"access_token"`
+	// PublicToken, if set, can be exchanged for AccessToken.
+	PublicToken  string              `json:"public_token"`
+	Item         plaid.Item          `json:"item"`
+	Accounts     []plaid.Account     `json:"accounts"`
+	Transactions []plaid.Transaction `json:"transactions"`
+}
+
+// Fault describes a failure the fake server injects into responses of an endpoint.
+type Fault struct {
+	// Times is the number of requests the fault applies to. Zero applies it to every
+	// request from now on.
+	Times int
+	// Latency delays the response.
+	Latency time.Duration
+	// StatusCode, if set, makes the server respond with a Plaid error of ErrorType and
+	// ErrorCode instead of handling the request.
+	StatusCode int
+	ErrorType  string
+	ErrorCode  string
+	// Malformed makes the server respond with a 200 and a body that isn't valid JSON.
+	Malformed bool
+	// PageSize caps the number of transactions returned per /transactions/get and
+	// /transactions/sync request, regardless of the requested count.
+	PageSize int
+}
+
+// ProductNotReady is a fault that fails a request once the way Plaid does while an item's
+// data is still being fetched. Inject it in front of a successful response to exercise
+// retry logic.
+var ProductNotReady = Fault{Times: 1, StatusCode: 400, ErrorType: "ITEM_ERROR", ErrorCode: "PRODUCT_NOT_READY"}
+
+// Request is a request received by the fake server.
+type Request struct {
+	Endpoint string
+	Body     []byte
+}
+
+// Server is a fake Plaid API for tests. It serves items added with AddItem and lets tests
+// script failures per endpoint with Inject.
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	items    map[string]*Item   // access token -> item
+	faults   map[string][]Fault // endpoint -> faults still to apply
+	requests []Request
+
+	linkTokens int // link tokens created so far
+}
+
+// NewServer starts a fake server. Close it when done.
+func NewServer() *Server {
+	s := &Server{items: map[string]*Item{}, faults: map[string][]Fault{}}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a client that sends its requests to the server.
+func (s *Server) Client(options ...plaid.Option) *plaid.Client {
+	options = append(options, plaid.WithBaseURL(s.URL))
+	return plaid.NewClient("test_id", "test_secret", plaid.Sandbox, options...)
+}
+
+// AddItem adds an item to the server, replacing any item with the same access token.
+// The server works on a copy of the item's accounts and transactions.
+func (s *Server) AddItem(item Item) {
+	item.Accounts = append([]plaid.Account(nil), item.Accounts...)
+	item.Transactions = append([]plaid.Transaction(nil), item.Transactions...)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[item.AccessToken] = &item
+}
+
+// Inject queues faults for an endpoint such as "/transactions/get". Faults apply in the
+// order they were injected; once all are used up the endpoint behaves normally again.
+func (s *Server) Inject(endpoint string, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[endpoint] = append(s.faults[endpoint], faults...)
+}
+
+// Requests returns the requests the server received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// nextFault returns the fault to apply to a request of endpoint, if any.
+func (s *Server) nextFault(endpoint string) (Fault, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	faults := s.faults[endpoint]
+	if len(faults) == 0 {
+		return Fault{}, false
+	}
+	fault := faults[0]
+	if fault.Times > 0 {
+		faults[0].Times--
+		if faults[0].Times == 0 {
+			s.faults[endpoint] = faults[1:]
+		}
+	}
+	return fault, true
+}
+
+// serverRequest holds the request fields the fake endpoints look at.
+type serverRequest struct {
+	AccessToken   string          `json:"access_token"`
+	PublicToken   string          `json:"public_token"`
+	InstitutionID string          `json:"institution_id"`
+	Webhook       string          `json:"webhook"`
+	StartDate     string          `json:"start_date"`
+	EndDate       string          `json:"end_date"`
+	Cursor        string          `json:"cursor"`
+	Count         int             `json:"count"`
+	AccountID     plaid.AccountID `json:"account_id"`
+	Options       struct {
+		Count  int `json:"count"`
+		Offset int `json:"offset"`
+	} `json:"options"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, 400, "INVALID_REQUEST", "UNKNOWN_FIELDS", err.Error())
+		return
+	}
+	endpoint := r.URL.Path
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Endpoint: endpoint, Body: body})
+	s.mu.Unlock()
+
+	fault, faulty := s.nextFault(endpoint)
+	if faulty {
+		if fault.Latency > 0 {
+			time.Sleep(fault.Latency)
+		}
+		if fault.Malformed {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"request_id": "malformed`))
+			return
+		}
+		if fault.StatusCode != 0 {
+			writeError(w, fault.StatusCode, fault.ErrorType, fault.ErrorCode, "injected fault")
+			return
+		}
+	}
+
+	var req serverRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeError(w, 400, "INVALID_REQUEST", "INVALID_BODY", err.Error())
+		return
+	}
+	s.handle(w, endpoint, req, fault)
+}
+
+func (s *Server) handle(w http.ResponseWriter, endpoint string, req serverRequest, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch endpoint {
+	case "/link/token/create":
+		s.linkTokens++
+		writeJSON(w, map[string]interface{}{
+			"link_token": "link-sandbox-fake-" + strconv.Itoa(s.linkTokens),
+			"expiration": time.Now().Add(4 * time.Hour).UTC().Format(time.RFC3339),
+		})
+		return
+	case "/sandbox/public_token/create":
+		// The fake server doesn't create items: it hands out the public token of an item
+		// added with AddItem for the institution.
+		for _, item := range s.items {
+			if item.PublicToken != "" && item.Item.InstitutionId == req.InstitutionID {
+				writeJSON(w, map[string]interface{}{"public_token": item.PublicToken})
+				return
+			}
+		}
+		writeError(w, 400, "INVALID_INPUT", "INVALID_INSTITUTION", "no item with a public token for the institution")
+		return
+	case "/item/public_token/exchange":
+		for _, item := range s.items {
+			if item.PublicToken != "" && item.PublicToken == req.PublicToken {
+				writeJSON(w, map[string]interface{}{"access_token": item.AccessToken, "item_id": item.Item.ItemId})
+				return
+			}
+		}
+		writeError(w, 400, "INVALID_INPUT", "INVALID_PUBLIC_TOKEN", "public token not found")
+		return
+	}
+
+	item, ok := s.items[req.AccessToken]
+	if !ok {
+		writeError(w, 400, "INVALID_INPUT", "INVALID_ACCESS_TOKEN", "access token not found")
+		return
+	}
+	switch endpoint {
+	case "/item/get":
+		writeJSON(w, map[string]interface{}{"item": item.Item})
+	case "/item/remove":
+		delete(s.items, req.AccessToken)
+		writeJSON(w, map[string]interface{}{})
+	case "/item/webhook/update":
+		item.Item.Webhook = req.Webhook
+		writeJSON(w, map[string]interface{}{"item": item.Item})
+	case "/accounts/get", "/accounts/balance/get":
+		writeJSON(w, map[string]interface{}{"item": item.Item, "accounts": item.Accounts})
+	case "/sandbox/item/fire_webhook":
+		writeJSON(w, map[string]interface{}{"webhook_fired": true})
+	case "/transactions/get":
+		var matching []plaid.Transaction
+		for _, t := range item.Transactions {
+			if (req.StartDate == "" || t.Date >= req.StartDate) && (req.EndDate == "" || t.Date <= req.EndDate) {
+				matching = append(matching, t)
+			}
+		}
+		count := req.Options.Count
+		if count == 0 {
+			count = 100
+		}
+		if fault.PageSize > 0 && fault.PageSize < count {
+			count = fault.PageSize
+		}
+		page := []plaid.Transaction{}
+		if req.Options.Offset < len(matching) {
+			page = matching[req.Options.Offset:]
+		}
+		if len(page) > count {
+			page = page[:count]
+		}
+		writeJSON(w, map[string]interface{}{
+			"item":               item.Item,
+			"accounts":           item.Accounts,
+			"transactions":       page,
+			"total_transactions": len(matching),
+		})
+	case "/transactions/sync":
+		// The fake server doesn't track changes: a cursor is the number of transactions
+		// already returned, and every transaction is reported as added.
+		offset := 0
+		if req.Cursor != "" {
+			var err error
+			if offset, err = strconv.Atoi(req.Cursor); err != nil || offset > len(item.Transactions) {
+				writeError(w, 400, "INVALID_INPUT", "INVALID_CURSOR", "cursor not recognized")
+				return
+			}
+		}
+		count := req.Count
+		if count == 0 {
+			count = 100
+		}
+		if fault.PageSize > 0 && fault.PageSize < count {
+			count = fault.PageSize
+		}
+		page := item.Transactions[offset:]
+		if len(page) > count {
+			page = page[:count]
+		}
+		writeJSON(w, map[string]interface{}{
+			"added":       append([]plaid.Transaction{}, page...),
+			"modified":    []plaid.Transaction{},
+			"removed":     []plaid.RemovedTransaction{},
+			"next_cursor": strconv.Itoa(offset + len(page)),
+			"has_more":    offset+len(page) < len(item.Transactions),
+		})
+	case "/processor/stripe/bank_account_token/create":
+		for _, account := range item.Accounts {
+			if account.AccountID == req.AccountID {
+				writeJSON(w, map[string]interface{}{"stripe_bank_account_token": "btok_" + account.AccountID})
+				return
+			}
+		}
+		writeError(w, 400, "INVALID_INPUT", "INVALID_ACCOUNT_ID", "account not found")
+	default:
+		writeError(w, 404, "INVALID_REQUEST", "NOT_FOUND", "endpoint not supported by the fake server")
+	}
+}
+
+var requestCounter struct {
+	sync.Mutex
+	n int
+}
+
+// requestID returns a unique request id for a response.
+func requestID() string {
+	requestCounter.Lock()
+	defer requestCounter.Unlock()
+	requestCounter.n++
+	return "fake-request-" + strconv.Itoa(requestCounter.n)
+}
+
+func writeJSON(w http.ResponseWriter, body map[string]interface{}) {
+	body["request_id"] = requestID()
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, errorType, errorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error_type":      errorType,
+		"error_code":      errorCode,
+		"error_message":   message,
+		"display_message": nil,
+		"request_id":      requestID(),
+	})
+}
@@ -0,0 +1,81 @@
+// Package plaidtest provides helpers for testing code built on the plaid package.
+package plaidtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid"
+	"github.com/wearevest/plaidgo/plaid/internal/pool"
+)
+
+// FireResult is the outcome of firing a sandbox webhook for one item.
+type FireResult struct {
+	AccessToken string
+	Err         error
+}
+
+// WebhookFirer fires sandbox webhooks for many items while keeping the requests spaced out
+// to stay under the sandbox rate limits. It is safe for concurrent use; concurrent calls
+// to Fire share the same spacing.
+type WebhookFirer struct {
+	client  *plaid.Client
+	spacing time.Duration
+
+	mu   sync.Mutex
+	next time.Time // earliest time the next webhook may be fired
+}
+
+// NewWebhookFirer instantiates a WebhookFirer that fires at most one webhook per spacing,
+// as timed by the client's Clock.
+func NewWebhookFirer(client *plaid.Client, spacing time.Duration) *WebhookFirer {
+	return &WebhookFirer{client: client, spacing: spacing}
+}
+
+// Fire fires a webhook with the given code for every access token using up to concurrency
+// requests in flight, and returns one result per token in the order of accessTokens.
+func (f *WebhookFirer) Fire(ctx context.Context, accessTokens []string, webhookCode string,
+	concurrency int) []FireResult {
+
+	results := make([]FireResult, len(accessTokens))
+	pool.Each(len(accessTokens), concurrency, func(i int) {
+		results[i] = FireResult{AccessToken: accessTokens[i], Err: f.fire(ctx, accessTokens[i], webhookCode)}
+	})
+	return results
+}
+
+func (f *WebhookFirer) fire(ctx context.Context, accessToken, webhookCode string) error {
+	if err := f.wait(ctx); err != nil {
+		return err
+	}
+	res, err := f.client.SandboxItemFireWebhookContext(ctx, accessToken, webhookCode)
+	if err != nil {
+		return err
+	}
+	if !res.WebhookFired {
+		return errors.New("plaid did not fire the webhook")
+	}
+	return nil
+}
+
+// wait blocks until the next webhook may be fired.
+func (f *WebhookFirer) wait(ctx context.Context) error {
+	clock := f.client.Clock()
+	f.mu.Lock()
+	now := clock.Now()
+	at := f.next
+	if at.Before(now) {
+		at = now
+	}
+	f.next = at.Add(f.spacing)
+	f.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(at.Sub(now)):
+		return nil
+	}
+}
@@ -0,0 +1,184 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Processors with named helpers. Any other processor supported by Plaid can be passed to
+// ProcessorTokenCreate.
+//
+// See https://plaid.com/docs/api/processors/#processortokencreate.
+const (
+	ProcessorDwolla         = "dwolla"
+	ProcessorUnit           = "unit"
+	ProcessorTreasuryPrime  = "treasury_prime"
+	ProcessorModernTreasury = "modern_treasury"
+)
+
+// processorSubtypes lists the depository account subtypes each processor accepts.
+var processorSubtypes = map[string][]string{
+	ProcessorDwolla:         {"checking", "savings"},
+	ProcessorUnit:           {"checking", "savings"},
+	ProcessorTreasuryPrime:  {"checking", "savings"},
+	ProcessorModernTreasury: {"checking", "savings", "money market", "cd"},
+}
+
+// ProcessorTokenCreate (POST /processor/token/create) creates a token that gives a
+// processor partner such as Dwolla access to an account of an item.
+//
+// See https://plaid.com/docs/api/processors/#processortokencreate.
+func (c *Client) ProcessorTokenCreate(accessToken string, accountID AccountID, processor string) (string, error) {
+	return c.ProcessorTokenCreateContext(context.Background(), accessToken, accountID, processor)
+}
+
+// ProcessorTokenCreateContext is like ProcessorTokenCreate but carries a context.
+func (c *Client) ProcessorTokenCreateContext(ctx context.Context, accessToken string, accountID AccountID,
+	processor string) (string, error) {
+
+	if err := validateProcessorTokenRequest(accessToken, accountID, processor); err != nil {
+		return "", err
+	}
+	var res struct {
+		ProcessorToken string `json:"processor_token"`
+		RequestID      string `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/processor/token/create", processorTokenCreateJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+		AccountID:   accountID,
+		Processor:   processor,
+	}, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.ProcessorToken, nil
+}
+
+type processorTokenCreateJson struct {
+	ClientID    string    `json:"client_id"`
+	Secret      string    `json:"secret"`
+	AccessToken string    `json:"access_token"`
+	AccountID   AccountID `json:"account_id"`
+	Processor   string    `json:"processor"`
+}
+
+// DwollaProcessorTokenCreate creates a Dwolla processor token for a checking or savings
+// account, after checking that the account is eligible. See ValidateProcessorAccount.
+func (c *Client) DwollaProcessorTokenCreate(accessToken string, accountID AccountID) (string, error) {
+	return c.DwollaProcessorTokenCreateContext(context.Background(), accessToken, accountID)
+}
+
+// DwollaProcessorTokenCreateContext is like DwollaProcessorTokenCreate but carries a context.
+func (c *Client) DwollaProcessorTokenCreateContext(ctx context.Context, accessToken string,
+	accountID AccountID) (string, error) {
+
+	return c.validatedProcessorTokenCreate(ctx, accessToken, accountID, ProcessorDwolla)
+}
+
+// UnitProcessorTokenCreate creates a Unit processor token for a checking or savings
+// account, after checking that the account is eligible. See ValidateProcessorAccount.
+func (c *Client) UnitProcessorTokenCreate(accessToken string, accountID AccountID) (string, error) {
+	return c.UnitProcessorTokenCreateContext(context.Background(), accessToken, accountID)
+}
+
+// UnitProcessorTokenCreateContext is like UnitProcessorTokenCreate but carries a context.
+func (c *Client) UnitProcessorTokenCreateContext(ctx context.Context, accessToken string,
+	accountID AccountID) (string, error) {
+
+	return c.validatedProcessorTokenCreate(ctx, accessToken, accountID, ProcessorUnit)
+}
+
+// TreasuryPrimeProcessorTokenCreate creates a Treasury Prime processor token for a
+// checking or savings account, after checking that the account is eligible. See
+// ValidateProcessorAccount.
+func (c *Client) TreasuryPrimeProcessorTokenCreate(accessToken string, accountID AccountID) (string, error) {
+	return c.TreasuryPrimeProcessorTokenCreateContext(context.Background(), accessToken, accountID)
+}
+
+// TreasuryPrimeProcessorTokenCreateContext is like TreasuryPrimeProcessorTokenCreate but carries a context.
+func (c *Client) TreasuryPrimeProcessorTokenCreateContext(ctx context.Context, accessToken string,
+	accountID AccountID) (string, error) {
+
+	return c.validatedProcessorTokenCreate(ctx, accessToken, accountID, ProcessorTreasuryPrime)
+}
+
+// ModernTreasuryProcessorTokenCreate creates a Modern Treasury processor token for a
+// depository account, after checking that the account is eligible. See
+// ValidateProcessorAccount.
+func (c *Client) ModernTreasuryProcessorTokenCreate(accessToken string, accountID AccountID) (string, error) {
+	return c.ModernTreasuryProcessorTokenCreateContext(context.Background(), accessToken, accountID)
+}
+
+// ModernTreasuryProcessorTokenCreateContext is like ModernTreasuryProcessorTokenCreate but carries a context.
+func (c *Client) ModernTreasuryProcessorTokenCreateContext(ctx context.Context, accessToken string,
+	accountID AccountID) (string, error) {
+
+	return c.validatedProcessorTokenCreate(ctx, accessToken, accountID, ProcessorModernTreasury)
+}
+
+// validatedProcessorTokenCreate creates a processor token after checking with
+// ValidateProcessorAccount that the processor accepts the account.
+func (c *Client) validatedProcessorTokenCreate(ctx context.Context, accessToken string, accountID AccountID,
+	processor string) (string, error) {
+
+	if err := validateProcessorTokenRequest(accessToken, accountID, processor); err != nil {
+		return "", err
+	}
+	accountsRes, err := c.AccountsContext(ctx, accessToken)
+	if err != nil {
+		return "", err
+	}
+	var account *Account
+	for i := range accountsRes.Accounts {
+		if accountsRes.Accounts[i].AccountID == accountID {
+			account = &accountsRes.Accounts[i]
+		}
+	}
+	if account == nil {
+		return "", fmt.Errorf("account %s not found in item", accountID)
+	}
+	if err = ValidateProcessorAccount(processor, *account); err != nil {
+		return "", err
+	}
+	return c.ProcessorTokenCreateContext(ctx, accessToken, accountID, processor)
+}
+
+// validateProcessorTokenRequest checks the arguments every processor token request needs.
+func validateProcessorTokenRequest(accessToken string, accountID AccountID, processor string) error {
+	if accessToken == "" || accountID == "" || processor == "" {
+		return errors.New("/processor/token/create - access token, account id and processor must be specified")
+	}
+	return nil
+}
+
+// ValidateProcessorAccount checks that a processor with a named helper accepts an account:
+// it must be a depository account of a subtype the processor supports, and accounts added
+// through micro-deposits must have completed verification. Other processors accept any
+// account.
+func ValidateProcessorAccount(processor string, account Account) error {
+	subtypes, ok := processorSubtypes[processor]
+	if !ok {
+		return nil
+	}
+	if account.Type != "depository" {
+		return fmt.Errorf("%s only accepts depository accounts, account %s is of type %q",
+			processor, account.AccountID, account.Type)
+	}
+	supported := false
+	for _, subtype := range subtypes {
+		if account.Subtype == subtype {
+			supported = true
+		}
+	}
+	if !supported {
+		return fmt.Errorf("%s does not accept %q accounts", processor, account.Subtype)
+	}
+	if account.VerificationStatus != "" && !account.VerificationStatus.Verified() {
+		return fmt.Errorf("account %s is not verified yet: %s", account.AccountID, account.VerificationStatus)
+	}
+	return nil
+}
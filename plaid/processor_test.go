@@ -0,0 +1,75 @@
+package plaid
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidateProcessorAccount(t *testing.T) {
+	tests := []struct {
+		name      string
+		processor string
+		account   Account
+		wantErr   bool
+	}{
+		{"checking", ProcessorDwolla, Account{Type: "depository", Subtype: "checking"}, false},
+		{"verified", ProcessorUnit, Account{Type: "depository", Subtype: "savings",
+			VerificationStatus: ManuallyVerified}, false},
+		{"pending verification", ProcessorUnit, Account{Type: "depository", Subtype: "savings",
+			VerificationStatus: PendingManualVerification}, true},
+		{"credit", ProcessorTreasuryPrime, Account{Type: "credit", Subtype: "credit card"}, true},
+		{"money market", ProcessorDwolla, Account{Type: "depository", Subtype: "money market"}, true},
+		{"money market for modern treasury", ProcessorModernTreasury,
+			Account{Type: "depository", Subtype: "money market"}, false},
+		{"other processor", "galileo", Account{Type: "credit", Subtype: "credit card"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProcessorAccount(tt.processor, tt.account)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateProcessorAccount = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProcessorTokenHelpers(t *testing.T) {
+	var created []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/accounts/get":
+			w.Write([]byte(`{"accounts": [
+				{"account_id": "checking", "type": "depository", "subtype": "checking"},
+				{"account_id": "card", "type": "credit", "subtype": "credit card"}
+			], "request_id": "r1"}`))
+		case "/processor/token/create":
+			var req processorTokenCreateJson
+			json.NewDecoder(r.Body).Decode(&req)
+			created = append(created, req.Processor+" "+string(req.AccountID))
+			w.Write([]byte(`{"processor_token": "processor-sandbox-1", "request_id": "r2"}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	c := NewClient("id", "secret", Sandbox, WithBaseURL(server.URL))
+
+	token, err := c.DwollaProcessorTokenCreate("access-sandbox-1", "checking")
+	if err != nil || token != "processor-sandbox-1" {
+		t.Fatalf("DwollaProcessorTokenCreate = %q, %v, want the processor token", token, err)
+	}
+	if _, err = c.UnitProcessorTokenCreate("access-sandbox-1", "card"); err == nil {
+		t.Fatal("UnitProcessorTokenCreate accepted a credit card")
+	}
+	if _, err = c.TreasuryPrimeProcessorTokenCreate("access-sandbox-1", "missing"); err == nil {
+		t.Fatal("TreasuryPrimeProcessorTokenCreate accepted an account that isn't in the item")
+	}
+	if _, err = c.ModernTreasuryProcessorTokenCreate("", "checking"); err == nil {
+		t.Fatal("ModernTreasuryProcessorTokenCreate accepted an empty access token")
+	}
+	if len(created) != 1 || created[0] != "dwolla checking" {
+		t.Fatalf("created processor tokens %v, want only the one for dwolla", created)
+	}
+}
@@ -0,0 +1,37 @@
+package plaid
+
+// PayloadProfile selects how much optional data Plaid includes in responses, for
+// deployments where bandwidth matters. Options structs that have a Profile field apply it
+// on top of their individual Include flags.
+type PayloadProfile int
+
+const (
+	// StandardProfile leaves the Include flags of the options as they are set.
+	StandardProfile PayloadProfile = iota
+	// MinimalProfile turns off every optional field, such as institution logos and
+	// original transaction descriptions.
+	MinimalProfile
+	// FullProfile turns on every optional field.
+	FullProfile
+)
+
+func (p PayloadProfile) transactionOptions(options TransactionOptionsJson) TransactionOptionsJson {
+	switch p {
+	case MinimalProfile, FullProfile:
+		full := p == FullProfile
+		options.IncludeOriginalDescription = full
+		options.IncludePersonalFinanceCategory = full
+	}
+	return options
+}
+
+func (p PayloadProfile) institutionOptions(options InstitutionOptions) InstitutionOptions {
+	switch p {
+	case MinimalProfile, FullProfile:
+		full := p == FullProfile
+		options.IncludeOptionalMetadata = full
+		options.IncludeStatus = full
+		options.IncludeAuthMetadata = full
+	}
+	return options
+}
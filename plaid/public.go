@@ -0,0 +1,195 @@
+package plaid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/wearevest/plaidgo/plaid/transport"
+)
+
+// DefaultPublicRate is the number of requests per second a PublicClient sends unless
+// configured otherwise with WithPublicRateLimit.
+const DefaultPublicRate = 10
+
+// PublicClient makes requests to the endpoints that only require a public key, such as
+// institution search. It never holds the client id or secret, so it can be handed to lower
+// trust code paths, e.g. a backend serving a frontend's institution picker, without exposing
+// full credentials. Requests are rate limited independently of any Client.
+type PublicClient struct {
+	publicKey   string
+	environment environmentURL
+	httpClient  *http.Client
+	clock       Clock
+	throttle    *throttleGroup
+}
+
+// PublicOption configures optional behaviour of a PublicClient.
+type PublicOption func(*PublicClient)
+
+// NewPublicClient instantiates a PublicClient associated with a public key and environment.
+func NewPublicClient(publicKey string, environment environmentURL, options ...PublicOption) *PublicClient {
+	c := &PublicClient{
+		publicKey:   publicKey,
+		environment: environment,
+		httpClient:  &http.Client{},
+		clock:       systemClock{},
+		throttle:    newThrottleGroup(ThrottleConfig{Rate: DefaultPublicRate}),
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithPublicHTTPClient makes the client send its requests through httpClient.
+func WithPublicHTTPClient(httpClient *http.Client) PublicOption {
+	return func(c *PublicClient) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithPublicBaseURL sends requests to the given URL instead of the client's environment.
+func WithPublicBaseURL(baseURL string) PublicOption {
+	return func(c *PublicClient) {
+		c.environment = environmentURL(strings.TrimRight(baseURL, "/"))
+	}
+}
+
+// WithPublicRateLimit replaces the client's default rate limit of DefaultPublicRate
+// requests per second. The limit adapts to failures as described for ThrottleConfig; a
+// zero Rate disables it.
+func WithPublicRateLimit(config ThrottleConfig) PublicOption {
+	return func(c *PublicClient) {
+		c.throttle = newThrottleGroup(config)
+	}
+}
+
+// InstitutionGetByID (POST /institutions/get_by_id) returns information for a single
+// institution, like Client.InstitutionGetByID.
+//
+// See https://plaid.com/docs/api/institutions/#institutionsget_by_id.
+func (c *PublicClient) InstitutionGetByID(institutionID string, countryCodes []string,
+	options *InstitutionOptions) (*InstitutionJson, error) {
+	return c.InstitutionGetByIDContext(context.Background(), institutionID, countryCodes, options)
+}
+
+// InstitutionGetByIDContext is like InstitutionGetByID but carries a context.
+func (c *PublicClient) InstitutionGetByIDContext(ctx context.Context, institutionID string,
+	countryCodes []string, options *InstitutionOptions) (*InstitutionJson, error) {
+
+	if institutionID == "" {
+		return nil, errors.New("/institutions/get_by_id - institution id must be specified")
+	}
+	if options != nil {
+		resolved := options.Profile.institutionOptions(*options)
+		options = &resolved
+	}
+	var res InstitutionJson
+	err := c.postAndDecode(ctx, "/institutions/get_by_id", publicInstitutionGetByIDJson{
+		PublicKey:     c.publicKey,
+		InstitutionID: institutionID,
+		CountryCodes:  countryCodes,
+		Options:       options,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// InstitutionsSearchOptions represents options associated with searching institutions.
+//
+// See https://plaid.com/docs/api/institutions/#institutionssearch.
+type InstitutionsSearchOptions struct {
+	IncludeOptionalMetadata bool `json:"include_optional_metadata,omitempty"`
+	// OAuth, if set, limits the results to institutions that do or don't use OAuth.
+	OAuth *bool `json:"oauth,omitempty"`
+}
+
+// InstitutionsSearch (POST /institutions/search) returns the institutions whose name
+// matches query. If products is not empty only institutions supporting all of them are
+// returned.
+//
+// See https://plaid.com/docs/api/institutions/#institutionssearch.
+func (c *PublicClient) InstitutionsSearch(query string, products, countryCodes []string,
+	options *InstitutionsSearchOptions) ([]Institution, error) {
+	return c.InstitutionsSearchContext(context.Background(), query, products, countryCodes, options)
+}
+
+// InstitutionsSearchContext is like InstitutionsSearch but carries a context.
+func (c *PublicClient) InstitutionsSearchContext(ctx context.Context, query string, products,
+	countryCodes []string, options *InstitutionsSearchOptions) ([]Institution, error) {
+
+	if query == "" {
+		return nil, errors.New("/institutions/search - query must be specified")
+	}
+	var res institutionsGetResponse
+	err := c.postAndDecode(ctx, "/institutions/search", institutionsSearchJson{
+		PublicKey:    c.publicKey,
+		Query:        query,
+		Products:     products,
+		CountryCodes: countryCodes,
+		Options:      options,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Institutions, nil
+}
+
+type publicInstitutionGetByIDJson struct {
+	PublicKey     string              `json:"public_key"`
+	InstitutionID string              `json:"institution_id"`
+	CountryCodes  []string            `json:"country_codes"`
+	Options       *InstitutionOptions `json:"options,omitempty"`
+}
+
+type institutionsSearchJson struct {
+	PublicKey    string                     `json:"public_key"`
+	Query        string                     `json:"query"`
+	Products     []string                   `json:"products,omitempty"`
+	CountryCodes []string                   `json:"country_codes"`
+	Options      *InstitutionsSearchOptions `json:"options,omitempty"`
+}
+
+// postAndDecode posts request as JSON to the given endpoint and decodes a successful
+// response into response.
+func (c *PublicClient) postAndDecode(ctx context.Context, endpoint string, request,
+	response interface{}) error {
+
+	jsonText, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", string(c.environment)+endpoint, bytes.NewReader(jsonText))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("User-Agent", "plaid-go")
+
+	if err = c.throttle.wait(ctx, c.clock); err != nil {
+		return err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		c.throttle.record(true, endpoint, nil)
+		return err
+	}
+	c.throttle.record(res.StatusCode == 429 || res.StatusCode >= 500, endpoint, nil)
+	raw, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return err
+	}
+	if res.StatusCode == 200 {
+		return json.Unmarshal(raw, response)
+	}
+	return transport.DecodeError(res.StatusCode, raw)
+}
@@ -0,0 +1,175 @@
+package plaid
+
+import (
+	"sort"
+	"strings"
+)
+
+// TransactionSort is the order a TransactionQuery returns transactions in.
+type TransactionSort int
+
+const (
+	// SortByDate orders by date, keeping transactions of the same day in their input order.
+	SortByDate TransactionSort = iota
+	// SortByAmount orders by amount.
+	SortByAmount
+	// SortByMerchant orders by merchant name, case insensitively.
+	SortByMerchant
+)
+
+// TransactionQuery selects and orders transactions in memory. Queries are built by chaining
+// filters, each of which further narrows the result:
+//
+//	coffee := plaid.NewTransactionQuery().
+//		Between("2024-01-01", "2024-01-31").
+//		Categories("FOOD_AND_DRINK_COFFEE").
+//		Pending(false).
+//		Sort(plaid.SortByAmount, true).
+//		Limit(10).
+//		Run(transactions)
+//
+// A query is not safe for concurrent modification, but may be run concurrently once built.
+type TransactionQuery struct {
+	filters    []func(Transaction) bool
+	sortBy     TransactionSort
+	descending bool
+	sorted     bool
+	limit      int
+}
+
+// NewTransactionQuery returns a query that matches every transaction and keeps their order.
+func NewTransactionQuery() *TransactionQuery {
+	return &TransactionQuery{}
+}
+
+// Where adds an arbitrary filter.
+func (q *TransactionQuery) Where(filter func(Transaction) bool) *TransactionQuery {
+	q.filters = append(q.filters, filter)
+	return q
+}
+
+// Between keeps transactions dated between startDate and endDate inclusive, formatted
+// YYYY-MM-DD. An empty date leaves that side open.
+func (q *TransactionQuery) Between(startDate, endDate string) *TransactionQuery {
+	return q.Where(func(t Transaction) bool {
+		return (startDate == "" || t.Date >= startDate) && (endDate == "" || t.Date <= endDate)
+	})
+}
+
+// Amounts keeps transactions whose amount is between min and max inclusive. Amounts follow
+// Plaid's convention: positive for money leaving the account.
+func (q *TransactionQuery) Amounts(min, max float64) *TransactionQuery {
+	return q.Where(func(t Transaction) bool {
+		return t.Amount64() >= min && t.Amount64() <= max
+	})
+}
+
+// Categories keeps transactions whose personal finance category, primary or detailed, is
+// one of categories, e.g. "FOOD_AND_DRINK" or "FOOD_AND_DRINK_COFFEE".
+func (q *TransactionQuery) Categories(categories ...string) *TransactionQuery {
+	set := stringSet(categories)
+	return q.Where(func(t Transaction) bool {
+		category := t.PersonalFinanceCategory
+		return category != nil && (set[category.Primary] || set[category.Detailed])
+	})
+}
+
+// Merchants keeps transactions of the given merchants, each either a merchant entity id or
+// a name compared as normalized by NormalizeMerchantName.
+func (q *TransactionQuery) Merchants(merchants ...string) *TransactionQuery {
+	set := map[string]bool{}
+	for _, merchant := range merchants {
+		set[merchant] = true
+		set[NormalizeMerchantName(merchant)] = true
+	}
+	return q.Where(func(t Transaction) bool {
+		key, name, _ := merchantKey(t)
+		return set[key] || set[NormalizeMerchantName(name)]
+	})
+}
+
+// Pending keeps only pending transactions if pending is true, and only posted ones
+// otherwise.
+func (q *TransactionQuery) Pending(pending bool) *TransactionQuery {
+	return q.Where(func(t Transaction) bool {
+		return t.Pending == pending
+	})
+}
+
+// Accounts keeps transactions of the given accounts.
+func (q *TransactionQuery) Accounts(accountIDs ...AccountID) *TransactionQuery {
+	set := make(map[AccountID]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		set[id] = true
+	}
+	return q.Where(func(t Transaction) bool {
+		return set[t.AccountID]
+	})
+}
+
+// Sort orders the result. The sort is stable, so transactions that compare equal keep
+// their input order.
+func (q *TransactionQuery) Sort(by TransactionSort, descending bool) *TransactionQuery {
+	q.sortBy, q.descending, q.sorted = by, descending, true
+	return q
+}
+
+// Limit caps the number of transactions returned. Zero means no limit.
+func (q *TransactionQuery) Limit(n int) *TransactionQuery {
+	q.limit = n
+	return q
+}
+
+// Run returns the transactions matching all filters, ordered and limited as configured.
+// transactions is not modified.
+func (q *TransactionQuery) Run(transactions []Transaction) []Transaction {
+	var result []Transaction
+	for _, t := range transactions {
+		if q.Matches(t) {
+			result = append(result, t)
+		}
+	}
+	if q.sorted {
+		sort.SliceStable(result, func(i, j int) bool {
+			if q.descending {
+				return q.less(result[j], result[i])
+			}
+			return q.less(result[i], result[j])
+		})
+	}
+	if q.limit > 0 && len(result) > q.limit {
+		result = result[:q.limit]
+	}
+	return result
+}
+
+// Matches reports whether a transaction passes all filters.
+func (q *TransactionQuery) Matches(t Transaction) bool {
+	for _, filter := range q.filters {
+		if !filter(t) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *TransactionQuery) less(a, b Transaction) bool {
+	switch q.sortBy {
+	case SortByAmount:
+		return a.Amount < b.Amount
+	case SortByMerchant:
+		_, nameA, _ := merchantKey(a)
+		_, nameB, _ := merchantKey(b)
+		return strings.ToLower(nameA) < strings.ToLower(nameB)
+	default:
+		return a.Date < b.Date
+	}
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
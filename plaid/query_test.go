@@ -0,0 +1,22 @@
+package plaid
+
+import "testing"
+
+func TestTransactionQueryAmountsBoundaries(t *testing.T) {
+	query := NewTransactionQuery().Amounts(4.33, 10.1)
+	for _, tc := range []struct {
+		amount float32
+		want   bool
+	}{
+		// float32(4.33) widens to 4.329999923706055 and float32(10.1) to
+		// 10.100000381469727, both of which fall outside the bounds.
+		{4.33, true},
+		{10.1, true},
+		{4.32, false},
+		{10.11, false},
+	} {
+		if got := query.Matches(Transaction{Amount: tc.amount}); got != tc.want {
+			t.Errorf("Amounts(4.33, 10.1) matches %v = %v, want %v", tc.amount, got, tc.want)
+		}
+	}
+}
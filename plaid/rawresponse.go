@@ -0,0 +1,90 @@
+package plaid
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// RawResponse is a response exactly as Plaid sent it.
+type RawResponse struct {
+	Method     string
+	Endpoint   string
+	StatusCode int
+	RequestID  string
+	Time       time.Time
+	Body       []byte
+}
+
+// RawResponseRecorder collects the raw responses of the requests made with a context
+// returned by WithRawResponse, e.g. to archive the exact payloads decoded into a response
+// for compliance without fetching them again.
+type RawResponseRecorder struct {
+	mu        sync.Mutex
+	responses []RawResponse
+}
+
+// Responses returns the responses recorded so far, in the order they were received. Calls
+// that page, such as TransactionsSyncAll, record one response per request.
+func (r *RawResponseRecorder) Responses() []RawResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RawResponse(nil), r.responses...)
+}
+
+// Last returns the most recent response, or nil if none was recorded.
+func (r *RawResponseRecorder) Last() *RawResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.responses) == 0 {
+		return nil
+	}
+	last := r.responses[len(r.responses)-1]
+	return &last
+}
+
+// Reset drops the recorded responses, so that a recorder can be reused between calls.
+func (r *RawResponseRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = nil
+}
+
+func (r *RawResponseRecorder) record(response RawResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, response)
+}
+
+type rawResponseKey struct{}
+
+// WithRawResponse returns a context that makes the requests made with it record their raw
+// responses in recorder, alongside the decoded structs returned as usual:
+//
+//	var raw plaid.RawResponseRecorder
+//	res, err := client.TransactionsSyncContext(plaid.WithRawResponse(ctx, &raw), token, cursor, 500)
+//	archive(raw.Last().Body)
+func WithRawResponse(ctx context.Context, recorder *RawResponseRecorder) context.Context {
+	return context.WithValue(ctx, rawResponseKey{}, recorder)
+}
+
+// recordRaw records a response in the recorder of ctx, if any.
+func recordRaw(ctx context.Context, method, endpoint string, statusCode int, received time.Time, raw []byte) {
+	recorder, ok := ctx.Value(rawResponseKey{}).(*RawResponseRecorder)
+	if !ok {
+		return
+	}
+	var summary struct {
+		RequestID string `json:"request_id"`
+	}
+	json.Unmarshal(raw, &summary)
+	recorder.record(RawResponse{
+		Method:     method,
+		Endpoint:   endpoint,
+		StatusCode: statusCode,
+		RequestID:  summary.RequestID,
+		Time:       received,
+		Body:       raw,
+	})
+}
@@ -0,0 +1,90 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+)
+
+// TransactionsRecurringGet (POST /transactions/recurring/get) returns the recurring
+// transaction streams Plaid detected for an item, e.g. payroll deposits and subscriptions.
+// If accountIDs is empty, streams of all accounts are returned.
+//
+// See https://plaid.com/docs/api/products/transactions/#transactionsrecurringget.
+func (c *Client) TransactionsRecurringGet(accessToken string, accountIDs []AccountID) (*TransactionsRecurringResponse, error) {
+	return c.TransactionsRecurringGetContext(context.Background(), accessToken, accountIDs)
+}
+
+// TransactionsRecurringGetContext is like TransactionsRecurringGet but carries a context.
+func (c *Client) TransactionsRecurringGetContext(ctx context.Context, accessToken string,
+	accountIDs []AccountID) (*TransactionsRecurringResponse, error) {
+
+	if accessToken == "" {
+		return nil, errors.New("/transactions/recurring/get - access token must be specified")
+	}
+	var res TransactionsRecurringResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/transactions/recurring/get", transactionsRecurringJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+		AccountIDs:  accountIDs,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// TransactionsRecurringResponse is the response of /transactions/recurring/get.
+type TransactionsRecurringResponse struct {
+	// InflowStreams are streams of money coming in, such as income. Their amounts are
+	// negative, following Plaid's sign convention.
+	InflowStreams  []TransactionStream `json:"inflow_streams"`
+	OutflowStreams []TransactionStream `json:"outflow_streams"`
+	// UpdatedDatetime is the RFC 3339 time the streams were last updated.
+	UpdatedDatetime string `json:"updated_datetime"`
+	RequestID       string `json:"request_id"`
+}
+
+// Stream frequencies.
+const (
+	StreamWeekly      = "WEEKLY"
+	StreamBiweekly    = "BIWEEKLY"
+	StreamSemiMonthly = "SEMI_MONTHLY"
+	StreamMonthly     = "MONTHLY"
+	StreamAnnually    = "ANNUALLY"
+	StreamUnknown     = "UNKNOWN"
+)
+
+// TransactionStream is a series of transactions Plaid recognized as recurring.
+//
+// See https://plaid.com/docs/api/products/transactions/#transactions-recurring-get-response-inflow-streams.
+type TransactionStream struct {
+	AccountID               AccountID                `json:"account_id"`
+	StreamID                string                   `json:"stream_id"`
+	Description             string                   `json:"description"`
+	MerchantName            string                   `json:"merchant_name"`
+	FirstDate               string                   `json:"first_date"`
+	LastDate                string                   `json:"last_date"`
+	Frequency               string                   `json:"frequency"`
+	TransactionIDs          []TransactionID          `json:"transaction_ids"`
+	AverageAmount           StreamAmount             `json:"average_amount"`
+	LastAmount              StreamAmount             `json:"last_amount"`
+	IsActive                bool                     `json:"is_active"`
+	Status                  string                   `json:"status"` // "MATURE", "EARLY_DETECTION" or "TOMBSTONED"
+	PersonalFinanceCategory *PersonalFinanceCategory `json:"personal_finance_category"`
+}
+
+// StreamAmount is an amount of a TransactionStream.
+type StreamAmount struct {
+	Amount                 float64 `json:"amount"`
+	IsoCurrencyCode        string  `json:"iso_currency_code"`
+	UnofficialCurrencyCode string  `json:"unofficial_currency_code"`
+}
+
+type transactionsRecurringJson struct {
+	ClientID    string      `json:"client_id"`
+	Secret      string      `json:"secret"`
+	AccessToken string      `json:"access_token"`
+	AccountIDs  []AccountID `json:"account_ids,omitempty"`
+}
@@ -0,0 +1,237 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// TokenStore supplies the access tokens that background components such as the
+// Refresher operate on.
+type TokenStore interface {
+	AccessTokens(ctx context.Context) ([]string, error)
+}
+
+// RefresherConfig configures a Refresher.
+type RefresherConfig struct {
+	// Tokens supplies the access tokens to refresh. It is consulted on every cycle, so
+	// tokens added to or removed from the store are picked up automatically.
+	Tokens TokenStore
+
+	// BalanceInterval is the time between balance refreshes. Zero disables them.
+	BalanceInterval time.Duration
+	// TransactionsInterval is the time between transaction refreshes. Zero disables them.
+	TransactionsInterval time.Duration
+	// TransactionsDays is how many days of transactions each refresh fetches. Defaults to 30.
+	TransactionsDays int
+	// Jitter is the upper bound of a random delay added to every interval, so that many
+	// processes started together don't refresh in lockstep.
+	Jitter time.Duration
+	// ErrorBackoff is how long a token is skipped after its institution reported being down
+	// or not responding. Defaults to 15 minutes.
+	ErrorBackoff time.Duration
+
+	OnBalance      func(accessToken string, accounts []Account)
+	OnTransactions func(accessToken string, transactions []Transaction)
+	OnError        func(accessToken string, err error)
+}
+
+// Refresher periodically refreshes balances and transactions for a set of access tokens,
+// for applications that can't rely on webhooks alone. Results are delivered through the
+// callbacks of its RefresherConfig.
+//
+// Tokens whose institution is down are skipped for ErrorBackoff, and a cycle is cut short
+// as soon as Plaid reports a rate limit. The next cycle then starts with the token that hit
+// the rate limit, so that the tokens after it aren't starved.
+//
+// A Refresher is either driven by Run or, as a Component, by Start and Close.
+type Refresher struct {
+	client    *Client
+	config    RefresherConfig
+	lifecycle lifecycle
+
+	mu      sync.Mutex
+	skipped map[string]time.Time // access token -> skipped until
+}
+
+// NewRefresher instantiates a Refresher that makes its requests through c.
+func NewRefresher(c *Client, config RefresherConfig) *Refresher {
+	if config.TransactionsDays == 0 {
+		config.TransactionsDays = 30
+	}
+	if config.ErrorBackoff == 0 {
+		config.ErrorBackoff = 15 * time.Minute
+	}
+	return &Refresher{client: c, config: config, skipped: map[string]time.Time{}}
+}
+
+// Run refreshes until ctx is done and then returns ctx.Err().
+func (r *Refresher) Run(ctx context.Context) error {
+	r.run(ctx, nil)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// Start refreshes in the background until ctx is done or Close is called.
+func (r *Refresher) Start(ctx context.Context) error {
+	return r.lifecycle.start(func(stop <-chan struct{}) {
+		r.run(ctx, stop)
+	})
+}
+
+// Close stops a Refresher started with Start and waits for in-flight refreshes to finish.
+func (r *Refresher) Close() error {
+	return r.lifecycle.close()
+}
+
+// run refreshes until ctx is done or stop is closed.
+func (r *Refresher) run(ctx context.Context, stop <-chan struct{}) {
+	var wg sync.WaitGroup
+	if r.config.BalanceInterval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.loop(ctx, stop, r.config.BalanceInterval, r.refreshBalance)
+		}()
+	}
+	if r.config.TransactionsInterval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.loop(ctx, stop, r.config.TransactionsInterval, r.refreshTransactions)
+		}()
+	}
+	wg.Wait()
+}
+
+func (r *Refresher) loop(ctx context.Context, stop <-chan struct{}, interval time.Duration,
+	refresh func(ctx context.Context, accessToken string) error) {
+
+	var resume string // the token to start the next cycle with, if the last was cut short
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-r.client.clock.After(r.delay(interval)):
+		}
+		resume = r.cycle(ctx, stop, refresh, resume)
+	}
+}
+
+// delay returns interval plus a random jitter.
+func (r *Refresher) delay(interval time.Duration) time.Duration {
+	if r.config.Jitter <= 0 {
+		return interval
+	}
+	return interval + time.Duration(rand.Int63n(int64(r.config.Jitter)))
+}
+
+// cycle refreshes every token once, starting with resume if it is still in the store. It
+// returns the token to start the next cycle with if the cycle was cut short by a rate limit.
+func (r *Refresher) cycle(ctx context.Context, stop <-chan struct{},
+	refresh func(ctx context.Context, accessToken string) error, resume string) string {
+
+	tokens, err := r.config.Tokens.AccessTokens(ctx)
+	if err != nil {
+		r.reportError("", err)
+		return resume
+	}
+	r.pruneSkipped(tokens)
+	for i, token := range tokens {
+		if token == resume {
+			tokens = append(tokens[i:len(tokens):len(tokens)], tokens[:i]...)
+			break
+		}
+	}
+	for _, token := range tokens {
+		if ctx.Err() != nil || stopped(stop) {
+			return ""
+		}
+		if r.isSkipped(token) {
+			continue
+		}
+		err := refresh(ctx, token)
+		if err == nil {
+			continue
+		}
+		r.reportError(token, err)
+		var plaidErr plaidError
+		if errors.As(err, &plaidErr) {
+			switch {
+			case plaidErr.ErrorType == "RATE_LIMIT_EXCEEDED":
+				return token
+			case plaidErr.ErrorCode == "INSTITUTION_DOWN", plaidErr.ErrorCode == "INSTITUTION_NOT_RESPONDING":
+				r.skip(token)
+			}
+		}
+	}
+	return ""
+}
+
+func (r *Refresher) refreshBalance(ctx context.Context, accessToken string) error {
+	res, err := r.client.BalanceContext(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if r.config.OnBalance != nil {
+		r.config.OnBalance(accessToken, res.Accounts)
+	}
+	return nil
+}
+
+func (r *Refresher) refreshTransactions(ctx context.Context, accessToken string) error {
+	window := r.client.daysBack(r.config.TransactionsDays)
+	transactions, _, err := r.client.allTransactions(ctx, accessToken, window.StartDate, window.EndDate)
+	if err != nil {
+		return err
+	}
+	if r.config.OnTransactions != nil {
+		r.config.OnTransactions(accessToken, transactions)
+	}
+	return nil
+}
+
+func (r *Refresher) isSkipped(accessToken string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	until, ok := r.skipped[accessToken]
+	if !ok {
+		return false
+	}
+	if r.client.clock.Now().After(until) {
+		delete(r.skipped, accessToken)
+		return false
+	}
+	return true
+}
+
+func (r *Refresher) skip(accessToken string) {
+	r.mu.Lock()
+	r.skipped[accessToken] = r.client.clock.Now().Add(r.config.ErrorBackoff)
+	r.mu.Unlock()
+}
+
+// pruneSkipped forgets the skipped tokens that were removed from the store.
+func (r *Refresher) pruneSkipped(tokens []string) {
+	current := make(map[string]bool, len(tokens))
+	for _, token := range tokens {
+		current[token] = true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for token := range r.skipped {
+		if !current[token] {
+			delete(r.skipped, token)
+		}
+	}
+}
+
+func (r *Refresher) reportError(accessToken string, err error) {
+	if r.config.OnError != nil {
+		r.config.OnError(accessToken, err)
+	}
+}
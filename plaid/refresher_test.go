@@ -0,0 +1,38 @@
+package plaid
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+type staticTokens []string
+
+func (s staticTokens) AccessTokens(ctx context.Context) ([]string, error) {
+	return s, nil
+}
+
+func TestRefresherCycleWrappedErrors(t *testing.T) {
+	c := NewClient("id", "secret", Sandbox)
+	r := NewRefresher(c, RefresherConfig{Tokens: staticTokens{"a", "b", "c"}})
+	var refreshed []string
+	resume := r.cycle(context.Background(), nil, func(ctx context.Context, token string) error {
+		refreshed = append(refreshed, token)
+		switch token {
+		case "a":
+			// Errors wrapped on the way up are still recognized.
+			return fmt.Errorf("refreshing balances: %w", plaidError{ErrorType: "INSTITUTION_ERROR",
+				ErrorCode: "INSTITUTION_DOWN"})
+		case "b":
+			return fmt.Errorf("refreshing balances: %w", plaidError{ErrorType: "RATE_LIMIT_EXCEEDED",
+				ErrorCode: "ACCOUNTS_LIMIT"})
+		}
+		return nil
+	}, "")
+	if resume != "b" || len(refreshed) != 2 {
+		t.Fatalf("cycle refreshed %v and resumes at %q, want it cut short at the rate-limited b", refreshed, resume)
+	}
+	if !r.isSkipped("a") {
+		t.Fatal("the token of the institution that is down isn't skipped")
+	}
+}
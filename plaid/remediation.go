@@ -0,0 +1,170 @@
+package plaid
+
+import (
+	"errors"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+	"github.com/wearevest/plaidgo/plaid/retry"
+)
+
+// RemediationAction is the step that resolves an error.
+type RemediationAction string
+
+const (
+	// ActionRetry means the request can be retried as is after RetryAfter.
+	ActionRetry RemediationAction = "RETRY"
+	// ActionLinkUpdate means the user has to go through Link in update mode for the item.
+	ActionLinkUpdate RemediationAction = "LINK_UPDATE"
+	// ActionRelink means the item is gone and the user has to link their account again.
+	ActionRelink RemediationAction = "RELINK"
+	// ActionContactInstitution means the user has to resolve an issue with their bank,
+	// e.g. unlock their account, before going through Link in update mode.
+	ActionContactInstitution RemediationAction = "CONTACT_INSTITUTION"
+	// ActionUnsupported means the item can't be used for the product; offer the user
+	// another way to connect.
+	ActionUnsupported RemediationAction = "UNSUPPORTED"
+	// ActionFixRequest means the request is invalid and needs a code or configuration fix.
+	ActionFixRequest RemediationAction = "FIX_REQUEST"
+	// ActionContactSupport means the error is not expected to resolve without Plaid support.
+	ActionContactSupport RemediationAction = "CONTACT_SUPPORT"
+)
+
+// RemediationSteps describes how to resolve an error returned by the client, so that every
+// product renders the same fix-it flow for the same error.
+type RemediationSteps struct {
+	Action                 RemediationAction
+	RequiresLinkUpdateMode bool
+	// Retryable reports whether the request can succeed if sent again unchanged.
+	Retryable  bool
+	RetryAfter time.Duration
+	// UserMessage is a message suitable for the end user, see UserMessage.
+	UserMessage string
+	// ActionLabel is a short label for the control that starts the action, e.g. "Reconnect
+	// your bank", or empty for actions the user can't take.
+	ActionLabel string
+
+	ErrorType string // empty if the error didn't come from Plaid
+	ErrorCode string
+}
+
+var remediations = map[string]RemediationSteps{
+	"ITEM_LOGIN_REQUIRED":      {Action: ActionLinkUpdate},
+	"PENDING_EXPIRATION":       {Action: ActionLinkUpdate},
+	"INSUFFICIENT_CREDENTIALS": {Action: ActionLinkUpdate},
+	"ACCESS_NOT_GRANTED":       {Action: ActionLinkUpdate},
+	"NO_ACCOUNTS":              {Action: ActionLinkUpdate},
+	"ITEM_LOCKED":              {Action: ActionContactInstitution},
+	"USER_SETUP_REQUIRED":      {Action: ActionContactInstitution},
+	"ITEM_NOT_SUPPORTED":       {Action: ActionUnsupported},
+	"MFA_NOT_SUPPORTED":        {Action: ActionUnsupported},
+	"INVALID_ACCESS_TOKEN":     {Action: ActionRelink},
+	"ITEM_NOT_FOUND":           {Action: ActionRelink},
+	"PRODUCT_NOT_READY": {
+		Action:     ActionRetry,
+		RetryAfter: time.Minute,
+	},
+	"INSTITUTION_DOWN": {
+		Action:     ActionRetry,
+		RetryAfter: 15 * time.Minute,
+	},
+	"INSTITUTION_NOT_RESPONDING": {
+		Action:     ActionRetry,
+		RetryAfter: 15 * time.Minute,
+	},
+	"INSTITUTION_NOT_AVAILABLE": {
+		Action:     ActionRetry,
+		RetryAfter: time.Hour,
+	},
+	"RATE_LIMIT_EXCEEDED": {
+		Action:     ActionRetry,
+		RetryAfter: time.Minute,
+	},
+	"PLANNED_MAINTENANCE": {
+		Action:     ActionRetry,
+		RetryAfter: 15 * time.Minute,
+	},
+	"INTERNAL_SERVER_ERROR": {
+		Action:     ActionRetry,
+		RetryAfter: time.Minute,
+	},
+}
+
+// remediationsByType apply to errors whose code has no entry in remediations.
+var remediationsByType = map[string]RemediationSteps{
+	"INVALID_REQUEST": {Action: ActionFixRequest},
+	"INVALID_INPUT":   {Action: ActionFixRequest},
+	"INVALID_RESULT":  {Action: ActionFixRequest},
+	"API_ERROR": {
+		Action:     ActionRetry,
+		RetryAfter: time.Minute,
+	},
+	"INSTITUTION_ERROR": {
+		Action:     ActionRetry,
+		RetryAfter: 15 * time.Minute,
+	},
+	"RATE_LIMIT_EXCEEDED": {
+		Action:     ActionRetry,
+		RetryAfter: time.Minute,
+	},
+}
+
+// actionLabels are the English labels of the actions the user can take.
+var actionLabels = map[RemediationAction]string{
+	ActionRetry:              "Try again",
+	ActionLinkUpdate:         "Reconnect your bank",
+	ActionRelink:             "Connect your bank",
+	ActionContactInstitution: "Reconnect your bank",
+	ActionUnsupported:        "Connect another account",
+}
+
+// Remediation returns how to resolve an error returned by the client. Of the errors that
+// didn't come from Plaid, network failures and timeouts are retryable; others, such as
+// invalid arguments, undecodable responses or a canceled context, call for ActionFixRequest.
+// It returns nil for a nil error.
+func Remediation(err error) *RemediationSteps {
+	return LocalizedRemediation(err, nil)
+}
+
+// LocalizedRemediation is like Remediation but translates the user message and the action
+// label with l. The labels are translated under "remediation." followed by the action,
+// e.g. "remediation.LINK_UPDATE".
+func LocalizedRemediation(err error, l Localizer) *RemediationSteps {
+	if err == nil {
+		return nil
+	}
+	var plaidErr plaidError
+	if !errors.As(err, &plaidErr) {
+		remediation := &RemediationSteps{Action: ActionFixRequest,
+			UserMessage: core.Localize(l, core.DefaultUserMessageKey, core.DefaultUserMessage)}
+		if retry.Transient(err) {
+			remediation.Action, remediation.Retryable, remediation.RetryAfter = ActionRetry, true, 30*time.Second
+		}
+		remediation.ActionLabel = actionLabel(l, remediation.Action)
+		return remediation
+	}
+
+	remediation, ok := remediations[plaidErr.ErrorCode]
+	if !ok {
+		remediation, ok = remediationsByType[plaidErr.ErrorType]
+	}
+	if !ok {
+		remediation = RemediationSteps{Action: ActionContactSupport}
+	}
+	remediation.ErrorType = plaidErr.ErrorType
+	remediation.ErrorCode = plaidErr.ErrorCode
+	remediation.RequiresLinkUpdateMode = remediation.Action == ActionLinkUpdate ||
+		remediation.Action == ActionContactInstitution
+	remediation.Retryable = remediation.Action == ActionRetry
+	remediation.UserMessage = plaidErr.LocalizedUserMessage(l)
+	remediation.ActionLabel = actionLabel(l, remediation.Action)
+	return &remediation
+}
+
+func actionLabel(l Localizer, action RemediationAction) string {
+	label, ok := actionLabels[action]
+	if !ok {
+		return ""
+	}
+	return core.Localize(l, "remediation."+string(action), label)
+}
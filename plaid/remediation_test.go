@@ -0,0 +1,36 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+func TestRemediationRetryable(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{"network failure", &url.Error{Op: "Post", URL: "https://sandbox.plaid.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		{"timeout", fmt.Errorf("/accounts/get: %w", context.DeadlineExceeded), true},
+		{"canceled", context.Canceled, false},
+		{"canceled request", &url.Error{Op: "Post", URL: "https://sandbox.plaid.com", Err: context.Canceled}, false},
+		{"validation", errors.New("/item/get - access token must be specified"), false},
+		{"decode", &core.DecodeError{Path: "accounts[0].balances", Err: errors.New("unexpected string")}, false},
+		{"rate limit", core.Error{ErrorType: "RATE_LIMIT_EXCEEDED", ErrorCode: "RATE_LIMIT_EXCEEDED"}, true},
+		{"login required", core.Error{ErrorType: "ITEM_ERROR", ErrorCode: "ITEM_LOGIN_REQUIRED"}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if steps := Remediation(c.err); steps.Retryable != c.retryable {
+				t.Errorf("Retryable = %v (action %s), want %v", steps.Retryable, steps.Action, c.retryable)
+			}
+		})
+	}
+}
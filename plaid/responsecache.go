@@ -0,0 +1,244 @@
+package plaid
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCachedEndpoints are the endpoints WithResponseCache caches unless given others.
+// Their responses are the same for every item and change rarely.
+var DefaultCachedEndpoints = []string{
+	"/institutions/get",
+	"/institutions/get_by_id",
+	"/institutions/search",
+	"/categories/get",
+}
+
+// CachedResponse is a successful response body kept by a ResponseCache, with the
+// validators Plaid sent along with it.
+type CachedResponse struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// ResponseCache persists responses across restarts, so that a restarting service doesn't
+// download e.g. the whole institution catalog again. Keys are opaque, safe for use as file
+// names and never contain credentials.
+type ResponseCache interface {
+	// GetResponse returns the response stored under key, or nil if there is none.
+	GetResponse(ctx context.Context, key string) (*CachedResponse, error)
+	PutResponse(ctx context.Context, key string, response CachedResponse) error
+}
+
+// WithResponseCache caches the successful responses of the given endpoints, or of
+// DefaultCachedEndpoints if none are given, in cache. A response younger than maxAge is
+// served without a request. An older one is revalidated with a conditional request
+// (If-None-Match or If-Modified-Since) where Plaid sent an ETag or Last-Modified header,
+// and served again if Plaid answers 304 Not Modified; otherwise it is fetched anew.
+//
+// Lookups emit EventCacheHit and EventCacheMiss for the cache "responses". Errors of the
+// cache are logged and otherwise ignored: the request is then sent as if nothing was
+// cached.
+func WithResponseCache(cache ResponseCache, maxAge time.Duration, endpoints ...string) Option {
+	return func(c *Client) {
+		if len(endpoints) == 0 {
+			endpoints = DefaultCachedEndpoints
+		}
+		r := &responseCache{cache: cache, maxAge: maxAge, endpoints: map[string]bool{}}
+		for _, endpoint := range endpoints {
+			r.endpoints[endpoint] = true
+		}
+		c.responses = r
+	}
+}
+
+type responseCache struct {
+	cache     ResponseCache
+	maxAge    time.Duration
+	endpoints map[string]bool
+}
+
+func (r *responseCache) caches(endpoint string) bool {
+	return r != nil && r.endpoints[endpoint]
+}
+
+// responseCacheKey identifies a request for the cache. It includes the environment and
+// API version the request is sent to, since clients of different environments or versions
+// may share a cache and get different responses to the same request. The secret is left
+// out, so that rotating it doesn't invalidate the cache.
+func (c *Client) responseCacheKey(method, endpoint string, jsonText []byte) string {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(jsonText, &fields) == nil {
+		delete(fields, "secret")
+		// Maps marshal with sorted keys, so equal requests get equal keys.
+		jsonText, _ = json.Marshal(fields)
+	}
+	prefix := string(c.environment) + " " + c.apiVersion + " " + method + " " + endpoint + " "
+	sum := sha256.Sum256(append([]byte(prefix), jsonText...))
+	return hex.EncodeToString(sum[:])
+}
+
+// cached serves a request from the cache, revalidates it, or sends it with send and stores
+// the response.
+func (c *Client) cached(ctx context.Context, method, endpoint string, jsonText []byte,
+	send func(ctx context.Context, header http.Header) (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+
+	key := c.responseCacheKey(method, endpoint, jsonText)
+	stored, err := c.responses.cache.GetResponse(ctx, key)
+	if err != nil {
+		c.logger.Printf("plaid: can't read the cached response of %s: %v", endpoint, err)
+		stored = nil
+	}
+	now := c.clock.Now()
+	if stored != nil && now.Sub(stored.StoredAt) < c.responses.maxAge {
+		c.emit(Event{Type: EventCacheHit, Cache: "responses", Endpoint: endpoint})
+		return cachedHTTPResponse(stored), stored.Body, nil
+	}
+	c.emit(Event{Type: EventCacheMiss, Cache: "responses", Endpoint: endpoint})
+
+	header := http.Header{}
+	if stored != nil && stored.ETag != "" {
+		header.Set("If-None-Match", stored.ETag)
+	}
+	if stored != nil && stored.LastModified != "" {
+		header.Set("If-Modified-Since", stored.LastModified)
+	}
+	res, raw, err := send(ctx, header)
+	if err != nil {
+		return res, raw, err
+	}
+	switch {
+	case res.StatusCode == http.StatusNotModified && stored != nil:
+		stored.StoredAt = now
+		c.putResponse(ctx, endpoint, key, *stored)
+		return cachedHTTPResponse(stored), stored.Body, nil
+	case res.StatusCode == http.StatusOK:
+		c.putResponse(ctx, endpoint, key, CachedResponse{
+			Body:         raw,
+			ETag:         res.Header.Get("ETag"),
+			LastModified: res.Header.Get("Last-Modified"),
+			StoredAt:     now,
+		})
+	}
+	return res, raw, nil
+}
+
+func (c *Client) putResponse(ctx context.Context, endpoint, key string, response CachedResponse) {
+	if err := c.responses.cache.PutResponse(ctx, key, response); err != nil {
+		c.logger.Printf("plaid: can't cache the response of %s: %v", endpoint, err)
+	}
+}
+
+// cachedHTTPResponse stands in for the response a cached body was received with.
+func cachedHTTPResponse(stored *CachedResponse) *http.Response {
+	header := http.Header{"Content-Type": {"application/json"}}
+	if stored.ETag != "" {
+		header.Set("ETag", stored.ETag)
+	}
+	return &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(stored.Body)),
+	}
+}
+
+// MemoryResponseCache is a ResponseCache that keeps responses in memory. It doesn't
+// survive restarts and is meant for tests.
+type MemoryResponseCache struct {
+	mu        sync.Mutex
+	responses map[string]CachedResponse
+}
+
+// NewMemoryResponseCache instantiates an empty MemoryResponseCache.
+func NewMemoryResponseCache() *MemoryResponseCache {
+	return &MemoryResponseCache{responses: map[string]CachedResponse{}}
+}
+
+// GetResponse implements ResponseCache.
+func (m *MemoryResponseCache) GetResponse(ctx context.Context, key string) (*CachedResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	response, ok := m.responses[key]
+	if !ok {
+		return nil, nil
+	}
+	return &response, nil
+}
+
+// PutResponse implements ResponseCache.
+func (m *MemoryResponseCache) PutResponse(ctx context.Context, key string, response CachedResponse) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses[key] = response
+	return nil
+}
+
+// DirResponseCache is a ResponseCache that keeps each response in a file of a directory.
+type DirResponseCache struct {
+	Dir string
+}
+
+// GetResponse implements ResponseCache.
+func (d DirResponseCache) GetResponse(ctx context.Context, key string) (*CachedResponse, error) {
+	data, err := os.ReadFile(d.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var response CachedResponse
+	if err = json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// PutResponse implements ResponseCache. The file is replaced atomically, so that
+// concurrent readers and crashes never see a partial response.
+func (d DirResponseCache) PutResponse(ctx context.Context, key string, response CachedResponse) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(d.Dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(d.Dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), d.path(key))
+}
+
+func (d DirResponseCache) path(key string) string {
+	return filepath.Join(d.Dir, strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, key)+".json")
+}
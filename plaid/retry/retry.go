@@ -0,0 +1,73 @@
+// Package retry decides which failed requests are worth repeating and repeats them. It
+// doesn't depend on the plaid package, whose Client retries its multi-request helpers and
+// classifies errors through it; see package core for how the plaid package is split up.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Transient reports whether an error that didn't come from Plaid is a timeout, a failure to
+// connect or a reset connection, which repeating the request may resolve. A canceled
+// context is not transient, and neither are other network errors, e.g. an invalid
+// certificate, an unknown host or an unsupported URL scheme.
+func Transient(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "dial" || errors.Is(opErr.Err, syscall.ECONNRESET))
+}
+
+// Policy bounds each attempt of a request, and repeats attempts that exceeded their bound.
+type Policy struct {
+	// PerCall bounds each attempt. Zero makes a single attempt, bounded only by its context.
+	PerCall time.Duration
+	// Retries is the number of times an attempt that exceeded PerCall is repeated.
+	Retries int
+}
+
+// Do runs attempt with the PerCall timeout, repeating it if it exceeded the timeout while ctx
+// is still alive, and calls onRetry, which may be nil, before every repetition. name
+// identifies the request in the error returned when every attempt timed out.
+func (p Policy) Do(ctx context.Context, name string, attempt func(ctx context.Context) error,
+	onRetry func()) error {
+
+	if p.PerCall <= 0 {
+		return attempt(ctx)
+	}
+	for i := 0; ; i++ {
+		callCtx, cancel := context.WithTimeout(ctx, p.PerCall)
+		err := attempt(callCtx)
+		cancel()
+		if err == nil || !errors.Is(err, context.DeadlineExceeded) || ctx.Err() != nil {
+			return err
+		}
+		if i >= p.Retries {
+			return fmt.Errorf("%s - exceeded the per-call timeout of %s %d times: %w", name,
+				p.PerCall, i+1, err)
+		}
+		if onRetry != nil {
+			onRetry()
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestPolicyDo(t *testing.T) {
+	policy := Policy{PerCall: time.Millisecond, Retries: 2}
+	attempts, retries := 0, 0
+	err := policy.Do(context.Background(), "/accounts/get", func(ctx context.Context) error {
+		attempts++
+		<-ctx.Done()
+		return ctx.Err()
+	}, func() { retries++ })
+	if !errors.Is(err, context.DeadlineExceeded) || attempts != 3 || retries != 2 {
+		t.Fatalf("Do = %v after %d attempts and %d retries, want a timeout after 3 attempts", err, attempts, retries)
+	}
+
+	attempts = 0
+	want := errors.New("INVALID_REQUEST")
+	err = policy.Do(context.Background(), "/accounts/get", func(ctx context.Context) error {
+		attempts++
+		return want
+	}, nil)
+	if err != want || attempts != 1 {
+		t.Fatalf("Do = %v after %d attempts, want other errors returned as is", err, attempts)
+	}
+}
+
+func TestTransient(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+	_, untrusted := http.Get(server.URL)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+	_, refused := http.Get("http://" + address)
+
+	_, scheme := http.Get("ftp://example.com")
+
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	for _, tc := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"deadline", context.DeadlineExceeded, true},
+		{"wrapped deadline", fmt.Errorf("call: %w", os.ErrDeadlineExceeded), true},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"connection refused", refused, true},
+		{"connection reset", fmt.Errorf("call: %w", reset), true},
+		{"canceled", context.Canceled, false},
+		{"untrusted certificate", untrusted, false},
+		{"unsupported scheme", scheme, false},
+		{"unknown host", &net.OpError{Op: "dial", Err: &net.DNSError{Name: "nowhere.invalid", IsNotFound: true}}, false},
+		{"other", errors.New("boom"), false},
+	} {
+		if tc.err == nil {
+			t.Fatalf("%s: no error to classify", tc.name)
+		}
+		if got := Transient(tc.err); got != tc.want {
+			t.Errorf("Transient(%s: %v) = %v, want %v", tc.name, tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package plaid
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/transactions"
+)
+
+// PersonalFinanceCategory is Plaid's two level transaction categorization, see
+// transactions.PersonalFinanceCategory.
+type PersonalFinanceCategory = transactions.PersonalFinanceCategory
+
+// UncategorizedCategory is the category rollups use for transactions without a personal
+// finance category.
+const UncategorizedCategory = "UNCATEGORIZED"
+
+// Period is the length of the calendar periods a rollup groups transactions by.
+type Period string
+
+const (
+	Weekly  Period = "weekly" // weeks start on Monday
+	Monthly Period = "monthly"
+)
+
+// PendingMode controls how rollups treat pending transactions.
+type PendingMode int
+
+const (
+	ExcludePending PendingMode = iota
+	IncludePending
+	OnlyPending
+)
+
+// RollupOptions represents options associated with rolling up transactions.
+type RollupOptions struct {
+	Period  Period // defaults to Monthly
+	Pending PendingMode
+
+	// BaseCurrency and Rates, if both set, convert the amounts of transactions in other
+	// currencies to BaseCurrency at the rate of their date before totaling them. Amounts
+	// Rates has no rate for, see ErrNoRate, are totaled unconverted.
+	BaseCurrency string
+	Rates        RateProvider
+}
+
+// CategorySummary totals the transactions of one category within one period.
+type CategorySummary struct {
+	Category    string // primary personal finance category
+	PeriodStart string // first day of the period, YYYY-MM-DD
+	Count       int
+	Total       float64
+}
+
+// RollupByCategory totals transactions by primary personal finance category and calendar
+// period. Summaries are ordered by period and then category.
+func RollupByCategory(transactions []Transaction, options RollupOptions) ([]CategorySummary, error) {
+	type rollupKey struct{ category, periodStart string }
+	byKey := map[rollupKey]*CategorySummary{}
+
+	for _, t := range transactions {
+		if !options.Pending.includes(t) {
+			continue
+		}
+		periodStart, err := options.Period.start(t.Date)
+		if err != nil {
+			return nil, err
+		}
+		category := UncategorizedCategory
+		if t.PersonalFinanceCategory != nil && t.PersonalFinanceCategory.Primary != "" {
+			category = t.PersonalFinanceCategory.Primary
+		}
+
+		key := rollupKey{category, periodStart}
+		summary, ok := byKey[key]
+		if !ok {
+			summary = &CategorySummary{Category: category, PeriodStart: periodStart}
+			byKey[key] = summary
+		}
+		amount, _, err := convertAmount(t, options.BaseCurrency, options.Rates)
+		if err != nil {
+			return nil, err
+		}
+		summary.Count++
+		summary.Total += amount
+	}
+
+	summaries := make([]CategorySummary, 0, len(byKey))
+	for _, summary := range byKey {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].PeriodStart != summaries[j].PeriodStart {
+			return summaries[i].PeriodStart < summaries[j].PeriodStart
+		}
+		return summaries[i].Category < summaries[j].Category
+	})
+	return summaries, nil
+}
+
+func (m PendingMode) includes(t Transaction) bool {
+	switch m {
+	case IncludePending:
+		return true
+	case OnlyPending:
+		return t.Pending
+	}
+	return !t.Pending
+}
+
+// start returns the first day of the period containing date.
+func (p Period) start(date string) (string, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "", err
+	}
+	if p == Weekly {
+		// time.Weekday starts on Sunday, shift so that Monday is 0.
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset).Format("2006-01-02"), nil
+	}
+	return day.AddDate(0, 0, 1-day.Day()).Format("2006-01-02"), nil
+}
@@ -0,0 +1,146 @@
+package plaid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// SandboxItemFireWebhook (POST /sandbox/item/fire_webhook) makes Plaid send a webhook with
+// the given code, e.g. "DEFAULT_UPDATE", for a sandbox item.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxitemfire_webhook.
+func (c *Client) SandboxItemFireWebhook(accessToken, webhookCode string) (postRes *postResponse, err error) {
+	return c.SandboxItemFireWebhookContext(context.Background(), accessToken, webhookCode)
+}
+
+// SandboxItemFireWebhookContext is like SandboxItemFireWebhook but carries a context.
+func (c *Client) SandboxItemFireWebhookContext(ctx context.Context, accessToken,
+	webhookCode string) (postRes *postResponse, err error) {
+
+	creds := c.creds.Load()
+	jsonText, err := json.Marshal(sandboxFireWebhookJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+		WebhookCode: webhookCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/sandbox/item/fire_webhook", bytes.NewReader(jsonText))
+	return postRes, err
+}
+
+// SandboxPublicTokenCreate (POST /sandbox/public_token/create) creates a public token for a
+// new sandbox item without going through Link, e.g. institutionID "ins_109508" and
+// products []string{"transactions"}.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxpublic_tokencreate.
+func (c *Client) SandboxPublicTokenCreate(institutionID string,
+	initialProducts []string) (*sandboxPublicTokenCreateResponse, error) {
+	return c.SandboxPublicTokenCreateContext(context.Background(), institutionID, initialProducts)
+}
+
+// SandboxPublicTokenCreateContext is like SandboxPublicTokenCreate but carries a context.
+func (c *Client) SandboxPublicTokenCreateContext(ctx context.Context, institutionID string,
+	initialProducts []string) (*sandboxPublicTokenCreateResponse, error) {
+	return c.SandboxPublicTokenCreateWithOptionsContext(ctx, institutionID, initialProducts, nil)
+}
+
+// SandboxPublicTokenCreateOptions represents the optional fields of a sandbox public token.
+type SandboxPublicTokenCreateOptions struct {
+	// OverrideUsername and OverridePassword log in as a sandbox test user other than
+	// user_good, e.g. SandboxBankIncomeUsername.
+	OverrideUsername string
+	OverridePassword string
+	Webhook          string
+	// UserToken, as returned by UserCreate, links the item to a user. Income verification
+	// requires it.
+	UserToken string
+	// IncomeSourceTypes are the income sources to verify, e.g. "bank" or "payroll".
+	IncomeSourceTypes []string
+	// BankIncomeDaysRequested is the number of days of history bank income is computed
+	// from.
+	BankIncomeDaysRequested int
+}
+
+// SandboxPublicTokenCreateWithOptions is like SandboxPublicTokenCreate but sets the
+// optional fields of the item, e.g. to log in as a sandbox test user.
+func (c *Client) SandboxPublicTokenCreateWithOptions(institutionID string, initialProducts []string,
+	options *SandboxPublicTokenCreateOptions) (*sandboxPublicTokenCreateResponse, error) {
+	return c.SandboxPublicTokenCreateWithOptionsContext(context.Background(), institutionID, initialProducts,
+		options)
+}
+
+// SandboxPublicTokenCreateWithOptionsContext is like SandboxPublicTokenCreateWithOptions
+// but carries a context.
+func (c *Client) SandboxPublicTokenCreateWithOptionsContext(ctx context.Context, institutionID string,
+	initialProducts []string, options *SandboxPublicTokenCreateOptions) (*sandboxPublicTokenCreateResponse, error) {
+
+	creds := c.creds.Load()
+	request := sandboxPublicTokenCreateJson{
+		ClientID:        creds.clientID,
+		Secret:          creds.secret,
+		InstitutionID:   institutionID,
+		InitialProducts: initialProducts,
+	}
+	if options != nil {
+		request.UserToken = options.UserToken
+		request.Options = &sandboxPublicTokenOptionsJson{
+			Webhook:          options.Webhook,
+			OverrideUsername: options.OverrideUsername,
+			OverridePassword: options.OverridePassword,
+		}
+		if len(options.IncomeSourceTypes) > 0 || options.BankIncomeDaysRequested > 0 {
+			income := &sandboxIncomeVerificationJson{IncomeSourceTypes: options.IncomeSourceTypes}
+			if options.BankIncomeDaysRequested > 0 {
+				income.BankIncome = &sandboxBankIncomeJson{DaysRequested: options.BankIncomeDaysRequested}
+			}
+			request.Options.IncomeVerification = income
+		}
+	}
+	var res sandboxPublicTokenCreateResponse
+	if err := c.postAndDecode(ctx, "/sandbox/public_token/create", request, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+type sandboxPublicTokenCreateResponse struct {
+	PublicToken string `json:"public_token"`
+	RequestID   string `json:"request_id"`
+}
+
+type sandboxPublicTokenCreateJson struct {
+	ClientID        string   `json:"client_id"`
+	Secret          string   `json:"secret"`
+	InstitutionID   string   `json:"institution_id"`
+	InitialProducts []string `json:"initial_products"`
+	UserToken       string   `json:"user_token,omitempty"`
+
+	Options *sandboxPublicTokenOptionsJson `json:"options,omitempty"`
+}
+
+type sandboxPublicTokenOptionsJson struct {
+	Webhook            string                         `json:"webhook,omitempty"`
+	OverrideUsername   string                         `json:"override_username,omitempty"`
+	OverridePassword   string                         `json:"override_password,omitempty"`
+	IncomeVerification *sandboxIncomeVerificationJson `json:"income_verification,omitempty"`
+}
+
+type sandboxIncomeVerificationJson struct {
+	IncomeSourceTypes []string               `json:"income_source_types,omitempty"`
+	BankIncome        *sandboxBankIncomeJson `json:"bank_income,omitempty"`
+}
+
+type sandboxBankIncomeJson struct {
+	DaysRequested int `json:"days_requested"`
+}
+
+type sandboxFireWebhookJson struct {
+	ClientID    string `json:"client_id"`
+	Secret      string `json:"secret"`
+	AccessToken string `json:"access_token"`
+	WebhookCode string `json:"webhook_code"`
+}
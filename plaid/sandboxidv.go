@@ -0,0 +1,102 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// SandboxIdentityVerificationOutcome is the outcome a simulated identity verification
+// session is forced to.
+type SandboxIdentityVerificationOutcome int
+
+const (
+	SandboxIdentityVerificationPass SandboxIdentityVerificationOutcome = iota
+	SandboxIdentityVerificationFail
+)
+
+// SandboxIdentityVerificationUser returns identity details the sandbox verifies with the
+// given outcome. The passing details are those of the sandbox persona Leslie Knope; the
+// failing ones are the same with a date of birth that doesn't match hers, which fails the
+// KYC check.
+//
+// See https://plaid.com/docs/identity-verification/testing/.
+func SandboxIdentityVerificationUser(outcome SandboxIdentityVerificationOutcome) IdentityVerificationUser {
+	user := IdentityVerificationUser{
+		EmailAddress: "leslie@knope.com",
+		PhoneNumber:  "+12345678909",
+		DateOfBirth:  "1975-01-18",
+		Name:         &IdentityVerificationName{GivenName: "Leslie", FamilyName: "Knope"},
+		Address: &UserAddress{
+			Street:     "123 Main St.",
+			City:       "Pawnee",
+			Region:     "IN",
+			PostalCode: "46001",
+			Country:    "US",
+		},
+		IDNumber: &IdentityVerificationID{Value: "123456789", Type: "us_ssn"},
+	}
+	if outcome == SandboxIdentityVerificationFail {
+		user.DateOfBirth = "1990-06-15"
+	}
+	return user
+}
+
+// SandboxIdentityVerificationTimeout is how long SandboxIdentityVerificationSimulate waits
+// for a session to reach a terminal status.
+const SandboxIdentityVerificationTimeout = 2 * time.Minute
+
+// ErrIdentityVerificationPending is returned, together with the session, by
+// SandboxIdentityVerificationSimulate for a session still active after
+// SandboxIdentityVerificationTimeout.
+var ErrIdentityVerificationPending = errors.New("identity verification session is still active")
+
+// SandboxIdentityVerificationSimulate runs a sandbox identity verification session for
+// clientUserID without Link and waits for it to reach a terminal status, which follows
+// outcome. The template must only have steps that run without the user, such as the KYC
+// check; sessions of templates with a documentary or selfie step stay active and are
+// returned with ErrIdentityVerificationPending after SandboxIdentityVerificationTimeout.
+// poll defaults to polling every second, up to every 10 seconds.
+func (c *Client) SandboxIdentityVerificationSimulate(templateID, clientUserID string,
+	outcome SandboxIdentityVerificationOutcome, poll *VerificationPollOptions) (*IdentityVerification, error) {
+	return c.SandboxIdentityVerificationSimulateContext(context.Background(), templateID, clientUserID, outcome, poll)
+}
+
+// SandboxIdentityVerificationSimulateContext is like SandboxIdentityVerificationSimulate
+// but carries a context. The session is returned with ctx's error if ctx is done first.
+func (c *Client) SandboxIdentityVerificationSimulateContext(ctx context.Context, templateID, clientUserID string,
+	outcome SandboxIdentityVerificationOutcome, poll *VerificationPollOptions) (*IdentityVerification, error) {
+
+	interval, maxInterval := time.Second, 10*time.Second
+	if poll != nil && poll.InitialInterval > 0 {
+		interval = poll.InitialInterval
+	}
+	if poll != nil && poll.MaxInterval > 0 {
+		maxInterval = poll.MaxInterval
+	}
+
+	session, err := c.IdentityVerificationCreateContext(ctx, templateID, clientUserID,
+		SandboxIdentityVerificationUser(outcome), &IdentityVerificationCreateOptions{GaveConsent: true})
+	if err != nil {
+		return nil, err
+	}
+	deadline := c.clock.Now().Add(SandboxIdentityVerificationTimeout)
+	for !session.Status.Terminal() {
+		if !c.clock.Now().Before(deadline) {
+			return session, ErrIdentityVerificationPending
+		}
+		select {
+		case <-ctx.Done():
+			return session, ctx.Err()
+		case <-c.clock.After(interval):
+		}
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+		if session, err = c.IdentityVerificationGetContext(ctx, session.ID); err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
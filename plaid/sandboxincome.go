@@ -0,0 +1,119 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+)
+
+// The sandbox test user whose items have bank income: a steady paycheck and a few other
+// inflows. Other income profiles can be configured by passing a JSON custom user
+// configuration as the password.
+//
+// See https://plaid.com/docs/sandbox/test-credentials/#credit-and-income-testing-credentials.
+const (
+	SandboxBankIncomeUsername = "user_bank_income"
+	SandboxBankIncomePassword = "{}"
+)
+
+// IncomeVerificationStatus is the outcome of an income verification, as reported by the
+// INCOME_VERIFICATION webhook.
+type IncomeVerificationStatus string
+
+const (
+	IncomeVerificationProcessingComplete IncomeVerificationStatus = "VERIFICATION_STATUS_PROCESSING_COMPLETE"
+	IncomeVerificationProcessingFailed   IncomeVerificationStatus = "VERIFICATION_STATUS_PROCESSING_FAILED"
+	IncomeVerificationUploadError        IncomeVerificationStatus = "VERIFICATION_STATUS_UPLOAD_ERROR"
+	IncomeVerificationInvalidType        IncomeVerificationStatus = "VERIFICATION_STATUS_INVALID_TYPE"
+	IncomeVerificationDocumentRejected   IncomeVerificationStatus = "VERIFICATION_STATUS_DOCUMENT_REJECTED"
+)
+
+// SandboxIncomeFireWebhook (POST /sandbox/income/fire_webhook) makes Plaid send the
+// INCOME_VERIFICATION webhook of a sandbox user to webhook with the given status, so that
+// both the successful and the failed outcome of a verification can be handled in tests.
+// itemID is optional.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxincomefire_webhook.
+func (c *Client) SandboxIncomeFireWebhook(userID string, itemID ItemID, webhook string,
+	status IncomeVerificationStatus) error {
+	return c.SandboxIncomeFireWebhookContext(context.Background(), userID, itemID, webhook, status)
+}
+
+// SandboxIncomeFireWebhookContext is like SandboxIncomeFireWebhook but carries a context.
+func (c *Client) SandboxIncomeFireWebhookContext(ctx context.Context, userID string, itemID ItemID, webhook string,
+	status IncomeVerificationStatus) error {
+
+	if userID == "" {
+		return errors.New("/sandbox/income/fire_webhook - user id must be specified")
+	}
+	if webhook == "" {
+		return errors.New("/sandbox/income/fire_webhook - webhook must be specified")
+	}
+	var res struct {
+		RequestID string `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	return c.postAndDecode(ctx, "/sandbox/income/fire_webhook", sandboxIncomeFireWebhookJson{
+		ClientID:           creds.clientID,
+		Secret:             creds.secret,
+		UserID:             userID,
+		ItemID:             itemID,
+		Webhook:            webhook,
+		VerificationStatus: status,
+	}, &res)
+}
+
+// SandboxIncomeFixture is a sandbox user with an item to verify the income of.
+type SandboxIncomeFixture struct {
+	UserToken   string
+	UserID      string
+	AccessToken string
+	ItemID      ItemID
+}
+
+// SandboxBankIncomeFixture creates a sandbox user for clientUserID and links an item of
+// the bank income test user at institutionID to it, e.g. "ins_109508", ready for
+// /credit/bank_income/get. webhook is optional and receives the item's webhooks.
+func (c *Client) SandboxBankIncomeFixture(clientUserID, institutionID, webhook string) (*SandboxIncomeFixture, error) {
+	return c.SandboxBankIncomeFixtureContext(context.Background(), clientUserID, institutionID, webhook)
+}
+
+// SandboxBankIncomeFixtureContext is like SandboxBankIncomeFixture but carries a context.
+func (c *Client) SandboxBankIncomeFixtureContext(ctx context.Context, clientUserID, institutionID,
+	webhook string) (*SandboxIncomeFixture, error) {
+
+	user, err := c.UserCreateContext(ctx, clientUserID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := c.SandboxPublicTokenCreateWithOptionsContext(ctx, institutionID, []string{"income_verification"},
+		&SandboxPublicTokenCreateOptions{
+			OverrideUsername:        SandboxBankIncomeUsername,
+			OverridePassword:        SandboxBankIncomePassword,
+			Webhook:                 webhook,
+			UserToken:               user.UserToken,
+			IncomeSourceTypes:       []string{"bank"},
+			BankIncomeDaysRequested: 365,
+		})
+	if err != nil {
+		return nil, err
+	}
+	exchange, err := c.ItemPublicTokenExchangeContext(ctx, token.PublicToken)
+	if err != nil {
+		return nil, err
+	}
+	return &SandboxIncomeFixture{
+		UserToken:   user.UserToken,
+		UserID:      user.UserID,
+		AccessToken: exchange.AccessToken,
+		ItemID:      exchange.ItemID,
+	}, nil
+}
+
+type sandboxIncomeFireWebhookJson struct {
+	ClientID           string                   `json:"client_id"`
+	Secret             string                   `json:"secret"`
+	UserID             string                   `json:"user_id"`
+	ItemID             ItemID                   `json:"item_id,omitempty"`
+	Webhook            string                   `json:"webhook"`
+	VerificationStatus IncomeVerificationStatus `json:"verification_status"`
+}
@@ -0,0 +1,338 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// TransferEventType is the type of a transfer event. The sandbox can simulate the events
+// that move a transfer through its lifecycle.
+//
+// See https://plaid.com/docs/api/products/transfer/#sandboxtransfersimulate-request-event-type.
+type TransferEventType string
+
+const (
+	TransferEventPosted         TransferEventType = "posted"
+	TransferEventSettled        TransferEventType = "settled"
+	TransferEventFundsAvailable TransferEventType = "funds_available"
+	TransferEventFailed         TransferEventType = "failed"
+	TransferEventReturned       TransferEventType = "returned"
+)
+
+// SandboxTransferSimulateOptions represents the optional fields of a simulated transfer
+// event.
+type SandboxTransferSimulateOptions struct {
+	// FailureReason is the reason of a failed or returned transfer, e.g. ACHReturnCode
+	// "R01" for insufficient funds.
+	FailureReason *TransferFailureReason
+	// TestClockID, if set, timestamps the event with the test clock's virtual time.
+	TestClockID string
+	// Webhook, if set, receives the TRANSFER_EVENTS_UPDATE webhook the event triggers.
+	Webhook string
+}
+
+// TransferFailureReason is why a transfer failed or was returned.
+type TransferFailureReason struct {
+	ACHReturnCode string `json:"ach_return_code,omitempty"`
+	Description   string `json:"description,omitempty"`
+}
+
+// SandboxTransferSimulate (POST /sandbox/transfer/simulate) simulates an event of a sandbox
+// transfer, e.g. TransferEventPosted and then TransferEventSettled. Events must follow the
+// transfer's lifecycle: a transfer must be posted before it settles or is returned.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxtransfersimulate.
+func (c *Client) SandboxTransferSimulate(transferID string, eventType TransferEventType,
+	options *SandboxTransferSimulateOptions) error {
+	return c.SandboxTransferSimulateContext(context.Background(), transferID, eventType, options)
+}
+
+// SandboxTransferSimulateContext is like SandboxTransferSimulate but carries a context.
+func (c *Client) SandboxTransferSimulateContext(ctx context.Context, transferID string,
+	eventType TransferEventType, options *SandboxTransferSimulateOptions) error {
+
+	if transferID == "" {
+		return errors.New("/sandbox/transfer/simulate - transfer id must be specified")
+	}
+	if eventType == "" {
+		return errors.New("/sandbox/transfer/simulate - event type must be specified")
+	}
+	creds := c.creds.Load()
+	request := sandboxTransferSimulateJson{
+		ClientID:   creds.clientID,
+		Secret:     creds.secret,
+		TransferID: transferID,
+		EventType:  eventType,
+	}
+	if options != nil {
+		request.FailureReason = options.FailureReason
+		request.TestClockID = options.TestClockID
+		request.Webhook = options.Webhook
+	}
+	var res struct {
+		RequestID string `json:"request_id"`
+	}
+	return c.postAndDecode(ctx, "/sandbox/transfer/simulate", request, &res)
+}
+
+// SandboxTransferSweepSimulate (POST /sandbox/transfer/sweep/simulate) simulates a sweep,
+// which collects the sandbox transfers that settled since the last sweep and moves their
+// funds to or from your funding account. It returns nil if there was nothing to sweep.
+// testClockID is optional.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxtransfersweepsimulate.
+func (c *Client) SandboxTransferSweepSimulate(testClockID, webhook string) (*TransferSweep, error) {
+	return c.SandboxTransferSweepSimulateContext(context.Background(), testClockID, webhook)
+}
+
+// SandboxTransferSweepSimulateContext is like SandboxTransferSweepSimulate but carries a
+// context.
+func (c *Client) SandboxTransferSweepSimulateContext(ctx context.Context, testClockID,
+	webhook string) (*TransferSweep, error) {
+
+	var res struct {
+		Sweep     *TransferSweep `json:"sweep"`
+		RequestID string         `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/sandbox/transfer/sweep/simulate", sandboxTransferSweepSimulateJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		TestClockID: testClockID,
+		Webhook:     webhook,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Sweep, nil
+}
+
+// TransferSweep is a movement of funds between your funding account and Plaid that settles
+// a batch of transfers.
+//
+// See https://plaid.com/docs/api/products/transfer/#transfersweepget-response-sweep.
+type TransferSweep struct {
+	ID               string `json:"id"`
+	FundingAccountID string `json:"funding_account_id"`
+	Created          string `json:"created"` // RFC 3339 timestamp
+	// Amount is a decimal string, positive when funds are swept into your funding account
+	// and negative when they are swept out of it.
+	Amount          string `json:"amount"`
+	IsoCurrencyCode string `json:"iso_currency_code"`
+	Settled         string `json:"settled"` // YYYY-MM-DD, empty until settled
+	Status          string `json:"status"`
+	Trigger         string `json:"trigger"`
+	Description     string `json:"description"`
+}
+
+// SandboxTransferFireWebhook (POST /sandbox/transfer/fire_webhook) makes Plaid send a
+// TRANSFER_EVENTS_UPDATE webhook to webhook, without simulating an event.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxtransferfire_webhook.
+func (c *Client) SandboxTransferFireWebhook(webhook string) error {
+	return c.SandboxTransferFireWebhookContext(context.Background(), webhook)
+}
+
+// SandboxTransferFireWebhookContext is like SandboxTransferFireWebhook but carries a
+// context.
+func (c *Client) SandboxTransferFireWebhookContext(ctx context.Context, webhook string) error {
+	if webhook == "" {
+		return errors.New("/sandbox/transfer/fire_webhook - webhook must be specified")
+	}
+	var res struct {
+		RequestID string `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	return c.postAndDecode(ctx, "/sandbox/transfer/fire_webhook", sandboxTransferFireWebhookJson{
+		ClientID: creds.clientID,
+		Secret:   creds.secret,
+		Webhook:  webhook,
+	}, &res)
+}
+
+// TransferTestClock is a sandbox clock that transfers and their events can be attached to.
+// Advancing it makes the time-dependent parts of a transfer's lifecycle, such as
+// recurring transfers, happen without waiting for them.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxtransfertest_clockcreate.
+type TransferTestClock struct {
+	TestClockID string `json:"test_clock_id"`
+	VirtualTime string `json:"virtual_time"` // RFC 3339 timestamp
+}
+
+// Time returns the clock's virtual time.
+func (tc *TransferTestClock) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, tc.VirtualTime)
+}
+
+// SandboxTransferTestClockCreate (POST /sandbox/transfer/test_clock/create) creates a test
+// clock at virtualTime, or at the current time if virtualTime is zero.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxtransfertest_clockcreate.
+func (c *Client) SandboxTransferTestClockCreate(virtualTime time.Time) (*TransferTestClock, error) {
+	return c.SandboxTransferTestClockCreateContext(context.Background(), virtualTime)
+}
+
+// SandboxTransferTestClockCreateContext is like SandboxTransferTestClockCreate but carries
+// a context.
+func (c *Client) SandboxTransferTestClockCreateContext(ctx context.Context,
+	virtualTime time.Time) (*TransferTestClock, error) {
+
+	creds := c.creds.Load()
+	request := sandboxTransferTestClockJson{
+		ClientID: creds.clientID,
+		Secret:   creds.secret,
+	}
+	if !virtualTime.IsZero() {
+		request.VirtualTime = virtualTime.UTC().Format(time.RFC3339)
+	}
+	var res transferTestClockResponse
+	if err := c.postAndDecode(ctx, "/sandbox/transfer/test_clock/create", request, &res); err != nil {
+		return nil, err
+	}
+	return &res.TestClock, nil
+}
+
+// SandboxTransferTestClockAdvance (POST /sandbox/transfer/test_clock/advance) moves a test
+// clock forward to newVirtualTime. Test clocks can't move backwards.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxtransfertest_clockadvance.
+func (c *Client) SandboxTransferTestClockAdvance(testClockID string, newVirtualTime time.Time) error {
+	return c.SandboxTransferTestClockAdvanceContext(context.Background(), testClockID, newVirtualTime)
+}
+
+// SandboxTransferTestClockAdvanceContext is like SandboxTransferTestClockAdvance but
+// carries a context.
+func (c *Client) SandboxTransferTestClockAdvanceContext(ctx context.Context, testClockID string,
+	newVirtualTime time.Time) error {
+
+	if testClockID == "" {
+		return errors.New("/sandbox/transfer/test_clock/advance - test clock id must be specified")
+	}
+	if newVirtualTime.IsZero() {
+		return errors.New("/sandbox/transfer/test_clock/advance - new virtual time must be specified")
+	}
+	var res struct {
+		RequestID string `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	return c.postAndDecode(ctx, "/sandbox/transfer/test_clock/advance", sandboxTransferTestClockJson{
+		ClientID:       creds.clientID,
+		Secret:         creds.secret,
+		TestClockID:    testClockID,
+		NewVirtualTime: newVirtualTime.UTC().Format(time.RFC3339),
+	}, &res)
+}
+
+// SandboxTransferTestClockGet (POST /sandbox/transfer/test_clock/get) retrieves a test
+// clock.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxtransfertest_clockget.
+func (c *Client) SandboxTransferTestClockGet(testClockID string) (*TransferTestClock, error) {
+	return c.SandboxTransferTestClockGetContext(context.Background(), testClockID)
+}
+
+// SandboxTransferTestClockGetContext is like SandboxTransferTestClockGet but carries a
+// context.
+func (c *Client) SandboxTransferTestClockGetContext(ctx context.Context,
+	testClockID string) (*TransferTestClock, error) {
+
+	if testClockID == "" {
+		return nil, errors.New("/sandbox/transfer/test_clock/get - test clock id must be specified")
+	}
+	var res transferTestClockResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/sandbox/transfer/test_clock/get", sandboxTransferTestClockJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		TestClockID: testClockID,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.TestClock, nil
+}
+
+// SandboxTransferTestClockList (POST /sandbox/transfer/test_clock/list) lists up to count
+// test clocks, starting at offset, whose virtual time is between start and end. A zero
+// start or end leaves that side of the range open.
+//
+// See https://plaid.com/docs/api/sandbox/#sandboxtransfertest_clocklist.
+func (c *Client) SandboxTransferTestClockList(start, end time.Time, count,
+	offset int) ([]TransferTestClock, error) {
+	return c.SandboxTransferTestClockListContext(context.Background(), start, end, count, offset)
+}
+
+// SandboxTransferTestClockListContext is like SandboxTransferTestClockList but carries a
+// context.
+func (c *Client) SandboxTransferTestClockListContext(ctx context.Context, start, end time.Time, count,
+	offset int) ([]TransferTestClock, error) {
+
+	creds := c.creds.Load()
+	request := sandboxTransferTestClockListJson{
+		ClientID: creds.clientID,
+		Secret:   creds.secret,
+		Count:    count,
+		Offset:   offset,
+	}
+	if !start.IsZero() {
+		request.StartVirtualTime = start.UTC().Format(time.RFC3339)
+	}
+	if !end.IsZero() {
+		request.EndVirtualTime = end.UTC().Format(time.RFC3339)
+	}
+	var res struct {
+		TestClocks []TransferTestClock `json:"test_clocks"`
+		RequestID  string              `json:"request_id"`
+	}
+	if err := c.postAndDecode(ctx, "/sandbox/transfer/test_clock/list", request, &res); err != nil {
+		return nil, err
+	}
+	return res.TestClocks, nil
+}
+
+type transferTestClockResponse struct {
+	TestClock TransferTestClock `json:"test_clock"`
+	RequestID string            `json:"request_id"`
+}
+
+type sandboxTransferSimulateJson struct {
+	ClientID      string                 `json:"client_id"`
+	Secret        string                 `json:"secret"`
+	TransferID    string                 `json:"transfer_id"`
+	EventType     TransferEventType      `json:"event_type"`
+	FailureReason *TransferFailureReason `json:"failure_reason,omitempty"`
+	TestClockID   string                 `json:"test_clock_id,omitempty"`
+	Webhook       string                 `json:"webhook,omitempty"`
+}
+
+type sandboxTransferSweepSimulateJson struct {
+	ClientID    string `json:"client_id"`
+	Secret      string `json:"secret"`
+	TestClockID string `json:"test_clock_id,omitempty"`
+	Webhook     string `json:"webhook,omitempty"`
+}
+
+type sandboxTransferFireWebhookJson struct {
+	ClientID string `json:"client_id"`
+	Secret   string `json:"secret"`
+	Webhook  string `json:"webhook"`
+}
+
+type sandboxTransferTestClockJson struct {
+	ClientID       string `json:"client_id"`
+	Secret         string `json:"secret"`
+	TestClockID    string `json:"test_clock_id,omitempty"`
+	VirtualTime    string `json:"virtual_time,omitempty"`
+	NewVirtualTime string `json:"new_virtual_time,omitempty"`
+}
+
+type sandboxTransferTestClockListJson struct {
+	ClientID         string `json:"client_id"`
+	Secret           string `json:"secret"`
+	StartVirtualTime string `json:"start_virtual_time,omitempty"`
+	EndVirtualTime   string `json:"end_virtual_time,omitempty"`
+	Count            int    `json:"count,omitempty"`
+	Offset           int    `json:"offset,omitempty"`
+}
@@ -0,0 +1,173 @@
+package plaid
+
+import (
+	"time"
+)
+
+// TransferNetwork is the network a transfer is sent over.
+//
+// See https://plaid.com/docs/api/products/transfer/#transferauthorizationcreate-request-network.
+type TransferNetwork string
+
+const (
+	NetworkACH        TransferNetwork = "ach"
+	NetworkSameDayACH TransferNetwork = "same-day-ach"
+	NetworkRTP        TransferNetwork = "rtp"
+)
+
+// SettlementEstimator estimates when transfers settle, from the ACH submission cutoffs, the
+// weekends and the holidays of the Federal Reserve, so that UIs can show when funds are
+// expected to be available. The estimates are what the schedule allows for; returns,
+// holds by the receiving bank and risk reviews can delay a transfer further.
+//
+// See https://plaid.com/docs/transfer/flow-of-funds/ for Plaid's current cutoffs.
+type SettlementEstimator struct {
+	// Location is the time zone of the cutoffs and dates. Defaults to America/New_York, the
+	// time zone of the Federal Reserve's schedule, or UTC if the time zone database is
+	// unavailable.
+	Location *time.Location
+	// SameDayCutoff and StandardCutoff are the times of day, as offsets from midnight, after
+	// which a same-day or standard ACH transfer is submitted on the next business day.
+	// Default to 3:00 PM and 8:30 PM.
+	SameDayCutoff  time.Duration
+	StandardCutoff time.Duration
+	// HoldDays is the number of business days funds are held after settlement, e.g. for
+	// debits from new users.
+	HoldDays int
+	// Holidays are additional dates ("2006-01-02") on which transfers don't settle.
+	Holidays []string
+}
+
+// SettlementEstimate is the schedule of a transfer, in Plaid dates.
+type SettlementEstimate struct {
+	// SubmissionDate is the business day the transfer is submitted to the network on.
+	SubmissionDate string
+	SettlementDate string
+	// AvailableDate is the SettlementDate plus HoldDays business days.
+	AvailableDate string
+}
+
+// Estimate returns the schedule of a transfer created at created and sent over network.
+// RTP transfers settle immediately, on any day.
+func (e *SettlementEstimator) Estimate(created time.Time, network TransferNetwork) SettlementEstimate {
+	loc := e.location()
+	created = created.In(loc)
+	day := civilDate(created, loc)
+	sinceMidnight := created.Sub(time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, loc))
+
+	var submission, settlement time.Time
+	switch network {
+	case NetworkRTP:
+		submission, settlement = day, day
+	case NetworkSameDayACH:
+		submission = e.submissionDay(day, sinceMidnight, e.cutoff(e.SameDayCutoff, 15*time.Hour))
+		settlement = submission
+	default:
+		submission = e.submissionDay(day, sinceMidnight, e.cutoff(e.StandardCutoff, 20*time.Hour+30*time.Minute))
+		settlement = e.AddBusinessDays(submission, 1)
+	}
+	return SettlementEstimate{
+		SubmissionDate: submission.Format("2006-01-02"),
+		SettlementDate: settlement.Format("2006-01-02"),
+		AvailableDate:  e.AddBusinessDays(settlement, e.HoldDays).Format("2006-01-02"),
+	}
+}
+
+// IsBusinessDay reports whether ACH transfers settle on the date of day: it is a weekday,
+// not a Federal Reserve holiday and not one of Holidays.
+func (e *SettlementEstimator) IsBusinessDay(day time.Time) bool {
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || IsBankHoliday(day) {
+		return false
+	}
+	date := day.Format("2006-01-02")
+	for _, holiday := range e.Holidays {
+		if holiday == date {
+			return false
+		}
+	}
+	return true
+}
+
+// AddBusinessDays returns the date n business days after day, or day itself if n is zero.
+func (e *SettlementEstimator) AddBusinessDays(day time.Time, n int) time.Time {
+	for n > 0 {
+		day = day.AddDate(0, 0, 1)
+		if e.IsBusinessDay(day) {
+			n--
+		}
+	}
+	return day
+}
+
+// submissionDay returns the business day a transfer created at sinceMidnight on day is
+// submitted on.
+func (e *SettlementEstimator) submissionDay(day time.Time, sinceMidnight, cutoff time.Duration) time.Time {
+	if e.IsBusinessDay(day) && sinceMidnight < cutoff {
+		return day
+	}
+	return e.AddBusinessDays(day, 1)
+}
+
+func (e *SettlementEstimator) cutoff(cutoff, fallback time.Duration) time.Duration {
+	if cutoff == 0 {
+		return fallback
+	}
+	return cutoff
+}
+
+func (e *SettlementEstimator) location() *time.Location {
+	if e.Location != nil {
+		return e.Location
+	}
+	if loc, err := time.LoadLocation("America/New_York"); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// IsBankHoliday reports whether the date of day is a holiday of the Federal Reserve, on which
+// ACH transfers don't settle. Holidays on a Sunday are observed on the following Monday;
+// holidays on a Saturday are not observed.
+//
+// See https://www.federalreserve.gov/aboutthefed/k8.htm.
+func IsBankHoliday(day time.Time) bool {
+	year, month, date := day.Date()
+	weekday := day.Weekday()
+
+	// Holidays on fixed dates, observed on Monday if they fall on a Sunday.
+	fixed := func(m time.Month, d int) bool {
+		return (month == m && date == d && weekday != time.Sunday) ||
+			(weekday == time.Monday && month == m && date == d+1)
+	}
+	// Holidays on the nth weekday of a month; a negative n counts from the end.
+	nth := func(m time.Month, wd time.Weekday, n int) bool {
+		if month != m || weekday != wd {
+			return false
+		}
+		if n > 0 {
+			return (date-1)/7 == n-1
+		}
+		return date+7 > daysIn(year, month)
+	}
+
+	switch {
+	case fixed(time.January, 1), // New Year's Day
+		nth(time.January, time.Monday, 3),    // Martin Luther King Jr. Day
+		nth(time.February, time.Monday, 3),   // Washington's Birthday
+		nth(time.May, time.Monday, -1),       // Memorial Day
+		year >= 2021 && fixed(time.June, 19), // Juneteenth
+		fixed(time.July, 4),                  // Independence Day
+		nth(time.September, time.Monday, 1),  // Labor Day
+		nth(time.October, time.Monday, 2),    // Columbus Day
+		fixed(time.November, 11),             // Veterans Day
+		nth(time.November, time.Thursday, 4), // Thanksgiving Day
+		fixed(time.December, 25):             // Christmas Day
+		return true
+	}
+	return false
+}
+
+// daysIn returns the number of days of a month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,31 @@
+package plaid
+
+import "github.com/wearevest/plaidgo/plaid/transactions"
+
+// SignConvention is how the sign of an amount tells money entering an account from money
+// leaving it, see transactions.SignConvention.
+type SignConvention = transactions.SignConvention
+
+const (
+	PlaidNative       = transactions.PlaidNative
+	AccountingNatural = transactions.AccountingNatural
+)
+
+// NormalizedTransaction is a transaction with its amount in a chosen SignConvention, see
+// transactions.NormalizedTransaction.
+type NormalizedTransaction = transactions.NormalizedTransaction
+
+// WithSignConvention sets the convention of the amounts returned by
+// Client.NormalizeTransactions. It defaults to PlaidNative. Transactions returned by the
+// API methods are never changed and always follow PlaidNative.
+func WithSignConvention(convention SignConvention) Option {
+	return func(c *Client) {
+		c.signConvention = convention
+	}
+}
+
+// NormalizeTransactions returns the transactions with their amounts signed according to
+// the client's SignConvention, see WithSignConvention.
+func (c *Client) NormalizeTransactions(transactions []Transaction) []NormalizedTransaction {
+	return c.signConvention.Normalize(transactions)
+}
@@ -0,0 +1,220 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+)
+
+// SignalEvaluate (POST /signal/evaluate) scores the risk that an ACH debit of amount from
+// an account will be returned. clientTransactionID is your own unique id for the debit and
+// is later used to report its outcome.
+//
+// See https://plaid.com/docs/api/products/signal/#signalevaluate.
+func (c *Client) SignalEvaluate(accessToken string, accountID AccountID, clientTransactionID string, amount float64,
+	options *SignalEvaluateOptions) (*SignalEvaluation, error) {
+	return c.SignalEvaluateContext(context.Background(), accessToken, accountID, clientTransactionID,
+		amount, options)
+}
+
+// SignalEvaluateContext is like SignalEvaluate but carries a context.
+func (c *Client) SignalEvaluateContext(ctx context.Context, accessToken string, accountID AccountID, clientTransactionID string,
+	amount float64, options *SignalEvaluateOptions) (*SignalEvaluation, error) {
+
+	if accountID == "" {
+		return nil, errors.New("/signal/evaluate - account id must be specified")
+	}
+	creds := c.creds.Load()
+	return c.signalEvaluate(ctx, "/signal/evaluate", signalEvaluateJson{
+		ClientID:            creds.clientID,
+		Secret:              creds.secret,
+		AccessToken:         accessToken,
+		AccountID:           accountID,
+		ClientTransactionID: clientTransactionID,
+		Amount:              amount,
+	}, options)
+}
+
+// ProcessorSignalEvaluate (POST /processor/signal/evaluate) is like SignalEvaluate for
+// processors, who identify the account with a processor token instead of an access token
+// and account id.
+//
+// See https://plaid.com/docs/api/processor-partners/#processorsignalevaluate.
+func (c *Client) ProcessorSignalEvaluate(processorToken, clientTransactionID string, amount float64,
+	options *SignalEvaluateOptions) (*SignalEvaluation, error) {
+	return c.ProcessorSignalEvaluateContext(context.Background(), processorToken, clientTransactionID,
+		amount, options)
+}
+
+// ProcessorSignalEvaluateContext is like ProcessorSignalEvaluate but carries a context.
+func (c *Client) ProcessorSignalEvaluateContext(ctx context.Context, processorToken, clientTransactionID string,
+	amount float64, options *SignalEvaluateOptions) (*SignalEvaluation, error) {
+
+	if processorToken == "" {
+		return nil, errors.New("/processor/signal/evaluate - processor token must be specified")
+	}
+	creds := c.creds.Load()
+	return c.signalEvaluate(ctx, "/processor/signal/evaluate", signalEvaluateJson{
+		ClientID:            creds.clientID,
+		Secret:              creds.secret,
+		ProcessorToken:      processorToken,
+		ClientTransactionID: clientTransactionID,
+		Amount:              amount,
+	}, options)
+}
+
+func (c *Client) signalEvaluate(ctx context.Context, endpoint string, request signalEvaluateJson,
+	options *SignalEvaluateOptions) (*SignalEvaluation, error) {
+
+	if request.ClientTransactionID == "" {
+		return nil, errors.New(endpoint + " - client transaction id must be specified")
+	}
+	if request.Amount <= 0 {
+		return nil, errors.New(endpoint + " - amount must be positive")
+	}
+	if options != nil {
+		request.UserPresent = options.UserPresent
+		request.ClientUserID = options.ClientUserID
+		request.IsRecurring = options.IsRecurring
+		request.DefaultPaymentMethod = options.DefaultPaymentMethod
+		request.User = options.User
+		request.Device = options.Device
+		request.RulesetKey = options.RulesetKey
+	}
+	var res SignalEvaluation
+	if err := c.postAndDecode(ctx, endpoint, request, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SignalPrepare (POST /signal/prepare) adds Signal to an item that was not initialized with
+// it, so Plaid starts collecting the data it needs and the first SignalEvaluate for the
+// item is more accurate.
+//
+// See https://plaid.com/docs/api/products/signal/#signalprepare.
+func (c *Client) SignalPrepare(accessToken string) error {
+	return c.SignalPrepareContext(context.Background(), accessToken)
+}
+
+// SignalPrepareContext is like SignalPrepare but carries a context.
+func (c *Client) SignalPrepareContext(ctx context.Context, accessToken string) error {
+	var res struct {
+		RequestID string `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	return c.postAndDecode(ctx, "/signal/prepare", itemJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+	}, &res)
+}
+
+// SignalEvaluateOptions represents the optional fields of a Signal evaluation. More
+// details improve the scores.
+type SignalEvaluateOptions struct {
+	UserPresent          *bool
+	ClientUserID         string
+	IsRecurring          *bool
+	DefaultPaymentMethod string // e.g. "SAME_DAY_ACH", "STANDARD_ACH" or "MULTIPLE_PAYMENT_METHODS"
+	User                 *SignalUser
+	Device               *SignalDevice
+	// RulesetKey selects the Signal ruleset to apply, as configured in the Plaid dashboard.
+	// If empty, the default ruleset is used.
+	RulesetKey string
+}
+
+// SignalUser describes the user initiating the debit.
+type SignalUser struct {
+	Name         *SignalUserName `json:"name,omitempty"`
+	PhoneNumber  string          `json:"phone_number,omitempty"`
+	EmailAddress string          `json:"email_address,omitempty"`
+	Address      *SignalAddress  `json:"address,omitempty"`
+}
+
+// SignalUserName is the name of a SignalUser.
+type SignalUserName struct {
+	Prefix     string `json:"prefix,omitempty"`
+	GivenName  string `json:"given_name,omitempty"`
+	MiddleName string `json:"middle_name,omitempty"`
+	FamilyName string `json:"family_name,omitempty"`
+	Suffix     string `json:"suffix,omitempty"`
+}
+
+// SignalAddress is the address of a SignalUser.
+type SignalAddress struct {
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	Street     string `json:"street,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// SignalDevice describes the device the debit was initiated from.
+type SignalDevice struct {
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// SignalEvaluation is the result of a Signal evaluation.
+//
+// See https://plaid.com/docs/api/products/signal/#signal-evaluate-response.
+type SignalEvaluation struct {
+	Scores struct {
+		CustomerInitiatedReturnRisk *SignalScore `json:"customer_initiated_return_risk"`
+		BankInitiatedReturnRisk     *SignalScore `json:"bank_initiated_return_risk"`
+	} `json:"scores"`
+	// CoreAttributes holds the raw attributes behind the scores. The set of attributes
+	// depends on the Plaid account, so they are not typed.
+	CoreAttributes map[string]interface{} `json:"core_attributes"`
+	// Ruleset is the outcome of the ruleset, if one applies to the evaluation.
+	Ruleset   *SignalRuleset  `json:"ruleset"`
+	Warnings  []SignalWarning `json:"warnings"`
+	RequestID string          `json:"request_id"`
+}
+
+// SignalScore is a return risk score from 1 to 99, higher meaning riskier, along with its
+// risk tier.
+type SignalScore struct {
+	Score    int `json:"score"`
+	RiskTier int `json:"risk_tier"`
+}
+
+// SignalRuleset is the outcome of a Signal ruleset.
+type SignalRuleset struct {
+	RulesetKey string `json:"ruleset_key"`
+	// Result is "ACCEPT", "REROUTE" or "REVIEW".
+	Result               string `json:"result"`
+	TriggeredRuleDetails *struct {
+		InternalNote    string `json:"internal_note"`
+		CustomActionKey string `json:"custom_action_key"`
+	} `json:"triggered_rule_details"`
+}
+
+// Accepted reports whether the ruleset accepted the debit.
+func (r *SignalRuleset) Accepted() bool {
+	return r != nil && r.Result == "ACCEPT"
+}
+
+// SignalWarning is a warning about missing or degraded data in a Signal evaluation.
+type SignalWarning struct {
+	WarningType    string `json:"warning_type"`
+	WarningCode    string `json:"warning_code"`
+	WarningMessage string `json:"warning_message"`
+}
+
+type signalEvaluateJson struct {
+	ClientID             string        `json:"client_id"`
+	Secret               string        `json:"secret"`
+	AccessToken          string        `json:"access_token,omitempty"`
+	AccountID            AccountID     `json:"account_id,omitempty"`
+	ProcessorToken       string        `json:"processor_token,omitempty"`
+	ClientTransactionID  string        `json:"client_transaction_id"`
+	Amount               float64       `json:"amount"`
+	UserPresent          *bool         `json:"user_present,omitempty"`
+	ClientUserID         string        `json:"client_user_id,omitempty"`
+	IsRecurring          *bool         `json:"is_recurring,omitempty"`
+	DefaultPaymentMethod string        `json:"default_payment_method,omitempty"`
+	User                 *SignalUser   `json:"user,omitempty"`
+	Device               *SignalDevice `json:"device,omitempty"`
+	RulesetKey           string        `json:"ruleset_key,omitempty"`
+}
@@ -0,0 +1,127 @@
+package plaid
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+)
+
+// DefaultSingleflightEndpoints are the read endpoints WithSingleflight deduplicates unless
+// given others.
+var DefaultSingleflightEndpoints = []string{
+	"/accounts/get",
+	"/accounts/balance/get",
+	"/auth/get",
+	"/identity/get",
+	"/item/get",
+	"/institutions/get",
+	"/institutions/get_by_id",
+	"/institutions/search",
+	"/transactions/get",
+	"/transactions/sync",
+	"/investments/holdings/get",
+	"/liabilities/get",
+	"/categories/get",
+}
+
+// WithSingleflight collapses concurrent identical requests to the given endpoints, or to
+// DefaultSingleflightEndpoints if none are given, into one request to Plaid. Requests are
+// identical if they have the same endpoint and body; the ones joining a request in flight
+// get its response and emit EventDeduplicated. Only use it for endpoints without side
+// effects.
+//
+// A shared request is canceled only once every caller waiting for it gave up, so that one
+// caller canceling its context doesn't fail the others.
+func WithSingleflight(endpoints ...string) Option {
+	return func(c *Client) {
+		if len(endpoints) == 0 {
+			endpoints = DefaultSingleflightEndpoints
+		}
+		g := &flightGroup{endpoints: map[string]bool{}, flights: map[string]*flight{}}
+		for _, endpoint := range endpoints {
+			g.endpoints[endpoint] = true
+		}
+		c.flights = g
+	}
+}
+
+type flightGroup struct {
+	endpoints map[string]bool
+
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a request in flight and the callers waiting for it.
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	res *http.Response
+	raw []byte
+	err error
+}
+
+func (g *flightGroup) dedupes(endpoint string) bool {
+	return g != nil && g.endpoints[endpoint]
+}
+
+// flightKey identifies identical requests. Like responseCacheKey, it includes the
+// environment and API version the request is sent to.
+func (c *Client) flightKey(method, endpoint string, jsonText []byte) string {
+	sum := sha256.Sum256(jsonText)
+	return string(c.environment) + " " + c.apiVersion + " " + method + " " + endpoint + " " +
+		hex.EncodeToString(sum[:])
+}
+
+// do sends the request with send unless an identical one is in flight, and waits for its
+// response or for ctx to be done. The shared request doesn't inherit the deadline of the
+// caller that started it, which would cut it short for callers willing to wait longer; it is
+// canceled instead once the last caller waiting for it is done.
+func (g *flightGroup) do(ctx context.Context, key, endpoint string, emit func(Event),
+	send func(ctx context.Context) (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+
+	g.mu.Lock()
+	f, ok := g.flights[key]
+	if ok {
+		f.waiters++
+		g.mu.Unlock()
+		emit(Event{Type: EventDeduplicated, Endpoint: endpoint})
+	} else {
+		// The request outlives the caller that started it as long as others wait for it.
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		f = &flight{done: make(chan struct{}), cancel: cancel, waiters: 1}
+		g.flights[key] = f
+		g.mu.Unlock()
+		go func() {
+			f.res, f.raw, f.err = send(flightCtx)
+			g.mu.Lock()
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+			cancel()
+			close(f.done)
+		}()
+	}
+
+	select {
+	case <-f.done:
+		return f.res, f.raw, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			// Nobody waits anymore: cancel the request and don't let new callers join it.
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			f.cancel()
+		}
+		g.mu.Unlock()
+		return nil, nil, ctx.Err()
+	}
+}
@@ -0,0 +1,129 @@
+package plaid
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFlightKey(t *testing.T) {
+	body := []byte(`{"access_token":"access-sandbox-1"}`)
+	key := NewClient("id", "secret", Sandbox).flightKey(http.MethodPost, "/accounts/get", body)
+	for name, other := range map[string]string{
+		"environment": NewClient("id", "secret", Development).flightKey(http.MethodPost, "/accounts/get", body),
+		"endpoint":    NewClient("id", "secret", Sandbox).flightKey(http.MethodPost, "/auth/get", body),
+		"body":        NewClient("id", "secret", Sandbox).flightKey(http.MethodPost, "/accounts/get", []byte(`{}`)),
+	} {
+		if other == key {
+			t.Errorf("requests of different %ss share the key %q", name, key)
+		}
+	}
+	if other := NewClient("other", "secret", Sandbox).flightKey(http.MethodPost, "/accounts/get", body); other != key {
+		t.Errorf("identical requests have the keys %q and %q", key, other)
+	}
+}
+
+// startFlight starts a request of g whose send blocks until release is closed, and returns
+// the error the caller got. send passes the context of the request to sent.
+func startFlight(g *flightGroup, ctx context.Context, emit func(Event), sent chan<- context.Context,
+	release <-chan struct{}) <-chan error {
+
+	result := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(ctx, "key", "/accounts/get", emit, func(ctx context.Context) (*http.Response, []byte, error) {
+			sent <- ctx
+			select {
+			case <-release:
+				return &http.Response{StatusCode: http.StatusOK}, []byte("{}"), nil
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		})
+		result <- err
+	}()
+	return result
+}
+
+func TestSingleflightJoin(t *testing.T) {
+	g := &flightGroup{endpoints: map[string]bool{"/accounts/get": true}, flights: map[string]*flight{}}
+	joined := make(chan Event, 1)
+	emit := func(event Event) { joined <- event }
+	sent, release := make(chan context.Context, 2), make(chan struct{})
+
+	first := startFlight(g, context.Background(), emit, sent, release)
+	<-sent
+	second := startFlight(g, context.Background(), emit, sent, release)
+	if event := <-joined; event.Type != EventDeduplicated {
+		t.Fatalf("joining emitted %v, want EventDeduplicated", event.Type)
+	}
+	close(release)
+	for _, result := range []<-chan error{first, second} {
+		if err := <-result; err != nil {
+			t.Fatalf("do = %v, want the shared response", err)
+		}
+	}
+	select {
+	case <-sent:
+		t.Fatal("the joining caller sent its own request")
+	default:
+	}
+}
+
+func TestSingleflightLastWaiterCancels(t *testing.T) {
+	g := &flightGroup{endpoints: map[string]bool{"/accounts/get": true}, flights: map[string]*flight{}}
+	joined := make(chan Event, 1)
+	emit := func(event Event) { joined <- event }
+	sent, release := make(chan context.Context, 1), make(chan struct{})
+	defer close(release)
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	first := startFlight(g, firstCtx, emit, sent, release)
+	flightCtx := <-sent
+	secondCtx, cancelSecond := context.WithCancel(context.Background())
+	second := startFlight(g, secondCtx, emit, sent, release)
+	<-joined
+
+	cancelFirst()
+	if err := <-first; err != context.Canceled {
+		t.Fatalf("first do = %v, want context.Canceled", err)
+	}
+	select {
+	case <-flightCtx.Done():
+		t.Fatal("the request was canceled while a caller still waited for it")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancelSecond()
+	if err := <-second; err != context.Canceled {
+		t.Fatalf("second do = %v, want context.Canceled", err)
+	}
+	select {
+	case <-flightCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the request outlived the last caller waiting for it")
+	}
+}
+
+func TestSingleflightDeadline(t *testing.T) {
+	g := &flightGroup{endpoints: map[string]bool{"/accounts/get": true}, flights: map[string]*flight{}}
+	sent, release := make(chan context.Context, 1), make(chan struct{})
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	result := startFlight(g, ctx, func(Event) {}, sent, release)
+	flightCtx := <-sent
+	if _, ok := flightCtx.Deadline(); ok {
+		t.Error("the shared request inherited the deadline of the caller that started it")
+	}
+	if err := <-result; err != context.DeadlineExceeded {
+		t.Fatalf("do = %v, want context.DeadlineExceeded", err)
+	}
+	// The request has no deadline, but its only caller's expired.
+	select {
+	case <-flightCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the request outlived the deadline of its only caller")
+	}
+}
@@ -0,0 +1,45 @@
+package plaid
+
+import (
+	"time"
+)
+
+// SlowRequest describes a request that exceeded the threshold set with
+// WithSlowRequestThreshold.
+type SlowRequest struct {
+	Method   string
+	Endpoint string
+	Start    time.Time
+	// Duration is the time from sending the request to reading the full response. Time
+	// spent waiting on throttling is reported separately as ThrottleWait.
+	Duration     time.Duration
+	ThrottleWait time.Duration
+
+	StatusCode    int    // 0 if no response was received
+	RequestID     string // Plaid's request id, if the response carried one
+	RequestBytes  int
+	ResponseBytes int
+	Err           error // transport error, if any
+}
+
+// WithSlowRequestThreshold makes the client call fn with the details of every request that
+// takes longer than threshold. fn is called synchronously before the request returns, so
+// it should hand off anything slow.
+func WithSlowRequestThreshold(threshold time.Duration, fn func(SlowRequest)) Option {
+	return func(c *Client) {
+		c.slow = &slowRequestHook{threshold: threshold, fn: fn}
+	}
+}
+
+type slowRequestHook struct {
+	threshold time.Duration
+	fn        func(SlowRequest)
+}
+
+// observe reports a request to the hook if it was slow.
+func (h *slowRequestHook) observe(request SlowRequest) {
+	if h == nil || request.Duration <= h.threshold {
+		return
+	}
+	h.fn(request)
+}
@@ -0,0 +1,206 @@
+package plaid
+
+// SpendClass classifies an outflow for budgeting.
+type SpendClass string
+
+const (
+	// NotSpend is the class of inflows and of transactions excluded from spending, such as
+	// transfers between the user's own accounts.
+	NotSpend SpendClass = ""
+	// FixedSpend is spending that recurs and can't easily be cut, e.g. rent or insurance.
+	FixedSpend SpendClass = "fixed"
+	// DiscretionarySpend is spending the user chooses, e.g. restaurants or entertainment.
+	DiscretionarySpend SpendClass = "discretionary"
+)
+
+// Personal finance categories classified by DefaultSpendRules. Entries are primary or
+// detailed categories; a detailed entry takes precedence over the entry of its primary.
+var (
+	DefaultFixedCategories = []string{
+		"RENT_AND_UTILITIES",
+		"LOAN_PAYMENTS",
+		"BANK_FEES",
+		"GENERAL_SERVICES_INSURANCE",
+		"GENERAL_SERVICES_CHILDCARE",
+		"GOVERNMENT_AND_NON_PROFIT_TAX_PAYMENT",
+		"FOOD_AND_DRINK_GROCERIES",
+		"MEDICAL",
+	}
+	DefaultDiscretionaryCategories = []string{
+		"ENTERTAINMENT",
+		"FOOD_AND_DRINK",
+		"GENERAL_MERCHANDISE",
+		"PERSONAL_CARE",
+		"TRAVEL",
+		"HOME_IMPROVEMENT",
+	}
+	DefaultExcludedCategories = []string{
+		"INCOME",
+		"TRANSFER_IN",
+		"TRANSFER_OUT",
+	}
+)
+
+// SpendRules tune ClassifySpend.
+type SpendRules struct {
+	// FixedCategories, DiscretionaryCategories and ExcludedCategories map personal finance
+	// categories, primary or detailed, to a class. A detailed entry takes precedence over
+	// the entry of its primary category.
+	FixedCategories         []string
+	DiscretionaryCategories []string
+	ExcludedCategories      []string
+	// Default is the class of outflows whose category matches no entry, including those
+	// without a personal finance category.
+	Default SpendClass
+
+	// Streams are recurring streams, e.g. the OutflowStreams of a
+	// TransactionsRecurringResponse. Their transactions are fixed spend unless their category
+	// is excluded.
+	Streams []TransactionStream
+	// DetectRecurring additionally treats outflows as recurring, and so as fixed, if the
+	// same merchant charged a consistent amount in at least RecurringMonths calendar months.
+	DetectRecurring bool
+	// RecurringMonths defaults to 3.
+	RecurringMonths int
+}
+
+// DefaultSpendRules returns the rules ClassifySpend is typically used with: the default
+// category lists, discretionary as the default and recurring detection enabled.
+func DefaultSpendRules() SpendRules {
+	return SpendRules{
+		FixedCategories:         DefaultFixedCategories,
+		DiscretionaryCategories: DefaultDiscretionaryCategories,
+		ExcludedCategories:      DefaultExcludedCategories,
+		Default:                 DiscretionarySpend,
+		DetectRecurring:         true,
+	}
+}
+
+// ClassifiedSpend is a transaction with its SpendClass.
+type ClassifiedSpend struct {
+	Transaction Transaction
+	Class       SpendClass
+	// Recurring is set if the transaction belongs to a recurring stream.
+	Recurring bool
+}
+
+// SpendSummary totals classified outflows.
+type SpendSummary struct {
+	Fixed         float64
+	Discretionary float64
+}
+
+// ClassifySpend classifies transactions as fixed or discretionary spend using their
+// personal finance categories and whether they recur. Inflows and pending transactions are
+// NotSpend. The result has one entry per transaction, in order.
+func ClassifySpend(transactions []Transaction, rules SpendRules) []ClassifiedSpend {
+	classes := map[string]SpendClass{}
+	for _, category := range rules.DiscretionaryCategories {
+		classes[category] = DiscretionarySpend
+	}
+	for _, category := range rules.FixedCategories {
+		classes[category] = FixedSpend
+	}
+	for _, category := range rules.ExcludedCategories {
+		classes[category] = NotSpend
+	}
+	recurring := map[TransactionID]bool{}
+	for _, stream := range rules.Streams {
+		for _, id := range stream.TransactionIDs {
+			recurring[id] = true
+		}
+	}
+	if rules.DetectRecurring {
+		for id := range detectRecurring(transactions, rules.RecurringMonths) {
+			recurring[id] = true
+		}
+	}
+
+	classified := make([]ClassifiedSpend, len(transactions))
+	for i, t := range transactions {
+		classified[i] = ClassifiedSpend{Transaction: t, Recurring: recurring[t.TransactionID]}
+		if t.Amount <= 0 || t.Pending {
+			continue
+		}
+		var class SpendClass
+		matched := false
+		if category := t.PersonalFinanceCategory; category != nil {
+			// The detailed category takes precedence over its primary.
+			if class, matched = classes[category.Detailed]; !matched {
+				class, matched = classes[category.Primary]
+			}
+		}
+		switch {
+		case class == NotSpend && matched:
+		case classified[i].Recurring:
+			classified[i].Class = FixedSpend
+		case matched:
+			classified[i].Class = class
+		default:
+			classified[i].Class = rules.Default
+		}
+	}
+	return classified
+}
+
+// SummarizeSpend totals classified transactions by class.
+func SummarizeSpend(classified []ClassifiedSpend) SpendSummary {
+	var summary SpendSummary
+	for _, c := range classified {
+		switch c.Class {
+		case FixedSpend:
+			summary.Fixed += c.Transaction.Amount64()
+		case DiscretionarySpend:
+			summary.Discretionary += c.Transaction.Amount64()
+		}
+	}
+	return summary
+}
+
+// recurringAmountVariation is the largest coefficient of variation of the amounts of a
+// merchant's charges for them to count as recurring.
+const recurringAmountVariation = 0.1
+
+// detectRecurring returns the ids of outflows whose merchant charged a consistent amount in
+// at least months calendar months.
+func detectRecurring(transactions []Transaction, months int) map[TransactionID]bool {
+	if months <= 0 {
+		months = 3
+	}
+	type merchant struct {
+		ids     []TransactionID
+		amounts []float64
+		months  map[string]bool
+	}
+	merchants := map[string]*merchant{}
+	for _, t := range transactions {
+		if t.Amount <= 0 || len(t.Date) < 7 {
+			continue
+		}
+		key, _, _ := merchantKey(t)
+		if key == "" {
+			continue
+		}
+		m, ok := merchants[key]
+		if !ok {
+			m = &merchant{months: map[string]bool{}}
+			merchants[key] = m
+		}
+		m.ids = append(m.ids, t.TransactionID)
+		m.amounts = append(m.amounts, t.Amount64())
+		m.months[t.Date[:7]] = true
+	}
+	recurring := map[TransactionID]bool{}
+	for _, m := range merchants {
+		if len(m.months) < months {
+			continue
+		}
+		if _, variation := meanAndVariation(m.amounts); variation > recurringAmountVariation {
+			continue
+		}
+		for _, id := range m.ids {
+			recurring[id] = true
+		}
+	}
+	return recurring
+}
@@ -0,0 +1,125 @@
+package plaid
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// StreamStability measures how dependable a recurring stream is, e.g. to judge an income
+// stream for lightweight underwriting without a full consumer report.
+type StreamStability struct {
+	StreamID    string
+	Description string
+	Frequency   string
+	// Occurrences is the number of the stream's transactions that were found, and
+	// MonthsObserved the number of distinct calendar months they fall in.
+	Occurrences    int
+	MonthsObserved int
+	// MeanInterval is the average number of days between occurrences and IntervalVariation
+	// the coefficient of variation of the intervals: 0 for perfectly regular payments.
+	MeanInterval      float64
+	IntervalVariation float64
+	// MeanAmount is the average absolute amount and AmountVariation its coefficient of
+	// variation: 0 for identical amounts.
+	MeanAmount      float64
+	AmountVariation float64
+	// Score combines regularity, amount consistency and months observed into a value
+	// between 0 and 1, see AnalyzeStreamStability.
+	Score float64
+}
+
+// stabilityFullMonths is the number of months observed at which a stream gets the full
+// coverage component of its score.
+const stabilityFullMonths = 12
+
+// AnalyzeStreamStability computes the stability of a stream from its transactions, which
+// are looked up by the stream's TransactionIDs in transactions; other transactions are
+// ignored. Amounts are compared as absolute values, so inflow and outflow streams are
+// analyzed alike.
+//
+// Score is the average of three components between 0 and 1: regularity, 1 minus
+// IntervalVariation; consistency, 1 minus AmountVariation; and coverage, MonthsObserved
+// out of 12. Variations above 1 count as 1. A stream with less than two transactions has
+// no intervals and scores 0.
+func AnalyzeStreamStability(stream TransactionStream, transactions []Transaction) StreamStability {
+	ids := make(map[TransactionID]bool, len(stream.TransactionIDs))
+	for _, id := range stream.TransactionIDs {
+		ids[id] = true
+	}
+	var dates []time.Time
+	var amounts []float64
+	months := map[string]bool{}
+	for _, t := range transactions {
+		if !ids[t.TransactionID] {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", t.Date)
+		if err != nil {
+			continue
+		}
+		dates = append(dates, date)
+		amounts = append(amounts, math.Abs(t.Amount64()))
+		months[t.Date[:7]] = true
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	stability := StreamStability{
+		StreamID:       stream.StreamID,
+		Description:    stream.Description,
+		Frequency:      stream.Frequency,
+		Occurrences:    len(dates),
+		MonthsObserved: len(months),
+	}
+	stability.MeanAmount, stability.AmountVariation = meanAndVariation(amounts)
+	if len(dates) < 2 {
+		return stability
+	}
+	intervals := make([]float64, len(dates)-1)
+	for i := 1; i < len(dates); i++ {
+		intervals[i-1] = dates[i].Sub(dates[i-1]).Hours() / 24
+	}
+	stability.MeanInterval, stability.IntervalVariation = meanAndVariation(intervals)
+
+	regularity := 1 - math.Min(stability.IntervalVariation, 1)
+	consistency := 1 - math.Min(stability.AmountVariation, 1)
+	coverage := math.Min(float64(stability.MonthsObserved)/stabilityFullMonths, 1)
+	stability.Score = (regularity + consistency + coverage) / 3
+	return stability
+}
+
+// AnalyzeIncomeStability computes the stability of inflow streams, such as the
+// InflowStreams of a TransactionsRecurringResponse, ordered by descending score. Streams
+// that are no longer active are skipped.
+func AnalyzeIncomeStability(streams []TransactionStream, transactions []Transaction) []StreamStability {
+	var results []StreamStability
+	for _, stream := range streams {
+		if stream.IsActive {
+			results = append(results, AnalyzeStreamStability(stream, transactions))
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// meanAndVariation returns the mean of values and their coefficient of variation, the
+// standard deviation relative to the mean.
+func meanAndVariation(values []float64) (mean, variation float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, value := range values {
+		mean += value
+	}
+	mean /= float64(len(values))
+	if mean == 0 {
+		return 0, 0
+	}
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(squares/float64(len(values))) / mean
+}
@@ -0,0 +1,117 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+)
+
+// SyncStream delivers the pages of transaction changes fetched by StreamTransactions on C.
+// C is closed when there are no more changes, the stream fails or it is closed.
+type SyncStream struct {
+	C <-chan *TransactionsSyncResponse
+
+	pages  *bounded[*TransactionsSyncResponse]
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// StreamTransactions follows TransactionsSync from cursor like TransactionsSyncAll, but
+// delivers every page on the returned stream as soon as it is fetched, rather than holding
+// all of them in memory, so that a long backfill can be applied while it is fetched.
+//
+// Only persist the NextCursor of a page whose HasMore is false. Plaid requires pagination
+// that fails with TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION to start over from the
+// cursor it started from, which the stream does, but a stream started again from an
+// intermediate cursor can't: it would skip the changes of the pages before it.
+//
+// Pages are buffered as configured by backpressure:
+//
+//   - OverflowBlock stops fetching pages while the buffer is full, so the stream goes at the
+//     consumer's pace.
+//   - OverflowDropOldest discards the oldest buffered page, counted by Dropped. The changes
+//     of a dropped page are lost; only use it with consumers that start over from their own
+//     cursor when Dropped isn't 0.
+//   - OverflowError fails the stream with ErrBufferFull.
+//
+// If the item's transactions change while the pages are fetched, the stream restarts from
+// cursor as Plaid requires, so pages may be delivered again: apply them by transaction id.
+func (c *Client) StreamTransactions(accessToken, cursor string, backpressure Backpressure) *SyncStream {
+	return c.StreamTransactionsContext(context.Background(), accessToken, cursor, backpressure)
+}
+
+// StreamTransactionsContext is like StreamTransactions but carries a context.
+func (c *Client) StreamTransactionsContext(ctx context.Context, accessToken, cursor string,
+	backpressure Backpressure) *SyncStream {
+
+	ctx, cancel := c.operation(ctx)
+	pages := newBounded[*TransactionsSyncResponse](backpressure, nil)
+	s := &SyncStream{C: pages.items, pages: pages, cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(s.done)
+		defer pages.close()
+		defer cancel()
+		err := c.streamTransactions(ctx, accessToken, cursor, pages)
+		select {
+		case <-pages.closed:
+			// Closed by Close, which canceled the request in flight.
+		default:
+			s.err = err
+		}
+	}()
+	return s
+}
+
+func (c *Client) streamTransactions(ctx context.Context, accessToken, cursor string,
+	pages *bounded[*TransactionsSyncResponse]) error {
+
+	next := cursor
+	for restarts := 0; ; {
+		var res *TransactionsSyncResponse
+		err := c.call(ctx, "/transactions/sync", func(ctx context.Context) (err error) {
+			res, err = c.TransactionsSyncContext(ctx, accessToken, next, 500)
+			return err
+		})
+		var plaidErr plaidError
+		if errors.As(err, &plaidErr) && restarts < 3 &&
+			plaidErr.ErrorCode == "TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION" {
+			c.emit(Event{Type: EventRetry, Endpoint: "/transactions/sync", Reason: plaidErr.ErrorCode})
+			next = cursor
+			restarts++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		switch err = pages.send(res, ctx.Done()); err {
+		case nil:
+		case errAborted:
+			return ctx.Err()
+		default:
+			return err
+		}
+		if !res.HasMore {
+			return nil
+		}
+		next = res.NextCursor
+	}
+}
+
+// Err waits for the stream to end and returns the error that ended it, or nil if it
+// delivered all changes or was closed.
+func (s *SyncStream) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Dropped returns the number of pages discarded by OverflowDropOldest.
+func (s *SyncStream) Dropped() uint64 {
+	return s.pages.Dropped()
+}
+
+// Close stops fetching pages and closes C. Pages already buffered can still be received.
+func (s *SyncStream) Close() {
+	s.pages.close()
+	s.cancel()
+	<-s.done
+}
@@ -0,0 +1,128 @@
+package plaid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncServer serves /transactions/sync with pages pages, the cursor of page i being "c<i>".
+// fail, if set, may answer a request from cursor instead.
+func syncServer(t *testing.T, pages int, fail func(cursor string, w http.ResponseWriter) bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Cursor string `json:"cursor"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding the request: %v", err)
+		}
+		if fail != nil && fail(req.Cursor, w) {
+			return
+		}
+		page := 0
+		if req.Cursor != "" {
+			fmt.Sscanf(req.Cursor, "c%d", &page)
+		}
+		json.NewEncoder(w).Encode(TransactionsSyncResponse{
+			Added:      []Transaction{{TransactionID: TransactionID(fmt.Sprint("t", page))}},
+			NextCursor: fmt.Sprint("c", page+1),
+			HasMore:    page+1 < pages,
+		})
+	}))
+}
+
+func TestStreamTransactions(t *testing.T) {
+	var mu sync.Mutex
+	mutated := false
+	server := syncServer(t, 3, func(cursor string, w http.ResponseWriter) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if cursor != "c2" || mutated {
+			return false
+		}
+		// The item changes once while its pages are fetched.
+		mutated = true
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error_type": "TRANSACTIONS_ERROR", "error_code": "TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION"}`))
+		return true
+	})
+	defer server.Close()
+	c := NewClient("id", "secret", Sandbox, WithBaseURL(server.URL))
+
+	stream := c.StreamTransactions("access-sandbox-1", "", Backpressure{Buffer: 1})
+	var got []string
+	for page := range stream.C {
+		got = append(got, string(page.Added[0].TransactionID))
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("Err = %v", err)
+	}
+	// The pagination starts over from the stream's cursor after the mutation.
+	if want := fmt.Sprint([]string{"t0", "t1", "t0", "t1", "t2"}); fmt.Sprint(got) != want {
+		t.Errorf("received the pages %v, want %v", got, want)
+	}
+}
+
+func TestStreamTransactionsErr(t *testing.T) {
+	server := syncServer(t, 3, func(cursor string, w http.ResponseWriter) bool {
+		if cursor != "c1" {
+			return false
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error_type": "ITEM_ERROR", "error_code": "ITEM_LOGIN_REQUIRED"}`))
+		return true
+	})
+	defer server.Close()
+	c := NewClient("id", "secret", Sandbox, WithBaseURL(server.URL))
+
+	stream := c.StreamTransactions("access-sandbox-1", "", Backpressure{})
+	pages := 0
+	for range stream.C {
+		pages++
+	}
+	var plaidErr plaidError
+	if err := stream.Err(); !errors.As(err, &plaidErr) || plaidErr.ErrorCode != "ITEM_LOGIN_REQUIRED" {
+		t.Fatalf("Err = %v, want ITEM_LOGIN_REQUIRED", err)
+	}
+	if pages != 1 {
+		t.Errorf("received %d pages before the error, want 1", pages)
+	}
+}
+
+func TestStreamTransactionsClose(t *testing.T) {
+	server := syncServer(t, 100, nil)
+	defer server.Close()
+	c := NewClient("id", "secret", Sandbox, WithBaseURL(server.URL))
+
+	stream := c.StreamTransactions("access-sandbox-1", "", Backpressure{Buffer: 1})
+	// Wait for the stream to block on its full buffer.
+	first := <-stream.C
+	time.Sleep(20 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		stream.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close didn't stop a stream blocked on its buffer")
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("Err = %v after Close, want nil", err)
+	}
+	pages := 1
+	for range stream.C {
+		pages++
+	}
+	if first.Added[0].TransactionID != "t0" || pages > 2 {
+		t.Errorf("received %d pages starting with %s, want at most the buffered one after t0", pages,
+			first.Added[0].TransactionID)
+	}
+}
@@ -0,0 +1,125 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// StripeBankAccountTokenCreate (POST /processor/stripe/bank_account_token/create) creates
+// a Stripe bank account token for an account of an item, to be attached to a Stripe
+// customer.
+//
+// See https://plaid.com/docs/api/processors/#processorstripebank_account_tokencreate.
+func (c *Client) StripeBankAccountTokenCreate(accessToken string, accountID AccountID) (string, error) {
+	return c.StripeBankAccountTokenCreateContext(context.Background(), accessToken, accountID)
+}
+
+// StripeBankAccountTokenCreateContext is like StripeBankAccountTokenCreate but carries a
+// context.
+func (c *Client) StripeBankAccountTokenCreateContext(ctx context.Context, accessToken string,
+	accountID AccountID) (string, error) {
+
+	if accessToken == "" || accountID == "" {
+		return "", errors.New("/processor/stripe/bank_account_token/create - access token and account id must be specified")
+	}
+	var res struct {
+		StripeBankAccountToken string `json:"stripe_bank_account_token"`
+		RequestID              string `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/processor/stripe/bank_account_token/create", stripeBankAccountTokenJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+		AccountID:   accountID,
+	}, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.StripeBankAccountToken, nil
+}
+
+type stripeBankAccountTokenJson struct {
+	ClientID    string    `json:"client_id"`
+	Secret      string    `json:"secret"`
+	AccessToken string    `json:"access_token"`
+	AccountID   AccountID `json:"account_id"`
+}
+
+// StripeOnboardResult holds everything needed to attach a bank account linked through
+// Plaid to a Stripe customer.
+type StripeOnboardResult struct {
+	AccessToken string
+	ItemID      ItemID
+	Account     Account
+	// StripeBankAccountToken is passed to Stripe as the source of a customer's bank
+	// account, e.g. "btok_...".
+	StripeBankAccountToken string
+}
+
+// OnboardStripe exchanges a public token, selects the account to pay from and creates a
+// Stripe bank account token for it. accountID is the account the user selected in Link;
+// if it is empty the item must have exactly one checking or savings account, which is
+// used.
+//
+// Like Onboard, OnboardStripe removes the item again if a step after the exchange fails,
+// and returns a *RollbackError if that fails as well.
+func (c *Client) OnboardStripe(publicToken string, accountID AccountID) (*StripeOnboardResult, error) {
+	return c.OnboardStripeContext(context.Background(), publicToken, accountID)
+}
+
+// OnboardStripeContext is like OnboardStripe but carries a context.
+func (c *Client) OnboardStripeContext(ctx context.Context, publicToken string, accountID AccountID) (*StripeOnboardResult, error) {
+	exchangeRes, err := c.ItemPublicTokenExchangeContext(ctx, publicToken)
+	if err != nil {
+		return nil, err
+	}
+	result := &StripeOnboardResult{AccessToken: exchangeRes.AccessToken, ItemID: exchangeRes.ItemID}
+
+	if err = c.onboardStripe(ctx, result, accountID); err != nil {
+		return nil, c.rollback(ctx, result.AccessToken, err)
+	}
+	return result, nil
+}
+
+// onboardStripe runs the steps of OnboardStripe that follow the token exchange.
+func (c *Client) onboardStripe(ctx context.Context, result *StripeOnboardResult, accountID AccountID) error {
+	accountsRes, err := c.AccountsContext(ctx, result.AccessToken)
+	if err != nil {
+		return err
+	}
+	account, err := stripeAccount(accountsRes.Accounts, accountID)
+	if err != nil {
+		return err
+	}
+	result.Account = *account
+
+	result.StripeBankAccountToken, err = c.StripeBankAccountTokenCreateContext(ctx, result.AccessToken,
+		account.AccountID)
+	return err
+}
+
+// stripeAccount selects the account to create a Stripe bank account token for.
+func stripeAccount(accounts []Account, accountID AccountID) (*Account, error) {
+	var eligible []Account
+	for _, account := range accounts {
+		if accountID != "" && account.AccountID == accountID {
+			return &account, nil
+		}
+		if account.IsChecking() || account.IsSavings() {
+			eligible = append(eligible, account)
+		}
+	}
+	switch {
+	case accountID != "":
+		return nil, fmt.Errorf("account %s not found in item", accountID)
+	case len(eligible) == 1:
+		return &eligible[0], nil
+	case len(eligible) == 0:
+		return nil, errors.New("item has no checking or savings account")
+	default:
+		return nil, fmt.Errorf("item has %d checking or savings accounts, an account id must be specified",
+			len(eligible))
+	}
+}
@@ -0,0 +1,100 @@
+package plaid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// SupportBundle gathers diagnostic data about an item for a Plaid support ticket. It is
+// sanitized: it holds no access tokens, secrets or account data.
+type SupportBundle struct {
+	GeneratedAt   time.Time `json:"generated_at"`
+	Environment   string    `json:"environment"`
+	ItemID        ItemID    `json:"item_id,omitempty"`
+	InstitutionID string    `json:"institution_id,omitempty"`
+
+	// Error is the error being reported.
+	Error *SupportBundleError `json:"error,omitempty"`
+	// ItemError is the error state of the item according to /item/get.
+	ItemError         *SupportBundleError `json:"item_error,omitempty"`
+	InstitutionStatus *InstitutionStatus  `json:"institution_status,omitempty"`
+	// RecentRequests are the item's requests kept by the client's debug buffer, see
+	// WithDebugBuffer.
+	RecentRequests []RequestRecord `json:"recent_requests,omitempty"`
+
+	// CollectionErrors lists the data that couldn't be gathered and why.
+	CollectionErrors []string `json:"collection_errors,omitempty"`
+}
+
+// SupportBundleError is an error as included in a support bundle.
+type SupportBundleError struct {
+	ErrorType    string `json:"error_type,omitempty"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message"`
+	RequestID    string `json:"request_id,omitempty"`
+	StatusCode   int    `json:"status_code,omitempty"`
+}
+
+// SupportBundle gathers a support bundle for the item of accessToken and an error it ran
+// into, which may be nil. Data that can't be gathered, e.g. because the item was removed,
+// is listed in CollectionErrors rather than failing the bundle.
+func (c *Client) SupportBundle(accessToken string, err error) *SupportBundle {
+	return c.SupportBundleContext(context.Background(), accessToken, err)
+}
+
+// SupportBundleContext is like SupportBundle but carries a context.
+func (c *Client) SupportBundleContext(ctx context.Context, accessToken string, err error) *SupportBundle {
+	bundle := &SupportBundle{
+		GeneratedAt:    c.clock.Now().UTC(),
+		Environment:    string(c.environment),
+		Error:          supportBundleError(err),
+		RecentRequests: c.debug.snapshot(tokenHash(accessToken)),
+	}
+
+	itemRes, itemErr := c.ItemGetContext(ctx, accessToken)
+	if itemErr != nil {
+		bundle.CollectionErrors = append(bundle.CollectionErrors, "/item/get: "+itemErr.Error())
+		return bundle
+	}
+	bundle.ItemID = itemRes.Item.ItemId
+	bundle.InstitutionID = itemRes.Item.InstitutionId
+	if itemRes.Item.Error != nil && itemRes.Item.Error.ErrorCode != "" {
+		bundle.ItemError = supportBundleError(*itemRes.Item.Error)
+	}
+
+	if bundle.InstitutionID == "" {
+		return bundle
+	}
+	institutionRes, institutionErr := c.InstitutionGetByIDContext(ctx, bundle.InstitutionID, supportedCountryCodes,
+		&InstitutionOptions{IncludeStatus: true})
+	if institutionErr != nil {
+		bundle.CollectionErrors = append(bundle.CollectionErrors, "/institutions/get_by_id: "+institutionErr.Error())
+		return bundle
+	}
+	bundle.InstitutionStatus = institutionRes.Institution.Status
+	return bundle
+}
+
+// JSON encodes the bundle for attaching to a support ticket.
+func (b *SupportBundle) JSON() ([]byte, error) {
+	return json.MarshalIndent(b, "", "  ")
+}
+
+func supportBundleError(err error) *SupportBundleError {
+	if err == nil {
+		return nil
+	}
+	var plaidErr plaidError
+	if !errors.As(err, &plaidErr) {
+		return &SupportBundleError{ErrorMessage: err.Error()}
+	}
+	return &SupportBundleError{
+		ErrorType:    plaidErr.ErrorType,
+		ErrorCode:    plaidErr.ErrorCode,
+		ErrorMessage: plaidErr.ErrorMessage,
+		RequestID:    plaidErr.RequestID,
+		StatusCode:   plaidErr.StatusCode,
+	}
+}
@@ -0,0 +1,64 @@
+package plaid
+
+import (
+	"context"
+	"time"
+)
+
+// institutionCacheTTL is how long institution metadata is reused before it is fetched again,
+// unless configured otherwise with WithInstitutionCache.
+const institutionCacheTTL = 24 * time.Hour
+
+// supportedCountryCodes are the countries institutions are looked up in.
+var supportedCountryCodes = []string{"US", "CA", "GB", "IE", "FR", "ES", "NL", "DE", "IT"}
+
+// ProductSupport reports whether an institution supports a product.
+type ProductSupport struct {
+	InstitutionID string
+	Product       string
+	Supported     bool
+	// Products lists every product the institution supports.
+	Products []string
+}
+
+// InstitutionSupports reports whether an institution supports a product such as "auth" or
+// "transactions", e.g. before creating a link token for that combination. Institution
+// metadata is cached by the client for a day by default, so checks are cheap to repeat.
+func (c *Client) InstitutionSupports(institutionID, product string) (*ProductSupport, error) {
+	return c.InstitutionSupportsContext(context.Background(), institutionID, product)
+}
+
+// InstitutionSupportsContext is like InstitutionSupports but carries a context.
+func (c *Client) InstitutionSupportsContext(ctx context.Context, institutionID,
+	product string) (*ProductSupport, error) {
+
+	institution, err := c.cachedInstitution(ctx, institutionID)
+	if err != nil {
+		return nil, err
+	}
+	support := &ProductSupport{
+		InstitutionID: institutionID,
+		Product:       product,
+		Products:      institution.Products,
+	}
+	for _, p := range institution.Products {
+		if p == product {
+			support.Supported = true
+		}
+	}
+	return support, nil
+}
+
+func (c *Client) cachedInstitution(ctx context.Context, institutionID string) (*Institution, error) {
+	now := c.clock.Now()
+	if institution, ok := c.institutions.get(institutionID, now, c.emit); ok {
+		return &institution, nil
+	}
+
+	res, err := c.InstitutionGetByIDContext(ctx, institutionID, supportedCountryCodes, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.institutions.Add(institutionID, res.Institution, now)
+	return &res.Institution, nil
+}
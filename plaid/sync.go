@@ -0,0 +1,111 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+)
+
+// TransactionsSync (POST /transactions/sync) returns the transaction changes of an item
+// since cursor: transactions added, modified and removed. Pass an empty cursor to get the
+// full history, and the NextCursor of the response to continue while HasMore is true.
+//
+// See https://plaid.com/docs/api/products/transactions/#transactionssync.
+func (c *Client) TransactionsSync(accessToken, cursor string, count int) (*TransactionsSyncResponse, error) {
+	return c.TransactionsSyncContext(context.Background(), accessToken, cursor, count)
+}
+
+// TransactionsSyncContext is like TransactionsSync but carries a context.
+func (c *Client) TransactionsSyncContext(ctx context.Context, accessToken, cursor string,
+	count int) (*TransactionsSyncResponse, error) {
+
+	if count < 0 || count > 500 {
+		return nil, errors.New("/transactions/sync - count must be between 1 and 500")
+	}
+	var res TransactionsSyncResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/transactions/sync", transactionsSyncJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		AccessToken: accessToken,
+		Cursor:      cursor,
+		Count:       count,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// TransactionsSyncAll follows TransactionsSync from cursor until there are no more changes
+// and returns all of them in one response, whose NextCursor is the cursor to continue from
+// next time. If the item's transactions change while the pages are fetched, it restarts
+// from cursor as Plaid requires.
+func (c *Client) TransactionsSyncAll(accessToken, cursor string) (*TransactionsSyncResponse, error) {
+	return c.TransactionsSyncAllContext(context.Background(), accessToken, cursor)
+}
+
+// TransactionsSyncAllContext is like TransactionsSyncAll but carries a context.
+func (c *Client) TransactionsSyncAllContext(ctx context.Context, accessToken, cursor string) (*TransactionsSyncResponse, error) {
+	ctx, cancel := c.operation(ctx)
+	defer cancel()
+	for restarts := 0; ; restarts++ {
+		all, err := c.transactionsSyncPages(ctx, accessToken, cursor)
+		var plaidErr plaidError
+		if errors.As(err, &plaidErr) && restarts < 3 &&
+			plaidErr.ErrorCode == "TRANSACTIONS_SYNC_MUTATION_DURING_PAGINATION" {
+			c.emit(Event{Type: EventRetry, Endpoint: "/transactions/sync", Reason: plaidErr.ErrorCode})
+			continue
+		}
+		return all, err
+	}
+}
+
+func (c *Client) transactionsSyncPages(ctx context.Context, accessToken, cursor string) (*TransactionsSyncResponse, error) {
+	all := &TransactionsSyncResponse{NextCursor: cursor}
+	for {
+		var res *TransactionsSyncResponse
+		err := c.call(ctx, "/transactions/sync", func(ctx context.Context) (err error) {
+			res, err = c.TransactionsSyncContext(ctx, accessToken, all.NextCursor, 500)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		all.Added = append(all.Added, res.Added...)
+		all.Modified = append(all.Modified, res.Modified...)
+		all.Removed = append(all.Removed, res.Removed...)
+		all.NextCursor = res.NextCursor
+		all.RequestID = res.RequestID
+		if !res.HasMore {
+			return all, nil
+		}
+	}
+}
+
+// TransactionsSyncResponse is a page of transaction changes.
+//
+// See https://plaid.com/docs/api/products/transactions/#transactions-sync-response.
+type TransactionsSyncResponse struct {
+	Added      []Transaction        `json:"added"`
+	Modified   []Transaction        `json:"modified"`
+	Removed    []RemovedTransaction `json:"removed"`
+	NextCursor string               `json:"next_cursor"`
+	HasMore    bool                 `json:"has_more"`
+	RequestID  string               `json:"request_id"`
+}
+
+// RemovedTransaction identifies a transaction removed from an item. Removed transactions
+// must be deleted by consumers, e.g. pending transactions that posted under a new id; see
+// TransactionSyncStore.
+type RemovedTransaction struct {
+	TransactionID TransactionID `json:"transaction_id"`
+	AccountID     AccountID     `json:"account_id"`
+}
+
+type transactionsSyncJson struct {
+	ClientID    string `json:"client_id"`
+	Secret      string `json:"secret"`
+	AccessToken string `json:"access_token"`
+	Cursor      string `json:"cursor,omitempty"`
+	Count       int    `json:"count,omitempty"`
+}
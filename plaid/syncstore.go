@@ -0,0 +1,137 @@
+package plaid
+
+import (
+	"context"
+	"sync"
+)
+
+// TransactionChangeKind is the kind of a TransactionChange.
+type TransactionChangeKind int
+
+const (
+	TransactionAdded TransactionChangeKind = iota
+	TransactionModified
+	TransactionRemoved
+)
+
+// TransactionChange is a single change of a TransactionsSyncResponse. Transaction is set for
+// added and modified transactions, Removed for removed ones.
+type TransactionChange struct {
+	Kind        TransactionChangeKind
+	Transaction *Transaction
+	Removed     *RemovedTransaction
+}
+
+// TransactionID returns the id of the changed transaction.
+func (c TransactionChange) TransactionID() TransactionID {
+	if c.Removed != nil {
+		return c.Removed.TransactionID
+	}
+	return c.Transaction.TransactionID
+}
+
+// Changes returns the changes of the response as a single list, removals first, so that a
+// consumer handling them one by one handles deletions like any other change.
+func (r *TransactionsSyncResponse) Changes() []TransactionChange {
+	changes := make([]TransactionChange, 0, len(r.Removed)+len(r.Added)+len(r.Modified))
+	for i := range r.Removed {
+		changes = append(changes, TransactionChange{Kind: TransactionRemoved, Removed: &r.Removed[i]})
+	}
+	for i := range r.Added {
+		changes = append(changes, TransactionChange{Kind: TransactionAdded, Transaction: &r.Added[i]})
+	}
+	for i := range r.Modified {
+		changes = append(changes, TransactionChange{Kind: TransactionModified, Transaction: &r.Modified[i]})
+	}
+	return changes
+}
+
+// TransactionSyncStore persists the transactions of items kept up to date with
+// /transactions/sync, together with the cursor to continue from.
+type TransactionSyncStore interface {
+	// SyncCursor returns the cursor of an access token, or an empty string if it was never
+	// synced.
+	SyncCursor(ctx context.Context, accessToken string) (string, error)
+	// ApplySync stores the changes of res and its NextCursor in a single transaction: either
+	// all of them are applied or none are. Removed transactions must be deleted.
+	ApplySync(ctx context.Context, accessToken string, res *TransactionsSyncResponse) error
+}
+
+// SyncToStore fetches the transaction changes of an item since the cursor held by store
+// and applies them to store, as a single call that can't forget removed transactions. If
+// applying fails the cursor stays where it was, so the next call fetches the changes again.
+func (c *Client) SyncToStore(accessToken string, store TransactionSyncStore) (*TransactionsSyncResponse, error) {
+	return c.SyncToStoreContext(context.Background(), accessToken, store)
+}
+
+// SyncToStoreContext is like SyncToStore but carries a context.
+func (c *Client) SyncToStoreContext(ctx context.Context, accessToken string,
+	store TransactionSyncStore) (*TransactionsSyncResponse, error) {
+
+	cursor, err := store.SyncCursor(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.TransactionsSyncAllContext(ctx, accessToken, cursor)
+	if err != nil {
+		return nil, err
+	}
+	if err = store.ApplySync(ctx, accessToken, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MemoryTransactionSyncStore is a TransactionSyncStore that keeps transactions in memory.
+// It doesn't survive restarts and is meant for tests.
+type MemoryTransactionSyncStore struct {
+	mu      sync.Mutex
+	cursors map[string]string
+	items   map[string]map[TransactionID]Transaction // access token -> transaction id -> transaction
+}
+
+// NewMemoryTransactionSyncStore instantiates an empty MemoryTransactionSyncStore.
+func NewMemoryTransactionSyncStore() *MemoryTransactionSyncStore {
+	return &MemoryTransactionSyncStore{cursors: map[string]string{}, items: map[string]map[TransactionID]Transaction{}}
+}
+
+// SyncCursor implements TransactionSyncStore.
+func (s *MemoryTransactionSyncStore) SyncCursor(ctx context.Context, accessToken string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursors[accessToken], nil
+}
+
+// ApplySync implements TransactionSyncStore.
+func (s *MemoryTransactionSyncStore) ApplySync(ctx context.Context, accessToken string,
+	res *TransactionsSyncResponse) error {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	transactions, ok := s.items[accessToken]
+	if !ok {
+		transactions = map[TransactionID]Transaction{}
+		s.items[accessToken] = transactions
+	}
+	for _, change := range res.Changes() {
+		if change.Kind == TransactionRemoved {
+			delete(transactions, change.Removed.TransactionID)
+		} else {
+			transactions[change.Transaction.TransactionID] = *change.Transaction
+		}
+	}
+	s.cursors[accessToken] = res.NextCursor
+	return nil
+}
+
+// Transactions returns the stored transactions of an access token, ordered like
+// MergeTransactions.
+func (s *MemoryTransactionSyncStore) Transactions(accessToken string) []Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	transactions := make([]Transaction, 0, len(s.items[accessToken]))
+	for _, t := range s.items[accessToken] {
+		transactions = append(transactions, t)
+	}
+	return MergeTransactions(transactions)
+}
@@ -0,0 +1,145 @@
+package plaid
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ThrottleConfig configures adaptive throttling for a group of endpoints.
+//
+// Requests in a group are spaced to stay under the group's current rate. Whenever the
+// share of failed requests (429s, 5xx responses and transport errors) in a window exceeds
+// ErrorThreshold the rate is halved, down to MinRate; every healthy window afterwards adds
+// Recovery*Rate back until Rate is reached again.
+type ThrottleConfig struct {
+	// Rate is the number of requests per second allowed while the group is healthy.
+	Rate float64
+	// MinRate is the lowest rate the group is slowed down to. Defaults to Rate/10.
+	MinRate float64
+	// ErrorThreshold is the failure ratio above which the rate is reduced. Defaults to 0.1.
+	ErrorThreshold float64
+	// Window is the number of requests the failure ratio is computed over. Defaults to 20.
+	Window int
+	// Recovery is the fraction of Rate restored after each healthy window. Defaults to 0.1.
+	Recovery float64
+}
+
+// WithThrottle enables adaptive throttling. groups maps endpoint prefixes such as
+// "/transactions/" to the throttle used for the endpoints starting with them. The longest
+// matching prefix wins and the empty prefix matches every endpoint; endpoints matching no
+// prefix are not throttled.
+func WithThrottle(groups map[string]ThrottleConfig) Option {
+	return func(c *Client) {
+		t := &throttle{groups: map[string]*throttleGroup{}}
+		for prefix, config := range groups {
+			t.groups[prefix] = newThrottleGroup(config)
+		}
+		c.throttle = t
+	}
+}
+
+type throttle struct {
+	groups map[string]*throttleGroup // endpoint prefix -> group
+}
+
+// group returns the throttle group for endpoint, or nil if it isn't throttled.
+func (t *throttle) group(endpoint string) *throttleGroup {
+	if t == nil {
+		return nil
+	}
+	var match *throttleGroup
+	matchLen := -1
+	for prefix, group := range t.groups {
+		if strings.HasPrefix(endpoint, prefix) && len(prefix) > matchLen {
+			match, matchLen = group, len(prefix)
+		}
+	}
+	return match
+}
+
+type throttleGroup struct {
+	config ThrottleConfig
+
+	mu       sync.Mutex
+	rate     float64
+	next     time.Time // earliest time the next request may be sent
+	requests int       // requests in the current window
+	failures int       // failed requests in the current window
+}
+
+func newThrottleGroup(config ThrottleConfig) *throttleGroup {
+	if config.MinRate == 0 {
+		config.MinRate = config.Rate / 10
+	}
+	if config.ErrorThreshold == 0 {
+		config.ErrorThreshold = 0.1
+	}
+	if config.Window == 0 {
+		config.Window = 20
+	}
+	if config.Recovery == 0 {
+		config.Recovery = 0.1
+	}
+	return &throttleGroup{config: config, rate: config.Rate}
+}
+
+// wait blocks until the next request of the group may be sent.
+func (g *throttleGroup) wait(ctx context.Context, clock Clock) error {
+	if g == nil || g.config.Rate <= 0 {
+		return nil
+	}
+	g.mu.Lock()
+	now := clock.Now()
+	at := g.next
+	if at.Before(now) {
+		at = now
+	}
+	g.next = at.Add(time.Duration(float64(time.Second) / g.rate))
+	g.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-clock.After(delay):
+		return nil
+	}
+}
+
+// record accounts for the outcome of a request to endpoint and adapts the rate once a window
+// is full, reporting changes to emit unless it is nil.
+func (g *throttleGroup) record(failed bool, endpoint string, emit func(Event)) {
+	if g == nil || g.config.Rate <= 0 {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.requests++
+	if failed {
+		g.failures++
+	}
+	if g.requests < g.config.Window {
+		return
+	}
+	rate := g.rate
+	if float64(g.failures)/float64(g.requests) > g.config.ErrorThreshold {
+		g.rate /= 2
+		if g.rate < g.config.MinRate {
+			g.rate = g.config.MinRate
+		}
+	} else {
+		g.rate += g.config.Recovery * g.config.Rate
+		if g.rate > g.config.Rate {
+			g.rate = g.config.Rate
+		}
+	}
+	g.requests, g.failures = 0, 0
+	if g.rate != rate && emit != nil {
+		emit(Event{Type: EventThrottleChanged, Endpoint: endpoint, Rate: g.rate})
+	}
+}
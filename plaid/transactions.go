@@ -2,25 +2,33 @@ package plaid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 )
 
-// Balance (POST /balance) retrieves real-time balance for a given access token.
+// Transactions (POST /transactions/get) retrieves transactions between two dates
+// (formatted YYYY-MM-DD) for a given access token.
 //
-// See https://plaid.com/docs/api/#balance.
+// See https://plaid.com/docs/api/products/transactions/#transactionsget.
 func (c *Client) Transactions(accessToken string, startDate string, endDate string, options TransactionOptionsJson) (postRes *postResponse, err error) {
+	return c.TransactionsContext(context.Background(), accessToken, startDate, endDate, options)
+}
+
+// TransactionsContext is like Transactions but carries a context.
+func (c *Client) TransactionsContext(ctx context.Context, accessToken string, startDate string, endDate string, options TransactionOptionsJson) (postRes *postResponse, err error) {
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(transactionJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		StartDate:   startDate,
 		EndDate:     endDate,
-		Options:     options,
+		Options:     options.Profile.transactionOptions(options),
 	})
 	if err != nil {
 		return nil, err
 	}
-	postRes, _, err = c.postAndUnmarshal("/transactions/get", bytes.NewReader(jsonText))
+	postRes, _, err = c.postAndUnmarshalContext(ctx, "/transactions/get", bytes.NewReader(jsonText))
 	return postRes, err
 }
 
@@ -36,4 +44,35 @@ type transactionJson struct {
 type TransactionOptionsJson struct {
 	Count  int `json:"count"`
 	Offset int `json:"offset"`
+
+	IncludeOriginalDescription     bool `json:"include_original_description,omitempty"`
+	IncludePersonalFinanceCategory bool `json:"include_personal_finance_category,omitempty"`
+
+	// Profile, if set, overrides the Include flags above.
+	Profile PayloadProfile `json:"-"`
+}
+
+// allTransactions fetches every page of an item's transactions between two dates and
+// returns them along with the item.
+func (c *Client) allTransactions(ctx context.Context, accessToken, startDate,
+	endDate string) ([]Transaction, Item, error) {
+
+	ctx, cancel := c.operation(ctx)
+	defer cancel()
+	var transactions []Transaction
+	for {
+		var res *postResponse
+		err := c.call(ctx, "/transactions/get", func(ctx context.Context) (err error) {
+			res, err = c.TransactionsContext(ctx, accessToken, startDate, endDate,
+				TransactionOptionsJson{Count: 500, Offset: len(transactions)})
+			return err
+		})
+		if err != nil {
+			return nil, Item{}, err
+		}
+		transactions = append(transactions, res.Transactions...)
+		if len(res.Transactions) == 0 || len(transactions) >= res.TotalTransactions {
+			return transactions, res.Item, nil
+		}
+	}
 }
@@ -0,0 +1,38 @@
+package transactions
+
+// Counterparty is a party involved in a transaction, such as the merchant or the payment
+// processor.
+//
+// See https://plaid.com/docs/api/products/transactions/#transactions-get-response-transactions-counterparties.
+type Counterparty struct {
+	Name            string `json:"name"`
+	Type            string `json:"type"` // e.g. "merchant", "financial_institution", "payment_app"
+	LogoURL         string `json:"logo_url"`
+	Website         string `json:"website"`
+	EntityID        string `json:"entity_id"`
+	ConfidenceLevel string `json:"confidence_level"` // e.g. "VERY_HIGH", "HIGH", "MEDIUM", "LOW"
+}
+
+// confidenceRank orders confidence levels, unknown levels rank lowest.
+var confidenceRank = map[string]int{
+	"LOW":       1,
+	"MEDIUM":    2,
+	"HIGH":      3,
+	"VERY_HIGH": 4,
+}
+
+// PrimaryMerchant returns the merchant counterparty of the transaction Plaid is most
+// confident about. The second return value is false if there is no merchant counterparty.
+func (t Transaction) PrimaryMerchant() (Counterparty, bool) {
+	var primary Counterparty
+	found := false
+	for _, counterparty := range t.Counterparties {
+		if counterparty.Type != "merchant" {
+			continue
+		}
+		if !found || confidenceRank[counterparty.ConfidenceLevel] > confidenceRank[primary.ConfidenceLevel] {
+			primary, found = counterparty, true
+		}
+	}
+	return primary, found
+}
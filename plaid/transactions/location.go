@@ -0,0 +1,45 @@
+package transactions
+
+import (
+	"math"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// Location is where a transaction took place.
+//
+// Zip and State are populated by older API versions, newer ones send PostalCode, Region
+// and Country instead.
+type Location struct {
+	Address     string          `json:"address"`
+	City        string          `json:"city"`
+	Region      string          `json:"region"`
+	PostalCode  core.FlexString `json:"postal_code"`
+	Country     string          `json:"country"`
+	Lat         float64         `json:"lat"`
+	Lon         float64         `json:"lon"`
+	StoreNumber core.FlexString `json:"store_number"`
+	Zip         core.FlexString `json:"zip"`
+	State       string          `json:"state"`
+}
+
+// earthRadius is the mean radius of the earth in kilometers.
+const earthRadius = 6371.0
+
+// HasCoordinates reports whether the location carries a latitude and longitude.
+// Plaid sends null coordinates for most transactions, which decode to zero.
+func (l Location) HasCoordinates() bool {
+	return l.Lat != 0 || l.Lon != 0
+}
+
+// Distance returns the great-circle distance in kilometers between the location and
+// the given coordinates. The result is meaningless unless HasCoordinates is true.
+func (l Location) Distance(lat, lon float64) float64 {
+	lat1, lat2 := l.Lat*math.Pi/180, lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (lon - l.Lon) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
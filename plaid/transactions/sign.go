@@ -0,0 +1,88 @@
+package transactions
+
+import (
+	"math"
+	"strconv"
+)
+
+// SignConvention is how the sign of an amount tells money entering an account from money
+// leaving it.
+type SignConvention int
+
+const (
+	// PlaidNative is the convention of Plaid's API and of Transaction.Amount: positive
+	// amounts leave the account, e.g. purchases, and negative amounts enter it, e.g.
+	// paychecks and refunds.
+	PlaidNative SignConvention = iota
+	// AccountingNatural is the convention of most ledgers and UIs: positive amounts enter
+	// the account and negative amounts leave it.
+	AccountingNatural
+)
+
+func (sc SignConvention) String() string {
+	switch sc {
+	case PlaidNative:
+		return "PlaidNative"
+	case AccountingNatural:
+		return "AccountingNatural"
+	}
+	return "SignConvention(invalid)"
+}
+
+// IsOutflow reports whether the transaction moved money out of the account.
+func (t Transaction) IsOutflow() bool {
+	return t.Amount > 0
+}
+
+// IsInflow reports whether the transaction moved money into the account.
+func (t Transaction) IsInflow() bool {
+	return t.Amount < 0
+}
+
+// Outflow returns the amount of money the transaction moved out of the account, or 0 for
+// an inflow.
+func (t Transaction) Outflow() float64 {
+	return math.Max(t.Amount64(), 0)
+}
+
+// Inflow returns the amount of money the transaction moved into the account, or 0 for an
+// outflow.
+func (t Transaction) Inflow() float64 {
+	return math.Max(-t.Amount64(), 0)
+}
+
+// SignedAmount returns the transaction's amount signed according to convention.
+func (t Transaction) SignedAmount(convention SignConvention) float64 {
+	if convention == AccountingNatural {
+		return -t.Amount64()
+	}
+	return t.Amount64()
+}
+
+// Amount64 returns the transaction's amount as the float64 closest to its shortest decimal
+// representation, e.g. 4.33 rather than 4.329999923706055, so that it encodes and compares
+// like the amount Plaid sent. Use it rather than float64(t.Amount).
+func (t Transaction) Amount64() float64 {
+	amount, _ := strconv.ParseFloat(strconv.FormatFloat(float64(t.Amount), 'f', -1, 32), 64)
+	return amount
+}
+
+// NormalizedTransaction is a transaction with its amount in a chosen SignConvention. The
+// embedded Transaction keeps Plaid's amount, so Inflow and Outflow stay correct whatever
+// the convention.
+type NormalizedTransaction struct {
+	Transaction
+	// Amount is the transaction's amount signed according to Convention. It shadows the
+	// Plaid-native Transaction.Amount.
+	Amount     float64        `json:"amount"`
+	Convention SignConvention `json:"-"`
+}
+
+// Normalize returns the transactions with their amounts signed according to sc.
+func (sc SignConvention) Normalize(transactions []Transaction) []NormalizedTransaction {
+	normalized := make([]NormalizedTransaction, len(transactions))
+	for i, t := range transactions {
+		normalized[i] = NormalizedTransaction{Transaction: t, Amount: t.SignedAmount(sc), Convention: sc}
+	}
+	return normalized
+}
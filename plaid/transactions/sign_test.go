@@ -0,0 +1,43 @@
+package transactions
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	transactions := []Transaction{{Amount: 4.33}, {Amount: -6.33}, {Amount: 500}, {Amount: 0.1}}
+	cases := []struct {
+		convention SignConvention
+		want       []float64
+	}{
+		{PlaidNative, []float64{4.33, -6.33, 500, 0.1}},
+		{AccountingNatural, []float64{-4.33, 6.33, -500, -0.1}},
+	}
+	for _, c := range cases {
+		for i, n := range c.convention.Normalize(transactions) {
+			if n.Amount != c.want[i] {
+				t.Errorf("%v: amount %v normalized to %v, want %v", c.convention, transactions[i].Amount, n.Amount, c.want[i])
+			}
+		}
+	}
+	if got := transactions[0].Outflow(); got != 4.33 {
+		t.Errorf("Outflow = %v, want 4.33", got)
+	}
+	if got := transactions[1].Inflow(); got != 6.33 {
+		t.Errorf("Inflow = %v, want 6.33", got)
+	}
+}
+
+func TestAmount64(t *testing.T) {
+	for _, tc := range []struct {
+		amount float32
+		want   float64
+	}{
+		{4.33, 4.33},
+		{-6.33, -6.33},
+		{0.1, 0.1},
+		{1234.56, 1234.56},
+	} {
+		if got := (Transaction{Amount: tc.amount}).Amount64(); got != tc.want {
+			t.Errorf("Amount64 of %v = %v, want %v", tc.amount, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Package transactions holds the types of Plaid's Transactions product, which retrieves the
+// transaction history of accounts. The plaid package's Client makes the requests; see package
+// core for how the products are split out of plaid.
+//
+// See https://plaid.com/docs/transactions/.
+package transactions
+
+import "github.com/wearevest/plaidgo/plaid/core"
+
+// Transaction is a transaction of an account.
+//
+// See https://plaid.com/docs/api/products/transactions/#transactions-get-response-transactions.
+type Transaction struct {
+	PendingTransactionID core.TransactionID `json:"pending_transaction_id"`
+	Name                 string             `json:"name"`
+	AccountOwner         string             `json:"account_owner"`
+	Category             []string           `json:"category"`
+	TransactionType      string             `json:"transaction_type"`
+	AccountID            core.AccountID     `json:"account_id"`
+	// Amount is positive for money leaving the account and negative for money entering
+	// it, see SignConvention.
+	Amount        float32            `json:"amount"`
+	Date          string             `json:"date"`
+	TransactionID core.TransactionID `json:"transaction_id"`
+	Location      Location           `json:"location"`
+	CategoryID    string             `json:"category_id"`
+	Pending       bool               `json:"pending"`
+	PaymentMeta   struct {
+		Reason           string `json:"reason"`
+		Payee            string `json:"payee"`
+		PpdID            string `json:"ppd_id"`
+		Payer            string `json:"payer"`
+		ByOrderOf        string `json:"by_order_of"`
+		ReferenceNumber  string `json:"reference_number"`
+		PaymentProcessor string `json:"payment_processor"`
+		PaymentMethod    string `json:"payment_method"`
+	} `json:"payment_meta"`
+	Counterparties   []Counterparty `json:"counterparties"`
+	MerchantName     string         `json:"merchant_name"`
+	MerchantEntityID string         `json:"merchant_entity_id"`
+
+	PersonalFinanceCategory *PersonalFinanceCategory `json:"personal_finance_category"`
+	OriginalDescription     string                   `json:"original_description"`
+
+	IsoCurrencyCode        string `json:"iso_currency_code"`
+	UnofficialCurrencyCode string `json:"unofficial_currency_code"`
+}
+
+// CurrencyCode returns the ISO currency code of the transaction, or the unofficial one for
+// currencies without an ISO code.
+func (t Transaction) CurrencyCode() string {
+	if t.IsoCurrencyCode != "" {
+		return t.IsoCurrencyCode
+	}
+	return t.UnofficialCurrencyCode
+}
+
+// PersonalFinanceCategory is Plaid's two level transaction categorization.
+//
+// See https://plaid.com/docs/api/products/transactions/#transactions-get-response-transactions-personal-finance-category.
+type PersonalFinanceCategory struct {
+	Primary         string `json:"primary"`  // e.g. "FOOD_AND_DRINK"
+	Detailed        string `json:"detailed"` // e.g. "FOOD_AND_DRINK_COFFEE"
+	ConfidenceLevel string `json:"confidence_level"`
+}
@@ -0,0 +1,131 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/wearevest/plaidgo/plaid/transfer"
+)
+
+// TransferIntentCreate (POST /transfer/intent/create) creates a transfer intent for the
+// Transfer UI. Pass the intent's ID to /link/token/create so that Link collects the
+// account and authorizes the transfer, then call TransferIntentGet for the outcome.
+// amount is in dollars, e.g. 12.34.
+//
+// See https://plaid.com/docs/api/products/transfer/#transferintentcreate.
+func (c *Client) TransferIntentCreate(mode TransferIntentMode, amount float64, description string,
+	user TransferUser, options *TransferIntentCreateOptions) (*TransferIntent, error) {
+	return c.TransferIntentCreateContext(context.Background(), mode, amount, description, user, options)
+}
+
+// TransferIntentCreateContext is like TransferIntentCreate but carries a context.
+func (c *Client) TransferIntentCreateContext(ctx context.Context, mode TransferIntentMode, amount float64,
+	description string, user TransferUser, options *TransferIntentCreateOptions) (*TransferIntent, error) {
+
+	if mode != TransferIntentPayment && mode != TransferIntentDisbursement {
+		return nil, errors.New("/transfer/intent/create - mode must be PAYMENT or DISBURSEMENT")
+	}
+	if amount <= 0 {
+		return nil, errors.New("/transfer/intent/create - amount must be positive")
+	}
+	if user.LegalName == "" {
+		return nil, errors.New("/transfer/intent/create - user legal name must be specified")
+	}
+	creds := c.creds.Load()
+	request := transferIntentCreateJson{
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
+		Mode:        mode,
+		Amount:      strconv.FormatFloat(amount, 'f', 2, 64),
+		Description: description,
+		User:        user,
+	}
+	if options != nil {
+		request.AccountID = options.AccountID
+		request.ACHClass = options.ACHClass
+		request.OriginationAccountID = options.OriginationAccountID
+		request.Metadata = options.Metadata
+		request.IsoCurrencyCode = options.IsoCurrencyCode
+		request.RequireGuarantee = options.RequireGuarantee
+	}
+	var res transferIntentResponse
+	if err := c.postAndDecode(ctx, "/transfer/intent/create", request, &res); err != nil {
+		return nil, err
+	}
+	return &res.TransferIntent, nil
+}
+
+// TransferIntentGet (POST /transfer/intent/get) retrieves a transfer intent, including its
+// status and authorization decision once the user went through Link.
+//
+// See https://plaid.com/docs/api/products/transfer/#transferintentget.
+func (c *Client) TransferIntentGet(transferIntentID string) (*TransferIntent, error) {
+	return c.TransferIntentGetContext(context.Background(), transferIntentID)
+}
+
+// TransferIntentGetContext is like TransferIntentGet but carries a context.
+func (c *Client) TransferIntentGetContext(ctx context.Context, transferIntentID string) (*TransferIntent, error) {
+	if transferIntentID == "" {
+		return nil, errors.New("/transfer/intent/get - transfer intent id must be specified")
+	}
+	var res transferIntentResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/transfer/intent/get", transferIntentGetJson{
+		ClientID:         creds.clientID,
+		Secret:           creds.secret,
+		TransferIntentID: transferIntentID,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.TransferIntent, nil
+}
+
+// TransferIntentMode is the direction of the funds of a transfer intent, see
+// transfer.IntentMode.
+type TransferIntentMode = transfer.IntentMode
+
+const (
+	TransferIntentPayment      = transfer.IntentPayment
+	TransferIntentDisbursement = transfer.IntentDisbursement
+)
+
+// TransferIntentCreateOptions represents the optional fields of a transfer intent, see
+// transfer.IntentCreateOptions.
+type TransferIntentCreateOptions = transfer.IntentCreateOptions
+
+// TransferUser is the account holder of a transfer, see transfer.User.
+type TransferUser = transfer.User
+
+// TransferUserAddress is the address of a TransferUser.
+type TransferUserAddress = transfer.UserAddress
+
+// TransferIntent is a transfer intent of the Transfer UI, see transfer.Intent.
+type TransferIntent = transfer.Intent
+
+type transferIntentResponse struct {
+	TransferIntent TransferIntent `json:"transfer_intent"`
+	RequestID      string         `json:"request_id"`
+}
+
+type transferIntentCreateJson struct {
+	ClientID             string             `json:"client_id"`
+	Secret               string             `json:"secret"`
+	AccountID            AccountID          `json:"account_id,omitempty"`
+	Mode                 TransferIntentMode `json:"mode"`
+	Amount               string             `json:"amount"`
+	Description          string             `json:"description"`
+	ACHClass             string             `json:"ach_class,omitempty"`
+	OriginationAccountID string             `json:"origination_account_id,omitempty"`
+	User                 TransferUser       `json:"user"`
+	Metadata             map[string]string  `json:"metadata,omitempty"`
+	IsoCurrencyCode      string             `json:"iso_currency_code,omitempty"`
+	RequireGuarantee     bool               `json:"require_guarantee,omitempty"`
+}
+
+type transferIntentGetJson struct {
+	ClientID         string `json:"client_id"`
+	Secret           string `json:"secret"`
+	TransferIntentID string `json:"transfer_intent_id"`
+}
@@ -0,0 +1,79 @@
+// Package transfer holds the types of Plaid's Transfer product, which moves money between
+// your accounts and your users'. The plaid package's Client makes the requests; see package
+// core for how the products are split out of plaid.
+//
+// See https://plaid.com/docs/transfer/.
+package transfer
+
+import "github.com/wearevest/plaidgo/plaid/core"
+
+// IntentMode is the direction of the funds of a transfer intent.
+type IntentMode string
+
+const (
+	// IntentPayment moves funds from the user's account to yours.
+	IntentPayment IntentMode = "PAYMENT"
+	// IntentDisbursement moves funds from your account to the user's.
+	IntentDisbursement IntentMode = "DISBURSEMENT"
+)
+
+// IntentCreateOptions represents the optional fields of a transfer intent.
+type IntentCreateOptions struct {
+	// AccountID, if set, skips account selection in Link.
+	AccountID            core.AccountID
+	ACHClass             string // e.g. "ppd", "ccd" or "web"
+	OriginationAccountID string
+	Metadata             map[string]string
+	IsoCurrencyCode      string
+	RequireGuarantee     bool
+}
+
+// User is the account holder of a transfer.
+type User struct {
+	LegalName    string       `json:"legal_name"`
+	PhoneNumber  string       `json:"phone_number,omitempty"`
+	EmailAddress string       `json:"email_address,omitempty"`
+	Address      *UserAddress `json:"address,omitempty"`
+}
+
+// UserAddress is the address of a User.
+type UserAddress = core.UserAddress
+
+// Intent is a transfer intent of the Transfer UI.
+//
+// See https://plaid.com/docs/api/products/transfer/#transfer-intent-get-response-transfer-intent.
+type Intent struct {
+	ID          string            `json:"id"`
+	Created     string            `json:"created"` // RFC 3339 timestamp
+	Status      string            `json:"status"`  // "PENDING", "SUCCEEDED" or "FAILED"
+	TransferID  string            `json:"transfer_id"`
+	AccountID   core.AccountID    `json:"account_id"`
+	Amount      string            `json:"amount"` // decimal string, e.g. "12.34"
+	Mode        IntentMode        `json:"mode"`
+	ACHClass    string            `json:"ach_class"`
+	Description string            `json:"description"`
+	User        User              `json:"user"`
+	Metadata    map[string]string `json:"metadata"`
+
+	IsoCurrencyCode string `json:"iso_currency_code"`
+
+	FailureReason *struct {
+		ErrorType    string `json:"error_type"`
+		ErrorCode    string `json:"error_code"`
+		ErrorMessage string `json:"error_message"`
+	} `json:"failure_reason"`
+
+	// AuthorizationDecision is "APPROVED" or "DECLINED", or empty until the user went
+	// through Link.
+	AuthorizationDecision          string `json:"authorization_decision"`
+	AuthorizationDecisionRationale *struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"authorization_decision_rationale"`
+	GuaranteeDecision string `json:"guarantee_decision"`
+}
+
+// Approved reports whether the transfer was authorized.
+func (t *Intent) Approved() bool {
+	return t.AuthorizationDecision == "APPROVED"
+}
@@ -0,0 +1,68 @@
+// Package transport holds the HTTP plumbing every Plaid request shares: building requests
+// with the headers Plaid expects, reading responses and decoding Plaid's error responses.
+// It doesn't depend on the plaid package, whose Client layers throttling, hooks, caching
+// and retries on top; see package core for how the plaid package is split up.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// UserAgent is the User-Agent header of every request.
+const UserAgent = "plaid-go"
+
+// NewRequest builds a JSON request to url with the given body, which may be nil. apiVersion
+// is sent as the Plaid-Version header unless it is empty, and header, which may be nil, is
+// added on top.
+func NewRequest(ctx context.Context, method, url string, body []byte, apiVersion string,
+	header http.Header) (*http.Request, error) {
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("User-Agent", UserAgent)
+	if apiVersion != "" {
+		req.Header.Add("Plaid-Version", apiVersion)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	return req, nil
+}
+
+// Do sends req with client and returns the response together with its fully read body. The
+// response is nil if none was received; it is returned along with the error if its body
+// couldn't be read.
+func Do(client *http.Client, req *http.Request) (*http.Response, []byte, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	return res, body, err
+}
+
+// DecodeError decodes the body of a failed response into a core.Error carrying statusCode,
+// or returns a *core.DecodeError if the body isn't one.
+func DecodeError(statusCode int, body []byte) error {
+	var plaidErr core.Error
+	if err := core.Decode(body, &plaidErr); err != nil {
+		return err
+	}
+	plaidErr.StatusCode = statusCode
+	return plaidErr
+}
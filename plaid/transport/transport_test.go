@@ -0,0 +1,40 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+func TestNewRequest(t *testing.T) {
+	req, err := NewRequest(context.Background(), "POST", "https://sandbox.plaid.com/accounts/get", []byte(`{}`),
+		"2020-09-14", http.Header{"If-None-Match": {`"v1"`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"Content-Type":  "application/json",
+		"User-Agent":    UserAgent,
+		"Plaid-Version": "2020-09-14",
+		"If-None-Match": `"v1"`,
+	} {
+		if got := req.Header.Get(name); got != want {
+			t.Errorf("header %s = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	err := DecodeError(400, []byte(`{"error_type": "ITEM_ERROR", "error_code": "ITEM_LOGIN_REQUIRED"}`))
+	var plaidErr core.Error
+	if !errors.As(err, &plaidErr) || plaidErr.ErrorCode != "ITEM_LOGIN_REQUIRED" || plaidErr.StatusCode != 400 {
+		t.Fatalf("DecodeError = %#v, want an ITEM_LOGIN_REQUIRED error with status 400", err)
+	}
+	var decodeErr *core.DecodeError
+	if err = DecodeError(502, []byte(`<html>`)); !errors.As(err, &decodeErr) {
+		t.Fatalf("DecodeError = %v, want a *core.DecodeError for a body that isn't JSON", err)
+	}
+}
@@ -2,18 +2,32 @@ package plaid
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 )
 
 // Upgrade (POST /upgrade) upgrades an access token to an additional product.
 //
 // See https://plaid.com/docs/api/#upgrade-user.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
 func (c *Client) Upgrade(accessToken, upgradeTo string,
 	options *UpgradeOptions) (postRes *postResponse, mfaRes *mfaResponse, err error) {
 
+	return c.UpgradeContext(context.Background(), accessToken, upgradeTo, options)
+}
+
+// UpgradeContext is like Upgrade but carries a context.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
+func (c *Client) UpgradeContext(ctx context.Context, accessToken, upgradeTo string,
+	options *UpgradeOptions) (postRes *postResponse, mfaRes *mfaResponse, err error) {
+
+	c.deprecated("Upgrade", "Link in update mode, see LinkTokenCreateOptions.AccessToken")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(upgradeJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		UpgradeTo:   upgradeTo,
 		Options:     options,
@@ -21,46 +35,72 @@ func (c *Client) Upgrade(accessToken, upgradeTo string,
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/upgrade", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/upgrade", bytes.NewReader(jsonText))
 }
 
 // UpgradeStepSendMethod (POST /upgrade/step) specifies a particular send method for MFA,
 // e.g. {"mask":"xxx-xxx-5309"}.
 //
 // See https://plaid.com/docs/api/#upgrade-user.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
 func (c *Client) UpgradeStepSendMethod(accessToken, key, value string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.UpgradeStepSendMethodContext(context.Background(), accessToken, key, value)
+}
+
+// UpgradeStepSendMethodContext is like UpgradeStepSendMethod but carries a context.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
+func (c *Client) UpgradeStepSendMethodContext(ctx context.Context, accessToken, key, value string) (postRes *postResponse,
+	mfaRes *mfaResponse, err error) {
+
+	c.deprecated("UpgradeStepSendMethod", "Link in update mode, see LinkTokenCreateOptions.AccessToken")
 	sendMethod := map[string]string{key: value}
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(upgradeStepSendMethodJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		Options:     upgradeStepOptions{sendMethod},
 	})
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/upgrade/step", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/upgrade/step", bytes.NewReader(jsonText))
 }
 
 // UpgradeStep (POST /upgrade/step) submits an MFA answer for a given access token.
 //
 // See https://plaid.com/docs/api/#mfa-authentication for upgrades to Connect.
 // See https://plaid.com/docs/api/#mfa-auth for upgrades to Auth.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
 func (c *Client) UpgradeStep(accessToken, answer string) (postRes *postResponse,
 	mfaRes *mfaResponse, err error) {
 
+	return c.UpgradeStepContext(context.Background(), accessToken, answer)
+}
+
+// UpgradeStepContext is like UpgradeStep but carries a context.
+//
+// Deprecated: use Link in update mode, see LinkTokenCreateOptions.AccessToken instead.
+func (c *Client) UpgradeStepContext(ctx context.Context, accessToken, answer string) (postRes *postResponse,
+	mfaRes *mfaResponse, err error) {
+
+	c.deprecated("UpgradeStep", "Link in update mode, see LinkTokenCreateOptions.AccessToken")
+	creds := c.creds.Load()
 	jsonText, err := json.Marshal(upgradeStepJson{
-		ClientID:    c.clientID,
-		Secret:      c.secret,
+		ClientID:    creds.clientID,
+		Secret:      creds.secret,
 		AccessToken: accessToken,
 		MFA:         answer,
 	})
 	if err != nil {
 		return nil, nil, err
 	}
-	return c.postAndUnmarshal("/upgrade/step", bytes.NewReader(jsonText))
+	return c.postAndUnmarshalContext(ctx, "/upgrade/step", bytes.NewReader(jsonText))
 }
 
 // UpgradeOptions represents options associated with upgrading a user.
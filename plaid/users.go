@@ -0,0 +1,116 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"regexp"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// LinkUser identifies the end user a link token is created for, along with the identity
+// details the application already knows, which Link can prefill and use to speed up
+// verification. It is meant as the one description of a user across products: TransferUser
+// derives the account holder of a transfer from it.
+//
+// See https://plaid.com/docs/api/tokens/#link-token-create-request-user.
+type LinkUser struct {
+	// ClientUserID is the application's stable id of the user. It must not contain personal
+	// information such as an email address or phone number.
+	ClientUserID string `json:"client_user_id"`
+	LegalName    string `json:"legal_name,omitempty"`
+	// PhoneNumber is in E.164 format, e.g. "+14155550123".
+	PhoneNumber string `json:"phone_number,omitempty"`
+	// PhoneNumberVerifiedTime is when the application verified the phone number, if it did.
+	PhoneNumberVerifiedTime *time.Time `json:"phone_number_verified_time,omitempty"`
+	EmailAddress            string     `json:"email_address,omitempty"`
+	// EmailAddressVerifiedTime is when the application verified the email address, if it did.
+	EmailAddressVerifiedTime *time.Time `json:"email_address_verified_time,omitempty"`
+	// DateOfBirth is a Plaid date ("2006-01-02").
+	DateOfBirth string       `json:"date_of_birth,omitempty"`
+	Address     *UserAddress `json:"address,omitempty"`
+}
+
+// UserAddress is the postal address of a user, see core.UserAddress.
+type UserAddress = core.UserAddress
+
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// Validate checks the fields of the user Plaid would reject or that can't be right.
+func (u LinkUser) Validate() error {
+	switch {
+	case u.ClientUserID == "":
+		return errors.New("user client_user_id must be specified")
+	case u.ClientUserID == u.EmailAddress || u.ClientUserID == u.PhoneNumber:
+		return errors.New("user client_user_id must not be an email address or phone number")
+	case u.PhoneNumber != "" && !e164.MatchString(u.PhoneNumber):
+		return errors.New("user phone number must be in E.164 format, e.g. +14155550123")
+	case u.PhoneNumber == "" && u.PhoneNumberVerifiedTime != nil:
+		return errors.New("user phone number verified time is set without a phone number")
+	case u.EmailAddress == "" && u.EmailAddressVerifiedTime != nil:
+		return errors.New("user email address verified time is set without an email address")
+	}
+	if u.EmailAddress != "" {
+		if address, err := mail.ParseAddress(u.EmailAddress); err != nil || address.Address != u.EmailAddress {
+			return errors.New("user email address " + u.EmailAddress + " is invalid")
+		}
+	}
+	if u.DateOfBirth != "" {
+		if _, err := time.Parse("2006-01-02", u.DateOfBirth); err != nil {
+			return errors.New("user date of birth must be formatted as 2006-01-02")
+		}
+	}
+	return nil
+}
+
+// TransferUser returns the user as the account holder of a transfer.
+func (u LinkUser) TransferUser() TransferUser {
+	return TransferUser{
+		LegalName:    u.LegalName,
+		PhoneNumber:  u.PhoneNumber,
+		EmailAddress: u.EmailAddress,
+		Address:      u.Address,
+	}
+}
+
+// UserCreate (POST /user/create) creates a Plaid user for the application's user with the
+// given id. Products that verify a person rather than an item, such as income
+// verification, require the returned user token.
+//
+// See https://plaid.com/docs/api/users/#usercreate.
+func (c *Client) UserCreate(clientUserID string) (*UserCreateResponse, error) {
+	return c.UserCreateContext(context.Background(), clientUserID)
+}
+
+// UserCreateContext is like UserCreate but carries a context.
+func (c *Client) UserCreateContext(ctx context.Context, clientUserID string) (*UserCreateResponse, error) {
+	if clientUserID == "" {
+		return nil, errors.New("/user/create - client user id must be specified")
+	}
+	var res UserCreateResponse
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/user/create", userCreateJson{
+		ClientID:     creds.clientID,
+		Secret:       creds.secret,
+		ClientUserID: clientUserID,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// UserCreateResponse is the response of UserCreate.
+type UserCreateResponse struct {
+	UserToken string `json:"user_token"`
+	UserID    string `json:"user_id"`
+	RequestID string `json:"request_id"`
+}
+
+type userCreateJson struct {
+	ClientID     string `json:"client_id"`
+	Secret       string `json:"secret"`
+	ClientUserID string `json:"client_user_id"`
+}
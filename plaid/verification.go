@@ -0,0 +1,98 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/auth"
+)
+
+// VerificationStatus is the state of an account added through micro-deposit based Auth flows.
+type VerificationStatus = auth.VerificationStatus
+
+const (
+	PendingAutomaticVerification = auth.PendingAutomaticVerification
+	PendingManualVerification    = auth.PendingManualVerification
+	AutomaticallyVerified        = auth.AutomaticallyVerified
+	ManuallyVerified             = auth.ManuallyVerified
+	VerificationFailed           = auth.VerificationFailed
+	VerificationExpired          = auth.VerificationExpired
+)
+
+// VerificationPollOptions represents options associated with waiting for verification.
+type VerificationPollOptions struct {
+	// InitialInterval is the delay before the first retry. Defaults to 30 seconds.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between polls, which doubles after every poll.
+	// Defaults to one hour.
+	MaxInterval time.Duration
+}
+
+// WaitForVerification polls an account until its verification status is terminal and
+// returns that status. Polls back off exponentially.
+//
+// It returns an error rather than waiting for a status that will never come if the account
+// has no verification status, i.e. wasn't added through a micro-deposit flow, or has one
+// this package doesn't know.
+//
+// Applications receiving Auth webhooks can use the AuthVerificationWebhook instead.
+func (c *Client) WaitForVerification(accessToken string, accountID AccountID,
+	options *VerificationPollOptions) (VerificationStatus, error) {
+
+	return c.WaitForVerificationContext(context.Background(), accessToken, accountID, options)
+}
+
+// WaitForVerificationContext is like WaitForVerification but carries a context. Cancel ctx
+// to give up waiting.
+func (c *Client) WaitForVerificationContext(ctx context.Context, accessToken string, accountID AccountID,
+	options *VerificationPollOptions) (VerificationStatus, error) {
+
+	interval, maxInterval := 30*time.Second, time.Hour
+	if options != nil && options.InitialInterval > 0 {
+		interval = options.InitialInterval
+	}
+	if options != nil && options.MaxInterval > 0 {
+		maxInterval = options.MaxInterval
+	}
+
+	for {
+		status, err := c.verificationStatus(ctx, accessToken, accountID)
+		if err != nil {
+			return "", err
+		}
+		if status.Terminal() {
+			return status, nil
+		}
+		if !status.Pending() {
+			return status, fmt.Errorf("account %s has no pending verification, its status is %q",
+				accountID, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-c.clock.After(interval):
+		}
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func (c *Client) verificationStatus(ctx context.Context, accessToken string,
+	accountID AccountID) (VerificationStatus, error) {
+
+	res, err := c.AccountsContext(ctx, accessToken)
+	if err != nil {
+		return "", err
+	}
+	for _, account := range res.Accounts {
+		if account.AccountID == accountID {
+			return account.VerificationStatus, nil
+		}
+	}
+	return "", errors.New("account " + string(accountID) + " not found")
+}
@@ -0,0 +1,29 @@
+package plaid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWaitForVerificationUnknownStatus(t *testing.T) {
+	for _, status := range []string{"", "database_matched"} {
+		t.Run(status+" status", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"accounts": [{"account_id": "a1", "verification_status": "` + status + `"}]}`))
+			}))
+			defer server.Close()
+			c := NewClient("id", "secret", Sandbox, WithBaseURL(server.URL))
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			got, err := c.WaitForVerificationContext(ctx, "access-sandbox-1", "a1",
+				&VerificationPollOptions{InitialInterval: time.Millisecond})
+			if err == nil || ctx.Err() != nil {
+				t.Fatalf("WaitForVerification = %q, %v, want an error without waiting", got, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package plaid
+
+// Plaid API versions whose response shapes this package has converters for. Responses of
+// the latest version decode into the structs of this package directly; see package
+// versions/v2019 for the structs of older versions.
+//
+// See https://plaid.com/docs/api/versioning/.
+const (
+	APIVersion20190529 = "2019-05-29"
+	APIVersion20200914 = "2020-09-14"
+)
+
+// WithAPIVersion pins the client to a Plaid API version by sending it in the Plaid-Version
+// header of every request, instead of using the version configured for the Plaid account.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
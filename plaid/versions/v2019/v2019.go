@@ -0,0 +1,236 @@
+// Package v2019 holds the account and transaction structs in the shape of Plaid API version
+// 2019-05-29, with functions converting them from and to the structs of the plaid package.
+//
+// It is meant for code written against that version, e.g. code persisting its responses, so
+// that it can upgrade to a newer version of this library and of the API one call site at a
+// time. Fields that version didn't have are dropped when converting from the plaid package,
+// and left empty when converting to it.
+//
+// See https://plaid.com/docs/api/versioning/#version-2020-09-14.
+package v2019
+
+import (
+	"github.com/wearevest/plaidgo/plaid"
+)
+
+// Version is the Plaid-Version whose response shapes this package models.
+const Version = plaid.APIVersion20190529
+
+// Account is an account as returned by version 2019-05-29.
+type Account struct {
+	AccountID          plaid.AccountID  `json:"account_id"`
+	Balances           Balances         `json:"balances"`
+	Mask               plaid.FlexString `json:"mask"`
+	Name               string           `json:"name"`
+	OfficialName       string           `json:"official_name"`
+	Type               string           `json:"type"`
+	Subtype            string           `json:"subtype"`
+	VerificationStatus string           `json:"verification_status"`
+}
+
+// Balances are the balances of an Account.
+type Balances struct {
+	Available              float64 `json:"available"`
+	Current                float64 `json:"current"`
+	Limit                  float64 `json:"limit"`
+	IsoCurrencyCode        string  `json:"iso_currency_code"`
+	UnofficialCurrencyCode string  `json:"unofficial_currency_code"`
+}
+
+// Transaction is a transaction as returned by version 2019-05-29, before merchant names,
+// personal finance categories and counterparties were added.
+type Transaction struct {
+	AccountID              plaid.AccountID     `json:"account_id"`
+	AccountOwner           string              `json:"account_owner"`
+	Amount                 float32             `json:"amount"`
+	IsoCurrencyCode        string              `json:"iso_currency_code"`
+	UnofficialCurrencyCode string              `json:"unofficial_currency_code"`
+	Category               []string            `json:"category"`
+	CategoryID             string              `json:"category_id"`
+	Date                   string              `json:"date"`
+	Location               Location            `json:"location"`
+	Name                   string              `json:"name"`
+	PaymentMeta            PaymentMeta         `json:"payment_meta"`
+	Pending                bool                `json:"pending"`
+	PendingTransactionID   plaid.TransactionID `json:"pending_transaction_id"`
+	TransactionID          plaid.TransactionID `json:"transaction_id"`
+	TransactionType        string              `json:"transaction_type"`
+}
+
+// Location is where a Transaction took place. Version 2019-05-29 sends State and Zip, which
+// later versions renamed to Region and PostalCode.
+type Location struct {
+	Address     string           `json:"address"`
+	City        string           `json:"city"`
+	State       string           `json:"state"`
+	Zip         plaid.FlexString `json:"zip"`
+	Lat         float64          `json:"lat"`
+	Lon         float64          `json:"lon"`
+	StoreNumber plaid.FlexString `json:"store_number"`
+}
+
+// PaymentMeta holds the payment details of a Transaction.
+type PaymentMeta struct {
+	ByOrderOf        string `json:"by_order_of"`
+	Payee            string `json:"payee"`
+	Payer            string `json:"payer"`
+	PaymentMethod    string `json:"payment_method"`
+	PaymentProcessor string `json:"payment_processor"`
+	PpdID            string `json:"ppd_id"`
+	Reason           string `json:"reason"`
+	ReferenceNumber  string `json:"reference_number"`
+}
+
+// FromAccount converts an account of the plaid package.
+func FromAccount(account plaid.Account) Account {
+	return Account{
+		AccountID: account.AccountID,
+		Balances: Balances{
+			Available:              account.Balances.Available,
+			Current:                account.Balances.Current,
+			Limit:                  account.Balances.Limit,
+			IsoCurrencyCode:        account.Balances.IsoCurrencyCode,
+			UnofficialCurrencyCode: account.Balances.UnofficialCurrencyCode,
+		},
+		Mask:               account.Mask,
+		Name:               account.Name,
+		OfficialName:       account.OfficialName,
+		Type:               account.Type,
+		Subtype:            account.Subtype,
+		VerificationStatus: string(account.VerificationStatus),
+	}
+}
+
+// ToAccount converts an account to the plaid package's Account.
+func ToAccount(account Account) plaid.Account {
+	var converted plaid.Account
+	converted.AccountID = account.AccountID
+	converted.Balances.Available = account.Balances.Available
+	converted.Balances.Current = account.Balances.Current
+	converted.Balances.Limit = account.Balances.Limit
+	converted.Balances.IsoCurrencyCode = account.Balances.IsoCurrencyCode
+	converted.Balances.UnofficialCurrencyCode = account.Balances.UnofficialCurrencyCode
+	converted.Mask = account.Mask
+	converted.Name = account.Name
+	converted.OfficialName = account.OfficialName
+	converted.Type = account.Type
+	converted.Subtype = account.Subtype
+	converted.VerificationStatus = plaid.VerificationStatus(account.VerificationStatus)
+	return converted
+}
+
+// FromTransaction converts a transaction of the plaid package.
+func FromTransaction(transaction plaid.Transaction) Transaction {
+	meta := transaction.PaymentMeta
+	return Transaction{
+		AccountID:              transaction.AccountID,
+		AccountOwner:           transaction.AccountOwner,
+		Amount:                 transaction.Amount,
+		IsoCurrencyCode:        transaction.IsoCurrencyCode,
+		UnofficialCurrencyCode: transaction.UnofficialCurrencyCode,
+		Category:               transaction.Category,
+		CategoryID:             transaction.CategoryID,
+		Date:                   transaction.Date,
+		Location:               FromLocation(transaction.Location),
+		Name:                   transaction.Name,
+		PaymentMeta: PaymentMeta{
+			ByOrderOf:        meta.ByOrderOf,
+			Payee:            meta.Payee,
+			Payer:            meta.Payer,
+			PaymentMethod:    meta.PaymentMethod,
+			PaymentProcessor: meta.PaymentProcessor,
+			PpdID:            meta.PpdID,
+			Reason:           meta.Reason,
+			ReferenceNumber:  meta.ReferenceNumber,
+		},
+		Pending:              transaction.Pending,
+		PendingTransactionID: transaction.PendingTransactionID,
+		TransactionID:        transaction.TransactionID,
+		TransactionType:      transaction.TransactionType,
+	}
+}
+
+// ToTransaction converts a transaction to the plaid package's Transaction.
+func ToTransaction(transaction Transaction) plaid.Transaction {
+	converted := plaid.Transaction{
+		AccountID:              transaction.AccountID,
+		AccountOwner:           transaction.AccountOwner,
+		Amount:                 transaction.Amount,
+		IsoCurrencyCode:        transaction.IsoCurrencyCode,
+		UnofficialCurrencyCode: transaction.UnofficialCurrencyCode,
+		Category:               transaction.Category,
+		CategoryID:             transaction.CategoryID,
+		Date:                   transaction.Date,
+		Location:               ToLocation(transaction.Location),
+		Name:                   transaction.Name,
+		Pending:                transaction.Pending,
+		PendingTransactionID:   transaction.PendingTransactionID,
+		TransactionID:          transaction.TransactionID,
+		TransactionType:        transaction.TransactionType,
+	}
+	meta := &converted.PaymentMeta
+	meta.ByOrderOf = transaction.PaymentMeta.ByOrderOf
+	meta.Payee = transaction.PaymentMeta.Payee
+	meta.Payer = transaction.PaymentMeta.Payer
+	meta.PaymentMethod = transaction.PaymentMeta.PaymentMethod
+	meta.PaymentProcessor = transaction.PaymentMeta.PaymentProcessor
+	meta.PpdID = transaction.PaymentMeta.PpdID
+	meta.Reason = transaction.PaymentMeta.Reason
+	meta.ReferenceNumber = transaction.PaymentMeta.ReferenceNumber
+	return converted
+}
+
+// FromTransactions converts transactions of the plaid package.
+func FromTransactions(transactions []plaid.Transaction) []Transaction {
+	converted := make([]Transaction, len(transactions))
+	for i, transaction := range transactions {
+		converted[i] = FromTransaction(transaction)
+	}
+	return converted
+}
+
+// ToTransactions converts transactions to the plaid package's Transaction.
+func ToTransactions(transactions []Transaction) []plaid.Transaction {
+	converted := make([]plaid.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		converted[i] = ToTransaction(transaction)
+	}
+	return converted
+}
+
+// FromLocation converts a location of the plaid package, taking State and Zip from Region
+// and PostalCode if the location came from a newer version.
+func FromLocation(location plaid.Location) Location {
+	converted := Location{
+		Address:     location.Address,
+		City:        location.City,
+		State:       location.State,
+		Zip:         location.Zip,
+		Lat:         location.Lat,
+		Lon:         location.Lon,
+		StoreNumber: location.StoreNumber,
+	}
+	if converted.State == "" {
+		converted.State = location.Region
+	}
+	if converted.Zip == "" {
+		converted.Zip = location.PostalCode
+	}
+	return converted
+}
+
+// ToLocation converts a location to the plaid package's Location, setting both the old and
+// the new names of the renamed fields.
+func ToLocation(location Location) plaid.Location {
+	return plaid.Location{
+		Address:     location.Address,
+		City:        location.City,
+		Region:      location.State,
+		PostalCode:  location.Zip,
+		Lat:         location.Lat,
+		Lon:         location.Lon,
+		StoreNumber: location.StoreNumber,
+		Zip:         location.Zip,
+		State:       location.State,
+	}
+}
@@ -0,0 +1,54 @@
+package plaid
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wearevest/plaidgo/plaid/webhooks"
+)
+
+// WebhookVerificationKey is a key Plaid signs webhooks with, see webhooks.Key.
+type WebhookVerificationKey = webhooks.Key
+
+// WebhookVerificationKeyGet (POST /webhook_verification_key/get) returns the key with the
+// given key id, taken from the header of a webhook's Plaid-Verification JWT.
+//
+// See https://plaid.com/docs/api/webhooks/webhook-verification/#webhook_verification_keyget.
+func (c *Client) WebhookVerificationKeyGet(keyID string) (*WebhookVerificationKey, error) {
+	return c.WebhookVerificationKeyGetContext(context.Background(), keyID)
+}
+
+// WebhookVerificationKeyGetContext is like WebhookVerificationKeyGet but carries a context.
+func (c *Client) WebhookVerificationKeyGetContext(ctx context.Context,
+	keyID string) (*WebhookVerificationKey, error) {
+
+	if keyID == "" {
+		return nil, errors.New("/webhook_verification_key/get - key id must be specified")
+	}
+	var res struct {
+		Key       WebhookVerificationKey `json:"key"`
+		RequestID string                 `json:"request_id"`
+	}
+	creds := c.creds.Load()
+	err := c.postAndDecode(ctx, "/webhook_verification_key/get", webhookVerificationKeyJson{
+		ClientID: creds.clientID,
+		Secret:   creds.secret,
+		KeyID:    keyID,
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Key, nil
+}
+
+// WebhookKeySource returns a webhooks.KeySource fetching keys through the client, for use
+// with webhooks.NewVerifier.
+func (c *Client) WebhookKeySource() webhooks.KeySource {
+	return c.WebhookVerificationKeyGetContext
+}
+
+type webhookVerificationKeyJson struct {
+	ClientID string `json:"client_id"`
+	Secret   string `json:"secret"`
+	KeyID    string `json:"key_id"`
+}
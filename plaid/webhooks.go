@@ -0,0 +1,28 @@
+package plaid
+
+import (
+	"github.com/wearevest/plaidgo/plaid/webhooks"
+)
+
+// The webhook types live in package webhooks, see there for their documentation.
+type (
+	Webhook                    = webhooks.Webhook
+	ItemWebhook                = webhooks.ItemWebhook
+	TransactionsWebhook        = webhooks.TransactionsWebhook
+	AuthVerificationWebhook    = webhooks.AuthVerificationWebhook
+	LinkSessionFinishedWebhook = webhooks.LinkSessionFinishedWebhook
+	GenericWebhook             = webhooks.GenericWebhook
+	UnknownWebhookError        = webhooks.UnknownWebhookError
+)
+
+// ParseWebhook decodes a webhook body into the type matching its webhook_type and
+// webhook_code. It is equivalent to webhooks.Parse.
+func ParseWebhook(body []byte) (interface{}, error) {
+	return webhooks.Parse(body)
+}
+
+// ParseWebhookStrict is like ParseWebhook but returns an *UnknownWebhookError for webhooks
+// this package doesn't model. It is equivalent to webhooks.ParseStrict.
+func ParseWebhookStrict(body []byte) (interface{}, error) {
+	return webhooks.ParseStrict(body)
+}
@@ -0,0 +1,198 @@
+package webhooks
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+)
+
+// PlaidSourceRanges are the addresses Plaid documents sending webhooks from. They change
+// rarely; an Allowlist can be refreshed from a RangeSource to pick up changes without a
+// release.
+//
+// See https://plaid.com/docs/api/webhooks/#configuring-webhooks.
+var PlaidSourceRanges = []string{
+	"52.21.26.131/32",
+	"52.21.47.157/32",
+	"52.41.247.19/32",
+	"52.88.82.239/32",
+}
+
+// RangeSource fetches the current webhook source ranges, as CIDR prefixes or single
+// addresses.
+type RangeSource func(ctx context.Context) ([]string, error)
+
+// URLRangeSource returns a RangeSource that fetches a text file from url with one range
+// per line, e.g. a copy of Plaid's list kept by the application's operators. Blank lines
+// and lines starting with # are skipped. client defaults to http.DefaultClient.
+func URLRangeSource(client *http.Client, url string) RangeSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) ([]string, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("can't fetch webhook source ranges from %s: status %d", url, res.StatusCode)
+		}
+		var ranges []string
+		scanner := bufio.NewScanner(io.LimitReader(res.Body, 1<<20))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line != "" && !strings.HasPrefix(line, "#") {
+				ranges = append(ranges, line)
+			}
+		}
+		return ranges, scanner.Err()
+	}
+}
+
+// Allowlist admits webhooks only from Plaid's source addresses. It is defense in depth
+// next to a Verifier, not a replacement for it: addresses can be shared by other tenants
+// of Plaid's cloud provider, while only Plaid can sign a webhook.
+//
+// Behind a load balancer or reverse proxy, set TrustedProxies so that the client address
+// is taken from the X-Forwarded-For header the proxies append to.
+type Allowlist struct {
+	// Source, if set, is what Refresh fetches the ranges from.
+	Source RangeSource
+	// TrustedProxies are the ranges of the proxies in front of the receiver.
+	TrustedProxies []netip.Prefix
+
+	mu     sync.RWMutex
+	ranges []netip.Prefix
+}
+
+// NewAllowlist instantiates an Allowlist of PlaidSourceRanges that refreshes from source,
+// which may be nil.
+func NewAllowlist(source RangeSource) *Allowlist {
+	ranges, err := parseRanges(PlaidSourceRanges)
+	if err != nil {
+		panic(err)
+	}
+	return &Allowlist{Source: source, ranges: ranges}
+}
+
+// Refresh replaces the ranges with those fetched from Source. The ranges are left
+// unchanged if fetching them fails or returns none, so that an outage of the source
+// doesn't lock Plaid out. Call it periodically, e.g. daily.
+func (a *Allowlist) Refresh(ctx context.Context) error {
+	if a.Source == nil {
+		return errors.New("webhook allowlist has no source to refresh from")
+	}
+	fetched, err := a.Source(ctx)
+	if err != nil {
+		return err
+	}
+	if len(fetched) == 0 {
+		return errors.New("webhook allowlist source returned no ranges")
+	}
+	ranges, err := parseRanges(fetched)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.ranges = ranges
+	a.mu.Unlock()
+	return nil
+}
+
+// Ranges returns the ranges webhooks are currently admitted from.
+func (a *Allowlist) Ranges() []netip.Prefix {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return append([]netip.Prefix(nil), a.ranges...)
+}
+
+// Allows reports whether addr is in one of the ranges.
+func (a *Allowlist) Allows(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, prefix := range a.ranges {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRequest reports whether req was sent from one of the ranges.
+func (a *Allowlist) AllowsRequest(req *http.Request) bool {
+	addr, ok := a.clientAddr(req)
+	return ok && a.Allows(addr)
+}
+
+// clientAddr returns the address req was sent from: its remote address or, if that is a
+// trusted proxy, the right-most address of X-Forwarded-For that isn't one.
+func (a *Allowlist) clientAddr(req *http.Request) (netip.Addr, bool) {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	if !a.trusted(addr) {
+		return addr.Unmap(), true
+	}
+	var hops []string
+	for _, header := range req.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(header, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			return netip.Addr{}, false
+		}
+		if !a.trusted(hop) {
+			return hop.Unmap(), true
+		}
+	}
+	return netip.Addr{}, false
+}
+
+func (a *Allowlist) trusted(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range a.TrustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRanges(ranges []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(ranges))
+	for _, r := range ranges {
+		if !strings.Contains(r, "/") {
+			addr, err := netip.ParseAddr(r)
+			if err != nil {
+				return nil, fmt.Errorf("invalid webhook source range %q: %w", r, err)
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook source range %q: %w", r, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
@@ -0,0 +1,240 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// Envelope is the format a webhook is enqueued in by an edge service that receives it from
+// Plaid, so that it can still be verified by the consumer: the body exactly as received and
+// the Plaid-Verification header.
+type Envelope struct {
+	Body         json.RawMessage `json:"body"`
+	Verification string          `json:"plaid_verification,omitempty"`
+	ReceivedAt   time.Time       `json:"received_at"`
+}
+
+// NewEnvelope returns the envelope of a webhook request whose body was read into body,
+// received at the time of clock, or of the system clock if clock is nil.
+func NewEnvelope(req *http.Request, body []byte, clock core.Clock) Envelope {
+	return Envelope{Body: body, Verification: req.Header.Get(VerificationHeader),
+		ReceivedAt: core.ClockOr(clock).Now()}
+}
+
+// Delivery is a message received from a queue.
+type Delivery struct {
+	// Body is an encoded Envelope.
+	Body []byte
+	// Ack removes the message from the queue once it was handled, and Nack makes the queue
+	// redeliver it, e.g. by resetting an SQS message's visibility timeout. Either may be nil
+	// for queues without the operation.
+	Ack  func(ctx context.Context) error
+	Nack func(ctx context.Context) error
+}
+
+// Source receives webhook deliveries from a queue. Receive blocks until at least one
+// delivery is available or ctx is done.
+//
+// An Amazon SQS source is a thin wrapper around ReceiveMessage:
+//
+//	func (s *sqsSource) Receive(ctx context.Context) ([]*webhooks.Delivery, error) {
+//		out, err := s.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+//			QueueUrl: &s.queueURL, MaxNumberOfMessages: 10, WaitTimeSeconds: 20,
+//		})
+//		if err != nil {
+//			return nil, err
+//		}
+//		deliveries := make([]*webhooks.Delivery, len(out.Messages))
+//		for i, m := range out.Messages {
+//			handle := m.ReceiptHandle
+//			deliveries[i] = &webhooks.Delivery{
+//				Body: []byte(*m.Body),
+//				Ack: func(ctx context.Context) error {
+//					_, err := s.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{QueueUrl: &s.queueURL, ReceiptHandle: handle})
+//					return err
+//				},
+//			}
+//		}
+//		return deliveries, nil
+//	}
+//
+// Google Cloud Pub/Sub pushes messages to a callback instead; ChannelSource adapts it:
+//
+//	source := webhooks.NewChannelSource(100)
+//	go subscription.Receive(ctx, func(ctx context.Context, m *pubsub.Message) {
+//		source.Push(ctx, &webhooks.Delivery{Body: m.Data,
+//			Ack:  func(context.Context) error { m.Ack(); return nil },
+//			Nack: func(context.Context) error { m.Nack(); return nil }})
+//	})
+type Source interface {
+	Receive(ctx context.Context) ([]*Delivery, error)
+}
+
+// ChannelSource is a Source fed by Push, for queue clients that deliver messages to a
+// callback.
+type ChannelSource struct {
+	deliveries chan *Delivery
+}
+
+// NewChannelSource instantiates a ChannelSource buffering up to buffer deliveries.
+func NewChannelSource(buffer int) *ChannelSource {
+	return &ChannelSource{deliveries: make(chan *Delivery, buffer)}
+}
+
+// Push queues a delivery, blocking while the buffer is full or until ctx is done.
+func (s *ChannelSource) Push(ctx context.Context, delivery *Delivery) error {
+	select {
+	case s.deliveries <- delivery:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Receive implements Source.
+func (s *ChannelSource) Receive(ctx context.Context) ([]*Delivery, error) {
+	select {
+	case delivery := <-s.deliveries:
+		return []*Delivery{delivery}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Consumer feeds webhooks received from a queue to a Router, for architectures where an
+// edge service enqueues webhooks rather than processing them inline. Deliveries are
+// verified with the Router's Verifier, if set, against the envelope's Plaid-Verification
+// header; verification then enforces the Verifier's MaxAge relative to when the webhook
+// was signed, so it should cover the queue's expected delay.
+//
+// A delivery is acked once the Router handled or dead-lettered it, and nacked otherwise, so
+// that the queue redelivers it. Deliveries that can never succeed, because they are
+// malformed or fail verification, are dead-lettered too; without an OnDeadLetter hook they
+// are acked and reported to OnError.
+type Consumer struct {
+	Source Source
+	Router *Router
+	// Concurrency is the number of deliveries handled at once. Defaults to 1.
+	Concurrency int
+	// OnError receives the errors of deliveries and of the source.
+	OnError func(err error)
+	// ReceiveBackoff is the pause after the source failed, timed by the Router's Clock.
+	// Defaults to 1 second.
+	ReceiveBackoff time.Duration
+}
+
+// Run consumes deliveries until ctx is done, lets in-flight deliveries finish and then
+// returns ctx.Err().
+func (c *Consumer) Run(ctx context.Context) error {
+	concurrency := c.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	deliveries := make(chan *Delivery)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range deliveries {
+				c.handle(context.WithoutCancel(ctx), delivery)
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(deliveries)
+
+	for ctx.Err() == nil {
+		received, err := c.Source.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			c.reportError(err)
+			backoff := c.ReceiveBackoff
+			if backoff == 0 {
+				backoff = time.Second
+			}
+			select {
+			case <-ctx.Done():
+			case <-core.ClockOr(c.Router.Clock).After(backoff):
+			}
+			continue
+		}
+		for _, delivery := range received {
+			deliveries <- delivery
+		}
+	}
+	return ctx.Err()
+}
+
+// handle processes a single delivery and acks or nacks it.
+func (c *Consumer) handle(ctx context.Context, delivery *Delivery) {
+	err := c.process(ctx, delivery)
+	var permanent *permanentError
+	switch {
+	case err == nil:
+		c.settle(ctx, delivery.Ack)
+	case errors.As(err, &permanent):
+		c.reportError(permanent.err)
+		c.settle(ctx, delivery.Ack)
+	default:
+		c.reportError(err)
+		c.settle(ctx, delivery.Nack)
+	}
+}
+
+func (c *Consumer) process(ctx context.Context, delivery *Delivery) error {
+	var envelope Envelope
+	if err := json.Unmarshal(delivery.Body, &envelope); err != nil {
+		return c.Router.deadLetter(ctx, DeadLetter{Body: delivery.Body, Err: Permanent(err)})
+	}
+	if verifier := c.Router.Verifier; verifier != nil {
+		err := verifier.Verify(ctx, envelope.Verification, envelope.Body)
+		if errors.Is(err, ErrUnverified) {
+			return c.Router.deadLetter(ctx, DeadLetter{Body: envelope.Body, Err: Permanent(err)})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	webhook, err := Parse(envelope.Body)
+	if err != nil {
+		return c.Router.deadLetter(ctx, DeadLetter{Body: envelope.Body, Err: Permanent(err)})
+	}
+	return c.Router.deliver(ctx, envelope.Body, webhook)
+}
+
+func (c *Consumer) settle(ctx context.Context, settle func(ctx context.Context) error) {
+	if settle == nil {
+		return
+	}
+	if err := settle(ctx); err != nil {
+		c.reportError(err)
+	}
+}
+
+func (c *Consumer) reportError(err error) {
+	if c.OnError != nil {
+		c.OnError(err)
+	}
+}
+
+// permanentError marks an error of a delivery that would fail again if redelivered.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
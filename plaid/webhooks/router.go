@@ -0,0 +1,287 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// Handler handles a webhook as decoded by Parse, e.g. an *ItemWebhook.
+type Handler func(ctx context.Context, webhook interface{}) error
+
+// Router dispatches webhooks to the handlers registered for their type and code. Webhooks
+// without a handler are passed to the fallback handler, if any, and otherwise ignored.
+//
+// A Router is an http.Handler: it reads the body, verifies it if a Verifier is set,
+// dispatches it and responds 200 once the handler returned, or 500 if it failed so that
+// Plaid retries the delivery. Handlers should therefore be idempotent. A verified body that
+// isn't a webhook is dead-lettered like Dispatch does, or answered with 400 without
+// OnDeadLetter.
+//
+// Webhooks are acked, by responding 200 or acking a queue message, only once they were
+// handled or handed to OnDeadLetter. A failed handler is retried according to Retry, unless
+// its error was wrapped with Permanent; if it still fails, the webhook is passed to
+// OnDeadLetter, or, without one, nacked so that Plaid or the queue redelivers it.
+type Router struct {
+	// Verifier, if set, rejects webhooks whose Plaid-Verification header doesn't verify.
+	Verifier *Verifier
+	// Allowlist, if set, rejects webhooks posted from outside Plaid's source addresses.
+	Allowlist *Allowlist
+	// MaxBodyBytes limits the size of webhook bodies. Defaults to 1 MiB.
+	MaxBodyBytes int64
+	// Retry is how often a failed handler is retried in place before the webhook is
+	// dead-lettered or nacked.
+	Retry RetryPolicy
+	// OnDeadLetter, if set, receives the webhooks whose handling failed for good, e.g. to
+	// persist them for inspection and replay. The webhook is acked once it returned nil.
+	OnDeadLetter func(ctx context.Context, letter DeadLetter) error
+	// Clock times the backoff between retries. Defaults to the system clock; pass the
+	// clock given to the plaid Client with WithClock, if any.
+	Clock core.Clock
+
+	mu       sync.RWMutex
+	handlers map[string]Handler // "type" or "type/code" -> handler
+	fallback Handler
+}
+
+// RetryPolicy configures the retries of a failed handler.
+type RetryPolicy struct {
+	// Attempts is the number of times a handler is called. Defaults to 1, i.e. no retries.
+	Attempts int
+	// Backoff is the pause before the first retry, doubled before each further one.
+	Backoff time.Duration
+}
+
+// DeadLetter is a webhook whose handling failed for good.
+type DeadLetter struct {
+	// Body is the webhook body as received.
+	Body []byte
+	// Webhook is the webhook as decoded by Parse, or nil if it couldn't be decoded.
+	Webhook interface{}
+	// Err is the error of the last attempt.
+	Err error
+	// Attempts is the number of times the handler was called.
+	Attempts int
+}
+
+// Permanent wraps the error of a handler that would fail again if retried, e.g. because the
+// webhook refers to an item that no longer exists. Such webhooks are dead-lettered without
+// retries.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// NewRouter instantiates a Router without handlers.
+func NewRouter() *Router {
+	return &Router{handlers: map[string]Handler{}}
+}
+
+// Handle registers handler for webhooks of webhookType and webhookCode. An empty
+// webhookCode handles every code of the type that has no handler of its own.
+func (r *Router) Handle(webhookType, webhookCode string, handler Handler) {
+	key := webhookType
+	if webhookCode != "" {
+		key += "/" + webhookCode
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[key] = handler
+}
+
+// HandleFallback registers handler for webhooks no other handler is registered for.
+func (r *Router) HandleFallback(handler Handler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fallback = handler
+}
+
+// OnItem registers handler for every ITEM webhook. Like the other typed registrations it
+// leaves webhooks of codes this package doesn't model to the fallback handler.
+func (r *Router) OnItem(handler func(ctx context.Context, webhook *ItemWebhook) error) {
+	r.Handle("ITEM", "", func(ctx context.Context, webhook interface{}) error {
+		if w, ok := webhook.(*ItemWebhook); ok {
+			return handler(ctx, w)
+		}
+		return r.unhandled(ctx, webhook)
+	})
+}
+
+// OnTransactions registers handler for every TRANSACTIONS webhook.
+func (r *Router) OnTransactions(handler func(ctx context.Context, webhook *TransactionsWebhook) error) {
+	r.Handle("TRANSACTIONS", "", func(ctx context.Context, webhook interface{}) error {
+		if w, ok := webhook.(*TransactionsWebhook); ok {
+			return handler(ctx, w)
+		}
+		return r.unhandled(ctx, webhook)
+	})
+}
+
+// OnAuthVerification registers handler for every AUTH webhook.
+func (r *Router) OnAuthVerification(handler func(ctx context.Context, webhook *AuthVerificationWebhook) error) {
+	r.Handle("AUTH", "", func(ctx context.Context, webhook interface{}) error {
+		if w, ok := webhook.(*AuthVerificationWebhook); ok {
+			return handler(ctx, w)
+		}
+		return r.unhandled(ctx, webhook)
+	})
+}
+
+// OnLinkSessionFinished registers handler for SESSION_FINISHED webhooks.
+func (r *Router) OnLinkSessionFinished(handler func(ctx context.Context, webhook *LinkSessionFinishedWebhook) error) {
+	r.Handle("LINK", "SESSION_FINISHED", func(ctx context.Context, webhook interface{}) error {
+		if w, ok := webhook.(*LinkSessionFinishedWebhook); ok {
+			return handler(ctx, w)
+		}
+		return r.unhandled(ctx, webhook)
+	})
+}
+
+// Dispatch decodes a webhook body and passes it to its handler, retrying and dead-lettering
+// it as configured. It returns nil if the webhook may be acked. Webhooks this package
+// doesn't model reach handlers as a *GenericWebhook.
+func (r *Router) Dispatch(ctx context.Context, body []byte) error {
+	webhook, err := Parse(body)
+	if err != nil {
+		return r.deadLetter(ctx, DeadLetter{Body: body, Err: Permanent(err)})
+	}
+	return r.deliver(ctx, body, webhook)
+}
+
+// deliver dispatches a webhook returned by Parse, retrying and dead-lettering it as
+// configured.
+func (r *Router) deliver(ctx context.Context, body []byte, webhook interface{}) error {
+	attempts := r.Retry.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := r.Retry.Backoff
+	letter := DeadLetter{Body: body, Webhook: webhook}
+	for {
+		letter.Attempts++
+		letter.Err = r.dispatch(ctx, webhook)
+		var permanent *permanentError
+		if letter.Err == nil {
+			return nil
+		}
+		if errors.As(letter.Err, &permanent) || letter.Attempts >= attempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return letter.Err
+		case <-core.ClockOr(r.Clock).After(backoff):
+		}
+		backoff *= 2
+	}
+	return r.deadLetter(ctx, letter)
+}
+
+// deadLetter passes a failed webhook to OnDeadLetter, returning nil if it took it, and
+// otherwise the webhook's error.
+func (r *Router) deadLetter(ctx context.Context, letter DeadLetter) error {
+	if r.OnDeadLetter == nil {
+		return letter.Err
+	}
+	return r.OnDeadLetter(ctx, letter)
+}
+
+// dispatch passes a webhook returned by Parse to its handler.
+func (r *Router) dispatch(ctx context.Context, webhook interface{}) error {
+	base := baseOf(webhook)
+	r.mu.RLock()
+	handler, ok := r.handlers[base.WebhookType+"/"+base.WebhookCode]
+	if !ok {
+		handler, ok = r.handlers[base.WebhookType]
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return r.unhandled(ctx, webhook)
+	}
+	return handler(ctx, webhook)
+}
+
+// unhandled passes a webhook without a handler to the fallback handler, if any. This
+// includes webhooks of a code this package doesn't model, which reach the typed handlers
+// of their type as a *GenericWebhook.
+func (r *Router) unhandled(ctx context.Context, webhook interface{}) error {
+	r.mu.RLock()
+	fallback := r.fallback
+	r.mu.RUnlock()
+	if fallback == nil {
+		return nil
+	}
+	return fallback(ctx, webhook)
+}
+
+// ServeHTTP implements http.Handler.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Allowlist != nil && !r.Allowlist.AllowsRequest(req) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	limit := r.MaxBodyBytes
+	if limit <= 0 {
+		limit = 1 << 20
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, limit))
+	if err != nil {
+		http.Error(w, "unreadable body", http.StatusBadRequest)
+		return
+	}
+	if r.Verifier != nil {
+		err = r.Verifier.Verify(req.Context(), req.Header.Get(VerificationHeader), body)
+		if errors.Is(err, ErrUnverified) {
+			http.Error(w, "unverified", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			// The key couldn't be fetched; let Plaid retry.
+			http.Error(w, "verification unavailable", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	webhook, err := Parse(body)
+	if err != nil && r.OnDeadLetter == nil {
+		http.Error(w, "malformed webhook", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		err = r.deadLetter(req.Context(), DeadLetter{Body: body, Err: Permanent(err)})
+	} else {
+		err = r.deliver(req.Context(), body, webhook)
+	}
+	if err != nil {
+		http.Error(w, "handler failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// baseOf returns the fields common to every webhook of a webhook returned by Parse.
+func baseOf(webhook interface{}) Webhook {
+	switch w := webhook.(type) {
+	case *ItemWebhook:
+		return w.Webhook
+	case *TransactionsWebhook:
+		return w.Webhook
+	case *AuthVerificationWebhook:
+		return w.Webhook
+	case *LinkSessionFinishedWebhook:
+		return w.Webhook
+	case *GenericWebhook:
+		return w.Webhook
+	}
+	return Webhook{}
+}
@@ -0,0 +1,84 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// instantClock is a core.Clock whose After fires at once and records the durations waited.
+type instantClock struct {
+	waited []time.Duration
+}
+
+func (c *instantClock) Now() time.Time {
+	return time.Time{}
+}
+
+func (c *instantClock) After(d time.Duration) <-chan time.Time {
+	c.waited = append(c.waited, d)
+	fired := make(chan time.Time, 1)
+	fired <- time.Time{}
+	return fired
+}
+
+func TestRouterRetryClock(t *testing.T) {
+	clock := &instantClock{}
+	r := NewRouter()
+	r.Retry = RetryPolicy{Attempts: 3, Backoff: time.Hour}
+	r.Clock = clock
+	attempts := 0
+	r.HandleFallback(func(ctx context.Context, webhook interface{}) error {
+		attempts++
+		return errors.New("boom")
+	})
+
+	err := r.Dispatch(context.Background(), []byte(`{"webhook_type": "ITEM", "webhook_code": "ERROR"}`))
+	if err == nil || attempts != 3 {
+		t.Fatalf("Dispatch = %v after %d attempts, want the handler's error after 3", err, attempts)
+	}
+	if want := fmt.Sprint([]time.Duration{time.Hour, 2 * time.Hour}); fmt.Sprint(clock.waited) != want {
+		t.Errorf("waited %v between attempts, want %v", clock.waited, want)
+	}
+}
+
+func TestRouterServeHTTPMalformed(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		deadLetter func(ctx context.Context, letter DeadLetter) error
+		want       int
+	}{
+		{"without dead letters", nil, http.StatusBadRequest},
+		{"dead-lettered", func(ctx context.Context, letter DeadLetter) error { return nil }, http.StatusOK},
+		{"dead letter failed", func(ctx context.Context, letter DeadLetter) error { return errors.New("boom") },
+			http.StatusInternalServerError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var letters []DeadLetter
+			r := NewRouter()
+			if tc.deadLetter != nil {
+				r.OnDeadLetter = func(ctx context.Context, letter DeadLetter) error {
+					letters = append(letters, letter)
+					return tc.deadLetter(ctx, letter)
+				}
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader("not a webhook")))
+			if w.Code != tc.want {
+				t.Errorf("ServeHTTP responded %d, want %d", w.Code, tc.want)
+			}
+			if tc.deadLetter == nil {
+				return
+			}
+			var permanent *permanentError
+			if len(letters) != 1 || string(letters[0].Body) != "not a webhook" || !errors.As(letters[0].Err, &permanent) {
+				t.Errorf("dead letters %+v, want the body with a permanent error", letters)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Server is a ready to run webhook receiver: an HTTP server that passes webhooks posted to
+// Path to its Router and answers health checks on HealthPath.
+//
+//	router := webhooks.NewRouter()
+//	router.Verifier = webhooks.NewVerifier(client.WebhookKeySource())
+//	router.OnTransactions(handleTransactions)
+//	server := &webhooks.Server{Addr: ":8443", Router: router, CertFile: "cert.pem", KeyFile: "key.pem"}
+//	err := server.ListenAndServe(ctx)
+type Server struct {
+	// Addr is the address to listen on, e.g. ":8443".
+	Addr   string
+	Router *Router
+	// Path is where webhooks are posted to. Defaults to "/plaid/webhook".
+	Path string
+	// HealthPath answers GET requests with 200 while the server runs. Defaults to
+	// "/healthz".
+	HealthPath string
+	// CertFile and KeyFile, if set, make the server serve TLS.
+	CertFile string
+	KeyFile  string
+	// ShutdownTimeout is how long in-flight webhooks may take to finish once the server is
+	// shutting down. Defaults to 10 seconds.
+	ShutdownTimeout time.Duration
+}
+
+// Handler returns the server's routes as an http.Handler, for mounting them in an existing
+// server instead of running ListenAndServe.
+func (s *Server) Handler() http.Handler {
+	path, healthPath := s.Path, s.HealthPath
+	if path == "" {
+		path = "/plaid/webhook"
+	}
+	if healthPath == "" {
+		healthPath = "/healthz"
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, s.Router)
+	mux.HandleFunc(healthPath, func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+// ListenAndServe serves webhooks until ctx is done and then shuts down gracefully, letting
+// in-flight webhooks finish for up to ShutdownTimeout. It returns nil after a shutdown
+// caused by ctx, and otherwise the error that stopped the server.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	served := make(chan error, 1)
+	go func() {
+		if s.CertFile != "" || s.KeyFile != "" {
+			served <- server.ListenAndServeTLS(s.CertFile, s.KeyFile)
+		} else {
+			served <- server.ListenAndServe()
+		}
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+	timeout := s.ShutdownTimeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
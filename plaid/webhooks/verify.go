@@ -0,0 +1,270 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// VerificationHeader is the header carrying the JWT that signs a webhook.
+const VerificationHeader = "Plaid-Verification"
+
+const (
+	// DefaultMaxAge is how old a webhook's signature may be before a Verifier rejects it.
+	DefaultMaxAge = 5 * time.Minute
+	// DefaultClockSkew is how far in the future a webhook's signature may be issued before a
+	// Verifier rejects it.
+	DefaultClockSkew = time.Minute
+	// DefaultKeyTTL is how long a Verifier uses a key before fetching it again to check that
+	// it hasn't expired.
+	DefaultKeyTTL = time.Hour
+	// DefaultUnknownKeyTTL is how long a Verifier rejects a key id whose key couldn't be
+	// fetched before trying to fetch it again.
+	DefaultUnknownKeyTTL = time.Minute
+	// DefaultKeyCacheSize is the number of key ids a Verifier caches keys or failures for.
+	DefaultKeyCacheSize = 100
+)
+
+// ErrUnverified is returned, wrapped, for webhooks whose signature doesn't verify.
+var ErrUnverified = errors.New("webhook verification failed")
+
+// Key is a JSON Web Key Plaid signs webhooks with.
+//
+// See https://plaid.com/docs/api/webhooks/webhook-verification/#webhook_verification_keyget.
+type Key struct {
+	Alg       string `json:"alg"`
+	Crv       string `json:"crv"`
+	Kid       string `json:"kid"`
+	Kty       string `json:"kty"`
+	Use       string `json:"use"`
+	X         string `json:"x"`
+	Y         string `json:"y"`
+	CreatedAt int64  `json:"created_at"`
+	// ExpiredAt is the unix time the key expired, or zero if it is current.
+	ExpiredAt int64 `json:"expired_at"`
+}
+
+// KeySource fetches the key with the given key id, e.g. from Plaid's
+// /webhook_verification_key/get endpoint. plaid.Client.WebhookKeySource returns one.
+type KeySource func(ctx context.Context, keyID string) (*Key, error)
+
+// Verifier checks that webhooks were sent by Plaid: their Plaid-Verification header must
+// hold a JWT signed with ES256 by a current Plaid key, issued within MaxAge, whose
+// request_body_sha256 claim matches the body.
+//
+// Keys are cached by key id, so a Verifier should be long-lived. A cached key is fetched
+// again after KeyTTL, so that a key Plaid expired stops verifying webhooks. A key id whose
+// key couldn't be fetched, or was expired or malformed, is rejected without fetching it
+// again for UnknownKeyTTL, so that webhooks with made-up key ids can't make the Verifier
+// call Plaid for every request. Keys are fetched one at a time, and at most KeyCacheSize key
+// ids are cached, dropping the least recently used.
+type Verifier struct {
+	keys KeySource
+	// MaxAge defaults to DefaultMaxAge.
+	MaxAge time.Duration
+	// ClockSkew defaults to DefaultClockSkew.
+	ClockSkew time.Duration
+	// KeyTTL defaults to DefaultKeyTTL.
+	KeyTTL time.Duration
+	// UnknownKeyTTL defaults to DefaultUnknownKeyTTL.
+	UnknownKeyTTL time.Duration
+	// KeyCacheSize defaults to DefaultKeyCacheSize. It is read when the first webhook is
+	// verified.
+	KeyCacheSize int
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+
+	fetch     sync.Mutex // held while fetching a key
+	cacheOnce sync.Once
+	cache     *core.LRU[cachedKey]
+}
+
+// cachedKey is a fetched key, or the error fetching it, until the time it is fetched again.
+type cachedKey struct {
+	key     *ecdsa.PublicKey
+	err     error
+	expires time.Time
+}
+
+// NewVerifier instantiates a Verifier that fetches keys from keys.
+func NewVerifier(keys KeySource) *Verifier {
+	return &Verifier{keys: keys}
+}
+
+// CacheStats returns the usage of the key cache.
+func (v *Verifier) CacheStats() core.CacheStats {
+	return v.keyCache().Stats()
+}
+
+func (v *Verifier) keyCache() *core.LRU[cachedKey] {
+	v.cacheOnce.Do(func() {
+		size := v.KeyCacheSize
+		if size <= 0 {
+			size = DefaultKeyCacheSize
+		}
+		v.cache = core.NewLRU[cachedKey]("webhook_keys", size, 0)
+	})
+	return v.cache
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	IssuedAt          int64  `json:"iat"`
+	RequestBodySHA256 string `json:"request_body_sha256"`
+}
+
+// Verify checks the JWT of a webhook's Plaid-Verification header against its body.
+func (v *Verifier) Verify(ctx context.Context, token string, body []byte) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return unverified("malformed token")
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return unverified("malformed token header")
+	}
+	if header.Alg != "ES256" {
+		return unverified("unexpected algorithm " + header.Alg)
+	}
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	key, err := v.key(ctx, header.Kid, now())
+	if err != nil {
+		return err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		return unverified("malformed signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(key, digest[:], r, s) {
+		return unverified("invalid signature")
+	}
+
+	var claims jwtClaims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return unverified("malformed token claims")
+	}
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if now().Sub(issuedAt) > orDefault(v.MaxAge, DefaultMaxAge) {
+		return unverified("token is too old")
+	}
+	if issuedAt.Sub(now()) > orDefault(v.ClockSkew, DefaultClockSkew) {
+		return unverified("token is issued in the future")
+	}
+	bodyHash := sha256.Sum256(body)
+	if subtle.ConstantTimeCompare([]byte(hex.EncodeToString(bodyHash[:])), []byte(claims.RequestBodySHA256)) != 1 {
+		return unverified("body doesn't match token")
+	}
+	return nil
+}
+
+// key returns the public key with the given id, fetching it unless it is cached.
+func (v *Verifier) key(ctx context.Context, keyID string, now time.Time) (*ecdsa.PublicKey, error) {
+	if cached, ok := v.cached(keyID, now); ok {
+		return cached.key, cached.err
+	}
+	v.fetch.Lock()
+	defer v.fetch.Unlock()
+	// The key may have been fetched while waiting for the lock.
+	if cached, ok := v.cached(keyID, now); ok {
+		return cached.key, cached.err
+	}
+	key, err := v.fetchKey(ctx, keyID)
+	if err != nil && ctx.Err() != nil {
+		// Don't hold the caller's cancellation against the key id.
+		return nil, err
+	}
+	cached := cachedKey{key: key, err: err, expires: now.Add(orDefault(v.KeyTTL, DefaultKeyTTL))}
+	if err != nil {
+		cached.expires = now.Add(orDefault(v.UnknownKeyTTL, DefaultUnknownKeyTTL))
+	}
+	v.keyCache().Add(keyID, cached, now)
+	return key, err
+}
+
+// cached returns the cached key or error for a key id, unless there is none or it is due to
+// be fetched again.
+func (v *Verifier) cached(keyID string, now time.Time) (cachedKey, bool) {
+	cached, ok := v.keyCache().Get(keyID, now)
+	if ok && !now.Before(cached.expires) {
+		v.keyCache().Remove(keyID)
+		return cachedKey{}, false
+	}
+	return cached, ok
+}
+
+// fetchKey fetches and parses the key with the given id, rejecting expired keys.
+func (v *Verifier) fetchKey(ctx context.Context, keyID string) (*ecdsa.PublicKey, error) {
+	jwk, err := v.keys(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if jwk.ExpiredAt != 0 {
+		return nil, unverified("key " + keyID + " has expired")
+	}
+	if jwk.Kty != "EC" || jwk.Crv != "P-256" {
+		return nil, unverified("unexpected key type " + jwk.Kty + " " + jwk.Crv)
+	}
+	x, errX := base64.RawURLEncoding.DecodeString(jwk.X)
+	y, errY := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+		return nil, unverified("malformed key " + keyID)
+	}
+	key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+	if err != nil {
+		return nil, unverified("malformed key " + keyID)
+	}
+	return key, nil
+}
+
+func orDefault(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
+
+func unverified(reason string) error {
+	return &verificationError{reason: reason}
+}
+
+type verificationError struct {
+	reason string
+}
+
+func (e *verificationError) Error() string {
+	return ErrUnverified.Error() + ": " + e.reason
+}
+
+func (e *verificationError) Unwrap() error {
+	return ErrUnverified
+}
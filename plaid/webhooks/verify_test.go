@@ -0,0 +1,155 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testKey signs webhooks like Plaid and serves its public key as a KeySource.
+type testKey struct {
+	private   *ecdsa.PrivateKey
+	expiredAt int64
+	fetches   int
+}
+
+func newTestKey(t *testing.T) *testKey {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKey{private: private}
+}
+
+func (k *testKey) source(ctx context.Context, keyID string) (*Key, error) {
+	k.fetches++
+	if keyID != "k1" {
+		return nil, errors.New("unknown key id " + keyID)
+	}
+	return &Key{
+		Alg: "ES256", Crv: "P-256", Kid: keyID, Kty: "EC", Use: "sig",
+		X:         base64.RawURLEncoding.EncodeToString(k.private.X.FillBytes(make([]byte, 32))),
+		Y:         base64.RawURLEncoding.EncodeToString(k.private.Y.FillBytes(make([]byte, 32))),
+		ExpiredAt: k.expiredAt,
+	}, nil
+}
+
+func (k *testKey) sign(t *testing.T, keyID string, issuedAt time.Time, body []byte) string {
+	encode := func(v interface{}) string {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+	bodyHash := sha256.Sum256(body)
+	signed := encode(jwtHeader{Alg: "ES256", Kid: keyID}) + "." +
+		encode(jwtClaims{IssuedAt: issuedAt.Unix(), RequestBodySHA256: hex.EncodeToString(bodyHash[:])})
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, k.private, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"webhook_type": "ITEM", "webhook_code": "WEBHOOK_UPDATE_ACKNOWLEDGED"}`)
+	cases := []struct {
+		name     string
+		keyID    string
+		issuedAt time.Time
+		body     []byte
+		ok       bool
+	}{
+		{"valid", "k1", now.Add(-time.Minute), body, true},
+		{"too old", "k1", now.Add(-DefaultMaxAge - time.Second), body, false},
+		{"within clock skew", "k1", now.Add(DefaultClockSkew), body, true},
+		{"issued in the future", "k1", now.Add(DefaultClockSkew + time.Second), body, false},
+		{"other body", "k1", now, []byte(`{}`), false},
+		{"unknown key", "k2", now, body, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			key := newTestKey(t)
+			v := NewVerifier(key.source)
+			v.Now = func() time.Time { return now }
+			err := v.Verify(context.Background(), key.sign(t, c.keyID, c.issuedAt, body), c.body)
+			if (err == nil) != c.ok {
+				t.Errorf("Verify = %v, want ok %v", err, c.ok)
+			}
+		})
+	}
+}
+
+func TestVerifierKeyCache(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{}`)
+	key := newTestKey(t)
+	v := NewVerifier(key.source)
+	v.Now = func() time.Time { return now }
+	verify := func(keyID string) error {
+		return v.Verify(context.Background(), key.sign(t, keyID, now, body), body)
+	}
+
+	if err := verify("k1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := verify("k1"); err != nil || key.fetches != 1 {
+		t.Fatalf("Verify = %v after %d fetches, want the cached key", err, key.fetches)
+	}
+
+	// Unknown key ids are fetched once per UnknownKeyTTL.
+	for i := 0; i < 3; i++ {
+		if err := verify("k2"); err == nil {
+			t.Fatal("verified a webhook signed with an unknown key id")
+		}
+	}
+	if key.fetches != 2 {
+		t.Fatalf("fetched keys %d times, want unknown key ids to be cached", key.fetches)
+	}
+	now = now.Add(DefaultUnknownKeyTTL)
+	verify("k2")
+	if key.fetches != 3 {
+		t.Fatalf("fetched keys %d times, want the unknown key id to be fetched again", key.fetches)
+	}
+
+	// Once Plaid expires the key, it stops verifying webhooks after KeyTTL.
+	key.expiredAt = now.Unix()
+	if err := verify("k1"); err != nil {
+		t.Fatalf("Verify = %v, want the cached key to be used until KeyTTL", err)
+	}
+	now = now.Add(DefaultKeyTTL)
+	if err := verify("k1"); !errors.Is(err, ErrUnverified) {
+		t.Fatalf("Verify = %v, want the expired key to be rejected", err)
+	}
+}
+
+func TestVerifierKeyCacheSize(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{}`)
+	key := newTestKey(t)
+	v := NewVerifier(key.source)
+	v.Now = func() time.Time { return now }
+	v.KeyCacheSize = 2
+
+	// Made-up key ids evict each other rather than growing the cache.
+	for i := 0; i < 10; i++ {
+		v.Verify(context.Background(), key.sign(t, fmt.Sprint("unknown", i), now, body), body)
+	}
+	stats := v.CacheStats()
+	if stats.Size != 2 || stats.Evictions != 8 {
+		t.Fatalf("CacheStats = %+v, want 2 cached key ids and 8 evictions", stats)
+	}
+}
@@ -0,0 +1,177 @@
+// Package webhooks decodes the webhooks Plaid sends. It doesn't depend on the plaid
+// package, so webhook receivers can be built without the API client.
+//
+// See https://plaid.com/docs/api/webhooks/.
+package webhooks
+
+import (
+	"encoding/json"
+
+	"github.com/wearevest/plaidgo/plaid/auth"
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// Webhook holds the fields common to every webhook Plaid sends.
+//
+// See https://plaid.com/docs/api/webhooks/.
+type Webhook struct {
+	WebhookType string      `json:"webhook_type"`
+	WebhookCode string      `json:"webhook_code"`
+	ItemID      core.ItemID `json:"item_id"`
+	Error       *core.Error `json:"error"`
+}
+
+// ItemWebhook is sent for changes to the state of an item, e.g. when it enters an error
+// state or its access consent is about to expire.
+//
+// See https://plaid.com/docs/api/items/#webhooks.
+type ItemWebhook struct {
+	Webhook
+	ConsentExpirationTime string `json:"consent_expiration_time"`
+	NewWebhookURL         string `json:"new_webhook_url"`
+}
+
+// TransactionsWebhook is sent when transactions for an item are ready or have changed.
+//
+// See https://plaid.com/docs/api/products/transactions/#webhooks.
+type TransactionsWebhook struct {
+	Webhook
+	NewTransactions     int                  `json:"new_transactions"`
+	RemovedTransactions []core.TransactionID `json:"removed_transactions"`
+}
+
+// AuthVerificationWebhook is sent when the verification status of an account changes.
+//
+// See https://plaid.com/docs/api/products/auth/#webhooks.
+type AuthVerificationWebhook struct {
+	Webhook
+	AccountID core.AccountID `json:"account_id"`
+	// Status is only sent with SMS_MICRODEPOSITS_VERIFICATION webhooks, use
+	// VerificationStatus instead.
+	Status string `json:"status"`
+}
+
+// VerificationStatus returns the verification status the account transitioned to.
+func (w *AuthVerificationWebhook) VerificationStatus() auth.VerificationStatus {
+	switch w.WebhookCode {
+	case "AUTOMATICALLY_VERIFIED":
+		return auth.AutomaticallyVerified
+	case "VERIFICATION_EXPIRED":
+		return auth.VerificationExpired
+	}
+	switch w.Status {
+	case "MANUALLY_VERIFIED":
+		return auth.ManuallyVerified
+	case "VERIFICATION_FAILED":
+		return auth.VerificationFailed
+	}
+	return ""
+}
+
+// LinkSessionFinishedWebhook is sent when a user finishes a Link session. With multi-item
+// Link it carries the public tokens of every item the user linked.
+//
+// See https://plaid.com/docs/api/link/#session_finished.
+type LinkSessionFinishedWebhook struct {
+	Webhook
+	Status        string   `json:"status"` // "SUCCESS" or "EXITED"
+	LinkSessionID string   `json:"link_session_id"`
+	LinkToken     string   `json:"link_token"`
+	PublicTokens  []string `json:"public_tokens"`
+}
+
+// GenericWebhook is returned by Parse for webhooks this package doesn't model,
+// typically because Plaid introduced them after this version of the package. Raw holds the
+// complete body so it can be decoded by the caller.
+type GenericWebhook struct {
+	Webhook
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnknownWebhookError is returned by ParseStrict for webhooks this package doesn't
+// model.
+type UnknownWebhookError struct {
+	WebhookType string
+	WebhookCode string
+}
+
+func (e *UnknownWebhookError) Error() string {
+	return "unknown webhook " + e.WebhookType + ": " + e.WebhookCode
+}
+
+// webhookTypes maps the webhook types and codes this package models to constructors of
+// their struct.
+var webhookTypes = map[string]map[string]func() interface{}{
+	"ITEM": {
+		"ERROR":                       func() interface{} { return &ItemWebhook{} },
+		"LOGIN_REPAIRED":              func() interface{} { return &ItemWebhook{} },
+		"NEW_ACCOUNTS_AVAILABLE":      func() interface{} { return &ItemWebhook{} },
+		"PENDING_DISCONNECT":          func() interface{} { return &ItemWebhook{} },
+		"PENDING_EXPIRATION":          func() interface{} { return &ItemWebhook{} },
+		"USER_PERMISSION_REVOKED":     func() interface{} { return &ItemWebhook{} },
+		"WEBHOOK_UPDATE_ACKNOWLEDGED": func() interface{} { return &ItemWebhook{} },
+	},
+	"TRANSACTIONS": {
+		"INITIAL_UPDATE":                func() interface{} { return &TransactionsWebhook{} },
+		"HISTORICAL_UPDATE":             func() interface{} { return &TransactionsWebhook{} },
+		"DEFAULT_UPDATE":                func() interface{} { return &TransactionsWebhook{} },
+		"TRANSACTIONS_REMOVED":          func() interface{} { return &TransactionsWebhook{} },
+		"SYNC_UPDATES_AVAILABLE":        func() interface{} { return &TransactionsWebhook{} },
+		"RECURRING_TRANSACTIONS_UPDATE": func() interface{} { return &TransactionsWebhook{} },
+	},
+	"AUTH": {
+		"AUTOMATICALLY_VERIFIED":         func() interface{} { return &AuthVerificationWebhook{} },
+		"VERIFICATION_EXPIRED":           func() interface{} { return &AuthVerificationWebhook{} },
+		"SMS_MICRODEPOSITS_VERIFICATION": func() interface{} { return &AuthVerificationWebhook{} },
+	},
+	"LINK": {
+		"SESSION_FINISHED": func() interface{} { return &LinkSessionFinishedWebhook{} },
+	},
+}
+
+// Parse decodes a webhook body into the type matching its webhook_type and
+// webhook_code: an *ItemWebhook, *TransactionsWebhook, *AuthVerificationWebhook or
+// *LinkSessionFinishedWebhook.
+//
+// Webhooks this package doesn't model are returned as a *GenericWebhook rather than an
+// error, so that Plaid introducing new webhook types or codes never breaks a consumer.
+// Use ParseStrict to reject them instead.
+//
+// Bodies that aren't JSON objects with a webhook_type fail with a *core.DecodeError
+// locating the offending value.
+func Parse(body []byte) (interface{}, error) {
+	webhook, err := parse(body)
+	if _, ok := err.(*UnknownWebhookError); ok {
+		generic := &GenericWebhook{Raw: json.RawMessage(body)}
+		if err = core.Decode(body, &generic.Webhook); err != nil {
+			return nil, err
+		}
+		return generic, nil
+	}
+	return webhook, err
+}
+
+// ParseStrict is like Parse but returns an *UnknownWebhookError for webhooks
+// this package doesn't model.
+func ParseStrict(body []byte) (interface{}, error) {
+	return parse(body)
+}
+
+func parse(body []byte) (interface{}, error) {
+	var base Webhook
+	if err := core.Decode(body, &base); err != nil {
+		return nil, err
+	}
+	if base.WebhookType == "" {
+		return nil, core.MissingFieldError("webhook_type")
+	}
+	newWebhook, ok := webhookTypes[base.WebhookType][base.WebhookCode]
+	if !ok {
+		return nil, &UnknownWebhookError{WebhookType: base.WebhookType, WebhookCode: base.WebhookCode}
+	}
+	webhook := newWebhook()
+	if err := core.Decode(body, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
@@ -0,0 +1,46 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/wearevest/plaidgo/plaid/core"
+)
+
+// FuzzParseWebhook checks that no body makes Parse panic, that it returns either a webhook
+// or an error, and that its errors are *core.DecodeErrors. The seed corpus in testdata/fuzz
+// holds malformed variants of real webhooks.
+func FuzzParseWebhook(f *testing.F) {
+	f.Add([]byte(`{"webhook_type": "TRANSACTIONS", "webhook_code": "SYNC_UPDATES_AVAILABLE", "item_id": "x", "initial_update_complete": true}`))
+	f.Add([]byte(`{"webhook_type": "ITEM", "webhook_code": "ERROR", "item_id": "x", "error": {"error_code": "ITEM_LOGIN_REQUIRED"}}`))
+	f.Add([]byte(`{"webhook_type": "LINK", "webhook_code": "SESSION_FINISHED", "status": "SUCCESS", "public_tokens": ["public-x"]}`))
+	f.Fuzz(func(t *testing.T, body []byte) {
+		webhook, err := Parse(body)
+		if (webhook == nil) == (err == nil) {
+			t.Fatalf("got webhook %v and error %v, want exactly one", webhook, err)
+		}
+		var decodeErr *core.DecodeError
+		if err != nil && !errors.As(err, &decodeErr) {
+			t.Fatalf("got %T %v, want a *core.DecodeError", err, err)
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	webhook, err := Parse([]byte(`{"webhook_type": "TRANSACTIONS", "webhook_code": "TRANSACTIONS_REMOVED", "item_id": "x", "removed_transactions": ["a", "b"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	transactions, ok := webhook.(*TransactionsWebhook)
+	if !ok || len(transactions.RemovedTransactions) != 2 || transactions.ItemID != "x" {
+		t.Fatalf("got %#v", webhook)
+	}
+
+	webhook, err = Parse([]byte(`{"webhook_type": "HOLDINGS", "webhook_code": "DEFAULT_UPDATE"}`))
+	if _, ok := webhook.(*GenericWebhook); !ok || err != nil {
+		t.Fatalf("got %#v, %v, want a *GenericWebhook", webhook, err)
+	}
+	if _, err = ParseStrict([]byte(`{"webhook_type": "HOLDINGS", "webhook_code": "DEFAULT_UPDATE"}`)); err == nil {
+		t.Fatal("ParseStrict accepted an unknown webhook")
+	}
+}
@@ -0,0 +1,85 @@
+package plaid
+
+import (
+	"context"
+
+	"github.com/wearevest/plaidgo/plaid/internal/pool"
+)
+
+// WebhookSweepOptions controls a webhook sweep.
+type WebhookSweepOptions struct {
+	// Concurrency is the number of items updated at once. It defaults to 1.
+	Concurrency int
+	// DryRun reports what the sweep would change without updating any item.
+	DryRun bool
+}
+
+// WebhookSweepResult is the outcome of a webhook sweep for one item.
+type WebhookSweepResult struct {
+	AccessToken string
+	ItemID      ItemID
+	PreviousURL string
+	// Updated reports whether the item's webhook was, or in a dry run would be, updated.
+	// Items already pointing at the new URL are left alone.
+	Updated bool
+	Err     error
+}
+
+// SweepWebhooks points the webhook of every item to webhookURL, e.g. when the webhook
+// receiver moves to a new domain. It returns one result per access token in the order of
+// accessTokens; a failure for one item doesn't stop the others. Use SweepErr to combine the
+// failures into one error, or FailedSweeps to pick out the items to retry.
+func (c *Client) SweepWebhooks(accessTokens []string, webhookURL string,
+	options WebhookSweepOptions) []WebhookSweepResult {
+
+	return c.SweepWebhooksContext(context.Background(), accessTokens, webhookURL, options)
+}
+
+// SweepWebhooksContext is like SweepWebhooks but carries a context.
+func (c *Client) SweepWebhooksContext(ctx context.Context, accessTokens []string, webhookURL string,
+	options WebhookSweepOptions) []WebhookSweepResult {
+
+	results := make([]WebhookSweepResult, len(accessTokens))
+	pool.Each(len(accessTokens), options.Concurrency, func(i int) {
+		results[i] = c.sweepWebhook(ctx, accessTokens[i], webhookURL, options.DryRun)
+	})
+	return results
+}
+
+func (c *Client) sweepWebhook(ctx context.Context, accessToken, webhookURL string, dryRun bool) WebhookSweepResult {
+	result := WebhookSweepResult{AccessToken: accessToken}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return result
+	}
+	res, err := c.ItemGetContext(ctx, accessToken)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.ItemID = res.Item.ItemId
+	result.PreviousURL = res.Item.Webhook
+	if res.Item.Webhook == webhookURL {
+		return result
+	}
+	result.Updated = true
+	if dryRun {
+		return result
+	}
+	if _, err := c.ItemWebhookUpdateContext(ctx, accessToken, webhookURL); err != nil {
+		result.Updated = false
+		result.Err = err
+	}
+	return result
+}
+
+// FailedSweeps returns the results of a sweep that failed.
+func FailedSweeps(results []WebhookSweepResult) []WebhookSweepResult {
+	var failed []WebhookSweepResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}